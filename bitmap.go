@@ -0,0 +1,281 @@
+package sysfont
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Bitmap font file signatures.
+const (
+	pcfSignature  = "\x01fcp"
+	bdfSignature  = "STARTFONT"
+	gzipSignature = "\x1f\x8b"
+)
+
+// PCF table types and formats.
+const (
+	pcfProperties    = 1 << 0
+	pcfFormatMask    = 0xFFFFFF00
+	pcfDefaultFormat = 0x00000000
+	pcfByteMask      = 1 << 2
+)
+
+// gzipMaxSize is the maximum decompressed size of gzip-compressed font files.
+const gzipMaxSize = 1 << 26
+
+// hasSignature returns true if the specified data starts with the specified
+// signature.
+func hasSignature(r io.ReaderAt, signature string) bool {
+	buf := make([]byte, len(signature))
+	if _, err := r.ReadAt(buf, 0); err != nil {
+		return false
+	}
+
+	return string(buf) == signature
+}
+
+// readGzipData returns the decompressed contents of the specified
+// gzip-compressed data.
+func readGzipData(r io.ReaderAt) (*bytes.Reader, error) {
+	zr, err := gzip.NewReader(io.NewSectionReader(r, 0, 1<<62))
+	if err != nil {
+		return nil, errInvalidFont
+	}
+	defer zr.Close()
+
+	data, err := io.ReadAll(io.LimitReader(zr, gzipMaxSize))
+	if err != nil {
+		return nil, errInvalidFont
+	}
+
+	return bytes.NewReader(data), nil
+}
+
+// isBitmapFont returns true if the specified data contains a PCF or a BDF
+// bitmap font.
+func isBitmapFont(r io.ReaderAt) bool {
+	return hasSignature(r, pcfSignature) || hasSignature(r, bdfSignature)
+}
+
+// readBitmapFont identifies the PCF or BDF bitmap font contained in the
+// specified font data, having the specified size, using the properties of
+// the font.
+func readBitmapFont(r io.ReaderAt, size int64, filename string) ([]*Font, error) {
+	var props map[string]string
+	var err error
+	if hasSignature(r, pcfSignature) {
+		props, err = readPCFProperties(r, size)
+	} else {
+		props, err = readBDFProperties(r)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	font, err := newBitmapFont(props)
+	if err != nil {
+		return nil, err
+	}
+	font.Filename = filename
+
+	return []*Font{font}, nil
+}
+
+// readPCFProperties returns the properties contained in the properties
+// table of the specified PCF font, having the specified size. Integer
+// properties are converted to strings.
+func readPCFProperties(r io.ReaderAt, dataSize int64) (map[string]string, error) {
+	// Read table of contents.
+	var header [8]byte
+	if _, err := r.ReadAt(header[:], 0); err != nil {
+		return nil, errInvalidFont
+	}
+
+	numTables := int(binary.LittleEndian.Uint32(header[4:]))
+	if numTables > 0xFF {
+		return nil, errInvalidFont
+	}
+
+	toc := make([]byte, numTables*16)
+	if _, err := r.ReadAt(toc, 8); err != nil {
+		return nil, errInvalidFont
+	}
+
+	var offset, size int64
+	for i := 0; i < numTables; i++ {
+		entry := toc[i*16:]
+		if binary.LittleEndian.Uint32(entry) == pcfProperties {
+			size = int64(binary.LittleEndian.Uint32(entry[8:]))
+			offset = int64(binary.LittleEndian.Uint32(entry[12:]))
+			break
+		}
+	}
+	if size < 8 {
+		return nil, errMissingTable
+	}
+	if offset+size > dataSize {
+		return nil, errInvalidFont
+	}
+
+	// Read properties table. The format of the table is always stored in
+	// little endian byte order.
+	data := make([]byte, size)
+	if _, err := r.ReadAt(data, offset); err != nil {
+		return nil, errInvalidFont
+	}
+
+	format := binary.LittleEndian.Uint32(data)
+	if format&pcfFormatMask != pcfDefaultFormat {
+		return nil, errUnsupportedFont
+	}
+
+	var order binary.ByteOrder = binary.LittleEndian
+	if format&pcfByteMask != 0 {
+		order = binary.BigEndian
+	}
+
+	// The property entries are padded to a multiple of 4 bytes and are
+	// followed by the size of the string pool and the string pool itself.
+	numProps := int(order.Uint32(data[4:]))
+	if numProps < 0 || numProps > len(data) {
+		return nil, errInvalidFont
+	}
+
+	poolOffset := 8 + numProps*9
+	if numProps&3 != 0 {
+		poolOffset += 4 - numProps&3
+	}
+	if poolOffset+4 > len(data) {
+		return nil, errInvalidFont
+	}
+	pool := data[poolOffset+4:]
+
+	str := func(offset uint32) string {
+		if int(offset) >= len(pool) {
+			return ""
+		}
+		if end := bytes.IndexByte(pool[offset:], 0); end >= 0 {
+			return string(pool[offset : int(offset)+end])
+		}
+		return string(pool[offset:])
+	}
+
+	props := make(map[string]string, numProps)
+	for i := 0; i < numProps; i++ {
+		prop := data[8+i*9:]
+
+		value := order.Uint32(prop[5:])
+		if prop[4] != 0 {
+			props[str(order.Uint32(prop))] = str(value)
+		} else {
+			props[str(order.Uint32(prop))] = strconv.Itoa(int(int32(value)))
+		}
+	}
+
+	return props, nil
+}
+
+// readBDFProperties returns the properties of the specified BDF font,
+// along with its name, stored as the FONT property.
+func readBDFProperties(r io.ReaderAt) (map[string]string, error) {
+	props := map[string]string{}
+
+	scanner := bufio.NewScanner(io.NewSectionReader(r, 0, 1<<62))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "ENDPROPERTIES" || strings.HasPrefix(line, "CHARS") {
+			break
+		}
+
+		fields := strings.SplitN(line, " ", 2)
+		if len(fields) != 2 {
+			continue
+		}
+
+		// Unquote string values. Quotes are escaped by doubling them.
+		value := strings.TrimSpace(fields[1])
+		if len(value) > 1 && value[0] == '"' && value[len(value)-1] == '"' {
+			value = strings.ReplaceAll(value[1:len(value)-1], `""`, `"`)
+		}
+		if _, ok := props[fields[0]]; !ok {
+			props[fields[0]] = value
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errInvalidFont
+	}
+	if props["FONT"] == "" && props["FAMILY_NAME"] == "" {
+		return nil, errInvalidFont
+	}
+
+	return props, nil
+}
+
+// newBitmapFont returns a font based on the specified X11 font properties.
+// Properties missing from the font are extracted from the X logical font
+// description of the font, stored as the FONT property. If the font has no
+// description, one is composed from its properties.
+func newBitmapFont(props map[string]string) (*Font, error) {
+	name := props["FONT"]
+	fields, ok := splitXLFD(name)
+	if !ok {
+		for i, prop := range xlfdProperties {
+			fields[i] = props[prop]
+		}
+		name = formatXLFD(fields)
+	}
+	for i, prop := range xlfdProperties {
+		if value := props[prop]; value != "" {
+			fields[i] = value
+		}
+	}
+
+	family := fields[xlfdFamily]
+	if family == "" {
+		return nil, errInvalidFont
+	}
+	pixelSize, _ := strconv.Atoi(fields[xlfdPixelSize])
+
+	// Identify font style attributes.
+	weight := parseXLFDWeight(fields[xlfdWeight])
+	stretch := parseXLFDStretch(fields[xlfdSetwidth])
+	style := parseXLFDSlant(fields[xlfdSlant])
+
+	// Compose the name of the font from its family and style attributes.
+	words := []string{family}
+	if weight != WeightNormal {
+		words = append(words, titleCase(fields[xlfdWeight]))
+	}
+	if stretch != StretchNormal {
+		words = append(words, titleCase(fields[xlfdSetwidth]))
+	}
+	if style != StyleNormal {
+		words = append(words, titleCase(style.String()))
+	}
+
+	return &Font{
+		Family:    family,
+		Name:      strings.Join(words, " "),
+		Weight:    weight,
+		Stretch:   stretch,
+		Style:     style,
+		PixelSize: pixelSize,
+		XLFD:      name,
+	}, nil
+}
+
+// titleCase returns the specified string with the first letter of each word
+// in upper case.
+func titleCase(s string) string {
+	words := strings.Fields(s)
+	for i, word := range words {
+		words[i] = strings.ToUpper(word[:1]) + word[1:]
+	}
+
+	return strings.Join(words, " ")
+}
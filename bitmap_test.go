@@ -0,0 +1,443 @@
+package sysfont
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+// testPCFProperty represents a property of a PCF font built for testing.
+// The value is either a string or an int32.
+type testPCFProperty struct {
+	name  string
+	value interface{}
+}
+
+// buildPCF returns a PCF font whose properties table contains the specified
+// properties, stored in the specified byte order. The properties table is
+// preceded by an empty table.
+func buildPCF(order binary.ByteOrder, props ...testPCFProperty) []byte {
+	// Build string pool.
+	var pool []byte
+	addString := func(s string) uint32 {
+		offset := uint32(len(pool))
+		pool = append(append(pool, s...), 0)
+		return offset
+	}
+
+	// Build properties table.
+	format := uint32(pcfDefaultFormat)
+	if order == binary.BigEndian {
+		format |= pcfByteMask
+	}
+	table := make([]byte, 8, 8+len(props)*9+8)
+	binary.LittleEndian.PutUint32(table, format)
+	order.PutUint32(table[4:], uint32(len(props)))
+
+	for _, prop := range props {
+		entry := make([]byte, 9)
+		order.PutUint32(entry, addString(prop.name))
+		switch value := prop.value.(type) {
+		case string:
+			entry[4] = 1
+			order.PutUint32(entry[5:], addString(value))
+		case int32:
+			order.PutUint32(entry[5:], uint32(value))
+		}
+		table = append(table, entry...)
+	}
+	if len(props)&3 != 0 {
+		table = append(table, make([]byte, 4-len(props)&3)...)
+	}
+	table = append(table, 0, 0, 0, 0)
+	order.PutUint32(table[len(table)-4:], uint32(len(pool)))
+	table = append(table, pool...)
+
+	// Build table of contents.
+	data := make([]byte, 8+2*16)
+	copy(data, pcfSignature)
+	binary.LittleEndian.PutUint32(data[4:], 2)
+	binary.LittleEndian.PutUint32(data[8:], 1<<3)
+	binary.LittleEndian.PutUint32(data[24:], pcfProperties)
+	binary.LittleEndian.PutUint32(data[28:], format)
+	binary.LittleEndian.PutUint32(data[32:], uint32(len(table)))
+	binary.LittleEndian.PutUint32(data[36:], uint32(len(data)))
+
+	return append(data, table...)
+}
+
+// patchPCF returns a copy of the specified PCF font, having the 32-bit value
+// at the specified offset replaced.
+func patchPCF(data []byte, offset int, order binary.ByteOrder, value uint32) []byte {
+	data = append([]byte(nil), data...)
+	order.PutUint32(data[offset:], value)
+
+	return data
+}
+
+const testXLFD = "-misc-fixed-bold-o-semicondensed--13-120-75-75-c-70-iso8859-1"
+
+func TestReadPCFProperties(t *testing.T) {
+	props := []testPCFProperty{
+		{"FONT", testXLFD},
+		{"FAMILY_NAME", "Fixed"},
+		{"PIXEL_SIZE", int32(13)},
+		{"FONT_DESCENT", int32(-2)},
+		{"COPYRIGHT", ""},
+	}
+	want := map[string]string{
+		"FONT":         testXLFD,
+		"FAMILY_NAME":  "Fixed",
+		"PIXEL_SIZE":   "13",
+		"FONT_DESCENT": "-2",
+		"COPYRIGHT":    "",
+	}
+	le := buildPCF(binary.LittleEndian, props...)
+
+	// The properties table starts after the table of contents, at offset 40.
+	tests := []struct {
+		name  string
+		data  []byte
+		props map[string]string
+		err   error
+	}{
+		{
+			name:  "little endian",
+			data:  le,
+			props: want,
+		},
+		{
+			name:  "big endian",
+			data:  buildPCF(binary.BigEndian, props...),
+			props: want,
+		},
+		{
+			name:  "no properties",
+			data:  buildPCF(binary.LittleEndian),
+			props: map[string]string{},
+		},
+		{
+			name: "string offset out of range",
+			data: patchPCF(le, 40+8+5, binary.LittleEndian, 0xFFFF),
+			props: map[string]string{
+				"FONT":         "",
+				"FAMILY_NAME":  "Fixed",
+				"PIXEL_SIZE":   "13",
+				"FONT_DESCENT": "-2",
+				"COPYRIGHT":    "",
+			},
+		},
+		{
+			name: "truncated header",
+			data: le[:6],
+			err:  errInvalidFont,
+		},
+		{
+			name: "oversized table count",
+			data: patchPCF(le, 4, binary.LittleEndian, 0x100),
+			err:  errInvalidFont,
+		},
+		{
+			name: "truncated table of contents",
+			data: le[:30],
+			err:  errInvalidFont,
+		},
+		{
+			name: "missing properties table",
+			data: patchPCF(le, 24, binary.LittleEndian, 1<<1),
+			err:  errMissingTable,
+		},
+		{
+			name: "oversized properties table",
+			data: patchPCF(le, 32, binary.LittleEndian, 0xFFFFFFF0),
+			err:  errInvalidFont,
+		},
+		{
+			name: "properties table exceeds data",
+			data: patchPCF(le, 32, binary.LittleEndian, uint32(len(le)-40+1)),
+			err:  errInvalidFont,
+		},
+		{
+			name: "undersized properties table",
+			data: patchPCF(le, 32, binary.LittleEndian, 4),
+			err:  errMissingTable,
+		},
+		{
+			name: "properties table out of bounds",
+			data: patchPCF(le, 36, binary.LittleEndian, 0xFFFFFFF0),
+			err:  errInvalidFont,
+		},
+		{
+			name: "truncated properties table",
+			data: le[:len(le)-1],
+			err:  errInvalidFont,
+		},
+		{
+			name: "unsupported format",
+			data: patchPCF(le, 40, binary.LittleEndian, 0x100),
+			err:  errUnsupportedFont,
+		},
+		{
+			name: "oversized property count",
+			data: patchPCF(le, 44, binary.LittleEndian, 0xFFFFFFFF),
+			err:  errInvalidFont,
+		},
+		{
+			name: "property count exceeds table",
+			data: patchPCF(le, 44, binary.LittleEndian, 40),
+			err:  errInvalidFont,
+		},
+	}
+
+	for _, test := range tests {
+		props, err := readPCFProperties(bytes.NewReader(test.data), int64(len(test.data)))
+		if !errors.Is(err, test.err) {
+			t.Errorf("%s: got error %v, want %v", test.name, err, test.err)
+			continue
+		}
+		if err == nil && !reflect.DeepEqual(props, test.props) {
+			t.Errorf("%s: got properties %q, want %q", test.name, props, test.props)
+		}
+	}
+}
+
+func TestReadBDFProperties(t *testing.T) {
+	tests := []struct {
+		name  string
+		data  string
+		props map[string]string
+		err   error
+	}{
+		{
+			name: "properties",
+			data: `STARTFONT 2.1
+FONT ` + testXLFD + `
+SIZE 13 75 75
+STARTPROPERTIES 4
+FAMILY_NAME "Fixed"
+COPYRIGHT "The ""Fixed"" font"
+PIXEL_SIZE 13
+FAMILY_NAME "Ignored"
+ENDPROPERTIES
+FOUNDRY "Ignored"
+CHARS 0
+ENDFONT
+`,
+			props: map[string]string{
+				"STARTFONT":       "2.1",
+				"FONT":            testXLFD,
+				"SIZE":            "13 75 75",
+				"STARTPROPERTIES": "4",
+				"FAMILY_NAME":     "Fixed",
+				"COPYRIGHT":       `The "Fixed" font`,
+				"PIXEL_SIZE":      "13",
+			},
+		},
+		{
+			name:  "family name",
+			data:  "STARTFONT 2.1\nFAMILY_NAME \"\"\"\"\nCHARS 1\nFONT ignored\n",
+			props: map[string]string{"STARTFONT": "2.1", "FAMILY_NAME": `"`},
+		},
+		{
+			name: "missing names",
+			data: "STARTFONT 2.1\nCHARS 0\nFONT ignored\n",
+			err:  errInvalidFont,
+		},
+		{
+			name: "empty",
+			data: "",
+			err:  errInvalidFont,
+		},
+		{
+			name: "oversized line",
+			data: "STARTFONT 2.1\nFONT " + testXLFD + "\nCOMMENT " + strings.Repeat("x", 1<<17) + "\n",
+			err:  errInvalidFont,
+		},
+	}
+
+	for _, test := range tests {
+		props, err := readBDFProperties(strings.NewReader(test.data))
+		if !errors.Is(err, test.err) {
+			t.Errorf("%s: got error %v, want %v", test.name, err, test.err)
+			continue
+		}
+		if err == nil && !reflect.DeepEqual(props, test.props) {
+			t.Errorf("%s: got properties %q, want %q", test.name, props, test.props)
+		}
+	}
+}
+
+// gzipData returns the specified data, compressed using gzip.
+func gzipData(data []byte) []byte {
+	var buf bytes.Buffer
+	zw, _ := gzip.NewWriterLevel(&buf, gzip.BestSpeed)
+	zw.Write(data)
+	zw.Close()
+
+	return buf.Bytes()
+}
+
+func TestReadGzipData(t *testing.T) {
+	data := []byte("STARTFONT 2.1\nFONT " + testXLFD + "\n")
+	compressed := gzipData(data)
+
+	tests := []struct {
+		name string
+		data []byte
+		size int
+		err  error
+	}{
+		{name: "compressed", data: compressed, size: len(data)},
+		{name: "not compressed", data: data, err: errInvalidFont},
+		{name: "truncated header", data: compressed[:5], err: errInvalidFont},
+		{name: "truncated data", data: compressed[:len(compressed)-10], err: errInvalidFont},
+	}
+	if !testing.Short() {
+		tests = append(tests, struct {
+			name string
+			data []byte
+			size int
+			err  error
+		}{name: "oversized data", data: gzipData(make([]byte, gzipMaxSize+1)), size: gzipMaxSize})
+	}
+
+	for _, test := range tests {
+		r, err := readGzipData(bytes.NewReader(test.data))
+		if !errors.Is(err, test.err) {
+			t.Errorf("%s: got error %v, want %v", test.name, err, test.err)
+			continue
+		}
+		if err == nil && r.Size() != int64(test.size) {
+			t.Errorf("%s: got %d bytes, want %d", test.name, r.Size(), test.size)
+		}
+	}
+}
+
+func TestNewBitmapFont(t *testing.T) {
+	tests := []struct {
+		name  string
+		props map[string]string
+		font  *Font
+		err   error
+	}{
+		{
+			name:  "font name",
+			props: map[string]string{"FONT": testXLFD},
+			font: &Font{
+				Family:    "fixed",
+				Name:      "fixed Bold Semicondensed Oblique",
+				Weight:    WeightBold,
+				Stretch:   StretchSemiCondensed,
+				Style:     StyleOblique,
+				PixelSize: 13,
+				XLFD:      testXLFD,
+			},
+		},
+		{
+			name: "properties",
+			props: map[string]string{
+				"FONT":        testXLFD,
+				"FAMILY_NAME": "Fixed",
+				"WEIGHT_NAME": "Medium",
+				"SLANT":       "RI",
+				"PIXEL_SIZE":  "14",
+			},
+			font: &Font{
+				Family:    "Fixed",
+				Name:      "Fixed Semicondensed Italic",
+				Weight:    WeightNormal,
+				Stretch:   StretchSemiCondensed,
+				Style:     StyleItalic,
+				PixelSize: 14,
+				XLFD:      testXLFD,
+			},
+		},
+		{
+			name: "composed font name",
+			props: map[string]string{
+				"FONT":             "terminus-16",
+				"FOUNDRY":          "xos4",
+				"FAMILY_NAME":      "Terminus",
+				"WEIGHT_NAME":      "demi bold",
+				"SLANT":            "r",
+				"PIXEL_SIZE":       "16",
+				"CHARSET_REGISTRY": "ISO10646",
+				"CHARSET_ENCODING": "1",
+			},
+			font: &Font{
+				Family:    "Terminus",
+				Name:      "Terminus Demi Bold",
+				Weight:    WeightSemiBold,
+				Stretch:   StretchNormal,
+				Style:     StyleNormal,
+				PixelSize: 16,
+				XLFD:      "-xos4-Terminus-demi bold-r---16------ISO10646-1",
+			},
+		},
+		{
+			name:  "missing family",
+			props: map[string]string{"FONT": "fixed", "WEIGHT_NAME": "Bold"},
+			err:   errInvalidFont,
+		},
+	}
+
+	for _, test := range tests {
+		font, err := newBitmapFont(test.props)
+		if !errors.Is(err, test.err) {
+			t.Errorf("%s: got error %v, want %v", test.name, err, test.err)
+			continue
+		}
+		if err == nil && !reflect.DeepEqual(font, test.font) {
+			t.Errorf("%s: got font %+v, want %+v", test.name, font, test.font)
+		}
+	}
+}
+
+func TestReadBitmapFontFile(t *testing.T) {
+	pcf := buildPCF(binary.BigEndian, testPCFProperty{"FONT", testXLFD})
+	fsys := fstest.MapFS{
+		"fixed.pcf":    {Data: pcf},
+		"fixed.pcf.gz": {Data: gzipData(pcf)},
+		"fixed.bdf":    {Data: []byte("STARTFONT 2.1\nFONT " + testXLFD + "\nCHARS 0\n")},
+		"invalid.gz":   {Data: gzipData([]byte("\x01fcp"))},
+	}
+
+	tests := []struct {
+		file      string
+		container FontContainer
+		err       error
+	}{
+		{"fixed.pcf", ContainerNone, nil},
+		{"fixed.pcf.gz", ContainerGzip, nil},
+		{"fixed.bdf", ContainerNone, nil},
+		{"invalid.gz", ContainerNone, errInvalidFont},
+	}
+
+	for _, test := range tests {
+		fonts, err := ReadFontFileFS(fsys, test.file)
+		if !errors.Is(err, test.err) {
+			t.Errorf("%s: got error %v, want %v", test.file, err, test.err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+
+		if len(fonts) != 1 {
+			t.Errorf("%s: got %d fonts, want 1", test.file, len(fonts))
+			continue
+		}
+		font := fonts[0]
+		if font.Name != "fixed Bold Semicondensed Oblique" || font.XLFD != testXLFD {
+			t.Errorf("%s: got font %q with name %q", test.file, font.Name, font.XLFD)
+		}
+		if font.Filename != test.file || font.Container != test.container {
+			t.Errorf("%s: got file %q with container %s, want container %s", test.file, font.Filename, font.Container, test.container)
+		}
+	}
+}
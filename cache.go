@@ -0,0 +1,279 @@
+package sysfont
+
+import (
+	"encoding/gob"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// cacheVersion is the version of the cache file format. Cache files with a
+// different version are ignored.
+const cacheVersion = 3
+
+// cacheEntry contains the fonts identified in a font file, along with the
+// file attributes used for detecting changes.
+type cacheEntry struct {
+	Size    int64
+	ModTime int64
+	Fonts   []*cacheFont
+}
+
+// cacheFont contains the cached data of a font.
+type cacheFont struct {
+	Font     Font
+	Coverage coverage
+}
+
+// cacheDir contains the entries of a directory, along with its modification
+// time, used for detecting added and removed entries. The cached font files
+// of the directory are indexed by file name.
+type cacheDir struct {
+	ModTime int64
+	Names   []string
+	Files   map[string]*cacheEntry
+}
+
+// cacheData represents the contents of a cache file. The directories are
+// indexed by path, including the trailing separator.
+type cacheData struct {
+	Version int
+	Dirs    map[string]*cacheDir
+}
+
+// fontCache stores identified fonts between scans. A nil cache is valid and
+// caches nothing. The cache can be used concurrently.
+type fontCache struct {
+	mu      sync.Mutex
+	path    string
+	loaded  map[string]*cacheDir
+	updated map[string]*cacheDir
+	changed bool
+}
+
+// loadFontCache loads the cache file found at the specified path. If the
+// file does not exist or cannot be read, an empty cache is returned.
+func loadFontCache(path string) *fontCache {
+	cache := &fontCache{
+		path:    path,
+		loaded:  map[string]*cacheDir{},
+		updated: map[string]*cacheDir{},
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return cache
+	}
+	defer f.Close()
+
+	var data cacheData
+	if err := gob.NewDecoder(f).Decode(&data); err != nil || data.Version != cacheVersion {
+		return cache
+	}
+	for dir, entry := range data.Dirs {
+		if entry != nil {
+			cache.loaded[dir] = entry
+		}
+	}
+
+	return cache
+}
+
+// dirNames returns the cached entry names of the specified directory. The
+// names are returned only if the modification time of the directory has not
+// changed since they were cached.
+func (c *fontCache) dirNames(dir string, info os.FileInfo) ([]string, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	entry, ok := c.loaded[cacheDirKey(dir)]
+	c.mu.Unlock()
+	if !ok || entry.Names == nil || entry.ModTime != info.ModTime().UnixNano() {
+		return nil, false
+	}
+
+	return entry.Names, true
+}
+
+// addDir adds the entry names of the specified directory to the cache.
+func (c *fontCache) addDir(dir string, info os.FileInfo, names []string) {
+	if c == nil {
+		return
+	}
+
+	if names == nil {
+		names = []string{}
+	}
+	key := cacheDirKey(dir)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := c.updatedDir(key)
+	entry.ModTime = info.ModTime().UnixNano()
+	entry.Names = names
+
+	// Mark the cache as changed if the directory was not cached or has
+	// changed.
+	if loaded, ok := c.loaded[key]; !ok || loaded.Names == nil || loaded.ModTime != entry.ModTime {
+		c.changed = true
+	}
+}
+
+// fonts returns the cached fonts of the specified file. The cached fonts are
+// returned only if the size and the modification time of the file have not
+// changed since the fonts were cached.
+func (c *fontCache) fonts(filename string, info os.FileInfo) ([]*Font, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	dir, base := filepath.Split(filename)
+
+	var entry *cacheEntry
+	c.mu.Lock()
+	if loaded, ok := c.loaded[dir]; ok {
+		entry = loaded.Files[base]
+	}
+	c.mu.Unlock()
+	if entry == nil || entry.Size != info.Size() || entry.ModTime != info.ModTime().UnixNano() {
+		return nil, false
+	}
+
+	fonts := make([]*Font, len(entry.Fonts))
+	for i, cached := range entry.Fonts {
+		font := cached.Font
+		font.coverage = cached.Coverage
+		fonts[i] = &font
+	}
+
+	return fonts, true
+}
+
+// add adds the fonts identified in the specified file to the cache.
+func (c *fontCache) add(filename string, info os.FileInfo, fonts []*Font) {
+	if c == nil {
+		return
+	}
+
+	entry := &cacheEntry{
+		Size:    info.Size(),
+		ModTime: info.ModTime().UnixNano(),
+		Fonts:   make([]*cacheFont, len(fonts)),
+	}
+	for i, font := range fonts {
+		entry.Fonts[i] = &cacheFont{Font: *font, Coverage: font.coverage}
+	}
+
+	dir, base := filepath.Split(filename)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.updatedDir(dir).Files[base] = entry
+
+	// Mark the cache as changed if the file was not cached or has changed.
+	var loaded *cacheEntry
+	if loadedDir, ok := c.loaded[dir]; ok {
+		loaded = loadedDir.Files[base]
+	}
+	if loaded == nil || loaded.Size != entry.Size || loaded.ModTime != entry.ModTime {
+		c.changed = true
+	}
+}
+
+// updatedDir returns the updated entry of the specified directory, creating
+// it if necessary. It must be called with the mutex of the cache locked.
+func (c *fontCache) updatedDir(key string) *cacheDir {
+	entry, ok := c.updated[key]
+	if !ok {
+		entry = &cacheDir{Files: map[string]*cacheEntry{}}
+		c.updated[key] = entry
+	}
+
+	return entry
+}
+
+// save writes the entries added during the current scan to the cache file,
+// along with the loaded entries of the directories which were not traversed
+// (e.g. directories found in other search paths). Loaded entries of files
+// which were not found in the traversed directories and of directories which
+// no longer exist are discarded. The file is written only if the cached data
+// has changed.
+func (c *fontCache) save() error {
+	if c == nil {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Merge the loaded entries which were not updated. The files of the
+	// traversed directories are not checked again, as they were all added
+	// during the traversal, if they still exist.
+	changed := c.changed
+	for dir, loaded := range c.loaded {
+		updated, ok := c.updated[dir]
+		if ok && updated.Names != nil {
+			for base := range loaded.Files {
+				if _, ok := updated.Files[base]; !ok {
+					changed = true
+				}
+			}
+			continue
+		}
+		if _, err := os.Stat(dir); os.IsNotExist(err) {
+			changed = true
+			continue
+		}
+
+		updated = c.updatedDir(dir)
+		if updated.Names == nil {
+			updated.ModTime, updated.Names = loaded.ModTime, loaded.Names
+		}
+		for base, entry := range loaded.Files {
+			if _, ok := updated.Files[base]; !ok {
+				updated.Files[base] = entry
+			}
+		}
+	}
+	if !changed {
+		return nil
+	}
+
+	// Write cache data to a temporary file and move it to the cache path.
+	if err := os.MkdirAll(filepath.Dir(c.path), 0755); err != nil {
+		return err
+	}
+
+	f, err := ioutil.TempFile(filepath.Dir(c.path), filepath.Base(c.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	data := &cacheData{Version: cacheVersion, Dirs: c.updated}
+	if err := gob.NewEncoder(f).Encode(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), c.path)
+}
+
+// cacheDirKey returns the key of the specified directory in the cache, which
+// ends in a path separator, like the directories returned by filepath.Split.
+func cacheDirKey(dir string) string {
+	if dir = filepath.Clean(dir); !strings.HasSuffix(dir, string(filepath.Separator)) {
+		dir += string(filepath.Separator)
+	}
+
+	return dir
+}
@@ -0,0 +1,261 @@
+package sysfont
+
+import (
+	"encoding/gob"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/adrg/strutil"
+)
+
+func TestFontCache(t *testing.T) {
+	dir := t.TempDir()
+	cachePath := filepath.Join(dir, "cache", "fonts.cache")
+	fontDir := filepath.Join(dir, "fonts")
+	if err := os.Mkdir(fontDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	// Create font files.
+	files := map[string]string{}
+	for _, name := range []string{"a.ttf", "b.ttf", "c.ttf"} {
+		files[name] = filepath.Join(fontDir, name)
+		if err := os.WriteFile(files[name], []byte(name), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	stat := func(path string) os.FileInfo {
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return info
+	}
+	font := func(name string) []*Font {
+		return []*Font{{
+			Family:   "Test",
+			Name:     name,
+			Filename: files[name],
+			coverage: coverage{{'a', 'z'}},
+		}}
+	}
+
+	// scan adds the specified directory entries to the cache, along with
+	// the fonts of the files which are not cached, and saves the cache.
+	scan := func(cache *fontCache, names []string, uncached ...string) {
+		t.Helper()
+		cache.addDir(fontDir, stat(fontDir), names)
+		for _, name := range names {
+			fonts, ok := cache.fonts(files[name], stat(files[name]))
+			if ok == strutil.SliceContains(uncached, name) {
+				t.Errorf("%s: got cached fonts %t", name, ok)
+			}
+			if !ok {
+				fonts = font(name)
+			}
+			cache.add(files[name], stat(files[name]), fonts)
+		}
+		if err := cache.save(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Cache all files.
+	cache := loadFontCache(cachePath)
+	if _, ok := cache.dirNames(fontDir, stat(fontDir)); ok {
+		t.Fatal("unexpected cached directory in empty cache")
+	}
+	scan(cache, []string{"a.ttf", "b.ttf", "c.ttf"}, "a.ttf", "b.ttf", "c.ttf")
+
+	// Change a.ttf and remove b.ttf. The directory entries are read again,
+	// as the modification time of the directory changes.
+	if err := os.Chtimes(files["a.ttf"], time.Now(), time.Now().Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(files["b.ttf"]); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(fontDir, time.Now(), time.Now().Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+
+	cache = loadFontCache(cachePath)
+	if _, ok := cache.dirNames(fontDir, stat(fontDir)); ok {
+		t.Fatal("unexpected cached entries for changed directory")
+	}
+	scan(cache, []string{"a.ttf", "c.ttf"}, "a.ttf")
+
+	// Check cache contents.
+	cache = loadFontCache(cachePath)
+	if names, ok := cache.dirNames(fontDir, stat(fontDir)); !ok || !reflect.DeepEqual(names, []string{"a.ttf", "c.ttf"}) {
+		t.Errorf("got cached directory entries %q, want a.ttf and c.ttf", names)
+	}
+	for _, name := range []string{"a.ttf", "c.ttf"} {
+		fonts, ok := cache.fonts(files[name], stat(files[name]))
+		if !ok {
+			t.Errorf("%s: missing cached fonts", name)
+			continue
+		}
+		if len(fonts) != 1 || fonts[0].Name != name || !fonts[0].Covers('q') {
+			t.Errorf("%s: got cached fonts %v", name, fonts)
+		}
+	}
+
+	dirEntry := cache.loaded[fontDir+string(filepath.Separator)]
+	if _, ok := dirEntry.Files["b.ttf"]; ok || len(dirEntry.Files) != 2 {
+		t.Errorf("got cache entries %v, want entries for a.ttf and c.ttf", dirEntry.Files)
+	}
+
+	// Directories which are not traversed are kept, without checking their
+	// files, and the cache file is not written if nothing changed.
+	modTime := stat(cachePath).ModTime()
+	if err := os.Chtimes(cachePath, modTime, modTime.Add(-time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if err := loadFontCache(cachePath).save(); err != nil {
+		t.Fatal(err)
+	}
+	if !stat(cachePath).ModTime().Equal(modTime.Add(-time.Hour)) {
+		t.Error("unchanged cache file was written")
+	}
+	if cache = loadFontCache(cachePath); len(cache.loaded) != 1 {
+		t.Errorf("got cached directories %v, want %s", cache.loaded, fontDir)
+	}
+
+	// Directories which no longer exist are discarded.
+	if err := os.RemoveAll(fontDir); err != nil {
+		t.Fatal(err)
+	}
+	if err := loadFontCache(cachePath).save(); err != nil {
+		t.Fatal(err)
+	}
+	if cache = loadFontCache(cachePath); len(cache.loaded) != 0 {
+		t.Errorf("got cached directories %v, want none", cache.loaded)
+	}
+}
+
+func TestLoadFontCacheInvalid(t *testing.T) {
+	dir := t.TempDir()
+
+	writeCache := func(name string, data interface{}) string {
+		path := filepath.Join(dir, name)
+		f, err := os.Create(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+
+		if data != nil {
+			if err := gob.NewEncoder(f).Encode(data); err != nil {
+				t.Fatal(err)
+			}
+		} else if _, err := f.WriteString("not a cache file"); err != nil {
+			t.Fatal(err)
+		}
+
+		return path
+	}
+
+	entries := map[string]*cacheDir{
+		"/fonts/": {Names: []string{"a.ttf"}, Files: map[string]*cacheEntry{"a.ttf": {Size: 1}}},
+	}
+
+	tests := []struct {
+		name string
+		path string
+	}{
+		{"missing file", filepath.Join(dir, "missing.cache")},
+		{"corrupt file", writeCache("corrupt.cache", nil)},
+		{"other version", writeCache("version.cache", &cacheData{Version: cacheVersion + 1, Dirs: entries})},
+	}
+
+	for _, test := range tests {
+		cache := loadFontCache(test.path)
+		if len(cache.loaded) != 0 || len(cache.updated) != 0 {
+			t.Errorf("%s: got non-empty cache", test.name)
+		}
+	}
+
+	// A nil cache caches nothing.
+	var cache *fontCache
+	cache.add("a.ttf", nil, nil)
+	if _, ok := cache.fonts("a.ttf", nil); ok {
+		t.Error("nil cache: unexpected cached fonts")
+	}
+	cache.addDir("fonts", nil, nil)
+	if _, ok := cache.dirNames("fonts", nil); ok {
+		t.Error("nil cache: unexpected cached directory entries")
+	}
+	if err := cache.save(); err != nil {
+		t.Errorf("nil cache: unexpected error: %v", err)
+	}
+}
+
+func TestFinderCache(t *testing.T) {
+	dir := t.TempDir()
+	cachePath := filepath.Join(dir, "fonts.cache")
+	fontDir := filepath.Join(dir, "fonts")
+	if err := os.MkdirAll(filepath.Join(fontDir, "sub"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	writeFont := func(name, family string) {
+		t.Helper()
+		data := "STARTFONT 2.1\nFONT -misc-" + family + "-medium-r-normal--13-120-75-75-p-70-iso10646-1\nCHARS 0\nENDFONT\n"
+		if err := os.WriteFile(filepath.Join(fontDir, name), []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	scan := func() []string {
+		t.Helper()
+		finder := NewFinder(&FinderOpts{
+			SearchPaths: []string{fontDir},
+			Extensions:  []string{".bdf"},
+			UseCache:    true,
+			CacheFile:   cachePath,
+		})
+		if errs := finder.Errors(); len(errs) != 0 {
+			t.Fatalf("got errors %v", errs)
+		}
+
+		var families []string
+		for _, font := range finder.List() {
+			families = append(families, font.Family)
+		}
+		return families
+	}
+	resetModTime := func(path string, modTime time.Time) {
+		t.Helper()
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	writeFont("a.bdf", "Alpha")
+	writeFont("sub/b.bdf", "Beta")
+	modTime := time.Now().Add(-time.Hour)
+	resetModTime(fontDir, modTime)
+	resetModTime(filepath.Join(fontDir, "sub"), modTime)
+	if got := scan(); !reflect.DeepEqual(got, []string{"Alpha", "Beta"}) {
+		t.Fatalf("initial scan: got fonts %q", got)
+	}
+
+	// The entries of unchanged directories are not read again. Files
+	// added without changing the modification time of their directory are
+	// not found, while changed files are read again.
+	writeFont("c.bdf", "Gamma")
+	writeFont("sub/b.bdf", "Delta")
+	resetModTime(fontDir, modTime)
+	if got := scan(); !reflect.DeepEqual(got, []string{"Alpha", "Delta"}) {
+		t.Errorf("unchanged directory: got fonts %q", got)
+	}
+
+	// Changed directories are read again.
+	resetModTime(fontDir, time.Now())
+	if got := scan(); !reflect.DeepEqual(got, []string{"Alpha", "Gamma", "Delta"}) {
+		t.Errorf("changed directory: got fonts %q", got)
+	}
+}
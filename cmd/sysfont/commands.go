@@ -0,0 +1,234 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/adrg/sysfont"
+)
+
+// fontInfo contains the font fields printed by the commands.
+type fontInfo struct {
+	Family      string   `json:"family"`
+	Name        string   `json:"name"`
+	Filename    string   `json:"filename"`
+	Index       int      `json:"index"`
+	Container   string   `json:"container"`
+	Metrics     string   `json:"metrics"`
+	PixelSize   int      `json:"pixel_size"`
+	XLFD        string   `json:"xlfd"`
+	Aliases     []string `json:"aliases"`
+	Weight      int      `json:"weight"`
+	Stretch     int      `json:"stretch"`
+	Style       string   `json:"style"`
+	ItalicAngle float64  `json:"italic_angle"`
+}
+
+func newFontInfo(font *sysfont.Font) *fontInfo {
+	return &fontInfo{
+		Family:      font.Family,
+		Name:        font.Name,
+		Filename:    font.Filename,
+		Index:       font.Index,
+		Container:   font.Container.String(),
+		Metrics:     font.MetricsFilename,
+		PixelSize:   font.PixelSize,
+		XLFD:        font.XLFD,
+		Aliases:     append([]string{}, font.Aliases...),
+		Weight:      font.Weight,
+		Stretch:     font.Stretch,
+		Style:       font.Style.String(),
+		ItalicAngle: font.ItalicAngle,
+	}
+}
+
+// matchInfo contains the match fields printed by the match command.
+type matchInfo struct {
+	Query  string    `json:"query"`
+	Font   *fontInfo `json:"font"`
+	Score  float64   `json:"score"`
+	Source string    `json:"source"`
+	Group  []string  `json:"group"`
+}
+
+func runList(opts *options, args []string) error {
+	var family, ext string
+
+	flags := flag.NewFlagSet("list", flag.ExitOnError)
+	flags.StringVar(&family, "family", "", "only list fonts whose family contains `name` (case-insensitive)")
+	flags.StringVar(&ext, "ext", "", "only list font files with the specified comma-separated `extensions`")
+	if args = parseCommandFlags(flags, "list [--family name] [--ext .ttf,.otf]", args); len(args) > 0 {
+		flags.Usage()
+		return fmt.Errorf("unexpected arguments: %s", strings.Join(args, " "))
+	}
+
+	// Filter fonts.
+	family = strings.ToLower(family)
+
+	fonts := []*fontInfo{}
+	var matches []*sysfont.Font
+	for _, font := range newFinder(opts, parseExtensions(ext)).List() {
+		if family != "" && !strings.Contains(strings.ToLower(font.Family), family) {
+			continue
+		}
+
+		fonts = append(fonts, newFontInfo(font))
+		matches = append(matches, font)
+	}
+
+	if opts.json {
+		return printJSON(fonts)
+	}
+	if opts.format != "" {
+		return printFormat(opts.format, matches)
+	}
+
+	rows := make([][]string, len(fonts))
+	for i, font := range fonts {
+		rows[i] = []string{font.Family, font.Name, fontStyle(font), font.Filename}
+	}
+
+	return printTable([]string{"FAMILY", "NAME", "STYLE", "FILE"}, rows)
+}
+
+func runMatch(opts *options, args []string) error {
+	var ext string
+
+	flags := flag.NewFlagSet("match", flag.ExitOnError)
+	flags.StringVar(&ext, "ext", "", "only match font files with the specified comma-separated `extensions`")
+	if args = parseCommandFlags(flags, "match [--ext .ttf,.pcf.gz] <query>...", args); len(args) == 0 {
+		flags.Usage()
+		return errors.New("no query specified")
+	}
+	finder := newFinder(opts, parseExtensions(ext))
+
+	matches := []*matchInfo{}
+	var fonts []*sysfont.Font
+	for _, query := range args {
+		match := &matchInfo{Query: query, Group: []string{}}
+		if result := finder.MatchDetails(query); result != nil {
+			fonts = append(fonts, result.Font)
+			match.Font = newFontInfo(result.Font)
+			match.Score = result.Score
+			match.Source = result.Source.String()
+			if result.Group != nil {
+				match.Group = result.Group
+			}
+		}
+
+		matches = append(matches, match)
+	}
+
+	if opts.json {
+		return printJSON(matches)
+	}
+	if opts.format != "" {
+		return printFormat(opts.format, fonts)
+	}
+
+	rows := make([][]string, len(matches))
+	for i, match := range matches {
+		if match.Font == nil {
+			rows[i] = []string{match.Query, "-", "-", "-", "-", "-"}
+			continue
+		}
+
+		rows[i] = []string{
+			match.Query,
+			match.Font.Name,
+			match.Font.Filename,
+			fmt.Sprintf("%.2f", match.Score),
+			match.Source,
+			strings.Join(match.Group, ", "),
+		}
+	}
+
+	return printTable([]string{"QUERY", "NAME", "FILE", "SCORE", "SOURCE", "GROUP"}, rows)
+}
+
+func runInfo(opts *options, args []string) error {
+	flags := flag.NewFlagSet("info", flag.ExitOnError)
+	if args = parseCommandFlags(flags, "info <file>...", args); len(args) == 0 {
+		flags.Usage()
+		return errors.New("no file specified")
+	}
+
+	fonts := []*fontInfo{}
+	var matches []*sysfont.Font
+	for _, filename := range args {
+		fileFonts, err := sysfont.ReadFontFile(filename)
+		if err != nil {
+			return err
+		}
+
+		for _, font := range fileFonts {
+			fonts = append(fonts, newFontInfo(font))
+		}
+		matches = append(matches, fileFonts...)
+	}
+
+	if opts.json {
+		return printJSON(fonts)
+	}
+	if opts.format != "" {
+		return printFormat(opts.format, matches)
+	}
+
+	for i, font := range fonts {
+		if i > 0 {
+			fmt.Println()
+		}
+
+		err := printTable(nil, [][]string{
+			{"File:", font.Filename},
+			{"Index:", fmt.Sprint(font.Index)},
+			{"Container:", font.Container},
+			{"Metrics:", font.Metrics},
+			{"XLFD:", font.XLFD},
+			{"Aliases:", strings.Join(font.Aliases, ", ")},
+			{"Family:", font.Family},
+			{"Name:", font.Name},
+			{"Weight:", fmt.Sprint(font.Weight)},
+			{"Stretch:", fmt.Sprint(font.Stretch)},
+			{"Style:", font.Style},
+			{"Italic angle:", fmt.Sprint(font.ItalicAngle)},
+			{"Pixel size:", fmt.Sprint(font.PixelSize)},
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// fontStyle returns a short description of the style attributes of a font
+// (e.g. 700 italic).
+func fontStyle(font *fontInfo) string {
+	style := fmt.Sprintf("%d %s", font.Weight, font.Style)
+	if font.Stretch != 0 && font.Stretch != sysfont.StretchNormal {
+		style += fmt.Sprintf(" (stretch %d)", font.Stretch)
+	}
+
+	return style
+}
+
+// parseExtensions returns the font file extensions contained in the specified
+// comma-separated list.
+func parseExtensions(list string) []string {
+	var extensions []string
+	for _, extension := range strings.Split(list, ",") {
+		if extension = strings.ToLower(strings.TrimSpace(extension)); extension == "" {
+			continue
+		}
+		if !strings.HasPrefix(extension, ".") {
+			extension = "." + extension
+		}
+
+		extensions = append(extensions, extension)
+	}
+
+	return extensions
+}
@@ -0,0 +1,139 @@
+// Command sysfont lists, matches and inspects installed fonts.
+//
+// Usage:
+//
+//	sysfont [flags] list [--family name] [--ext .ttf,.otf]
+//	sysfont [flags] match [--ext .ttf,.pcf.gz] <query>...
+//	sysfont [flags] info <file>...
+//
+// Flags:
+//
+//	--json        print results in JSON format
+//	--format fmt  print fonts using the fontconfig format syntax
+//	              (e.g. "%{family}: %{style}\n", "%{=fclist}\n")
+//	--path dir    search for fonts in the specified directory (repeatable)
+//
+// Queries can be specified as fontconfig patterns (e.g. "DejaVu Sans:bold")
+// or as X logical font descriptions, which must follow the -- separator
+// (e.g. sysfont match --ext .pcf.gz -- -*-fixed-bold-*). By default, only
+// TrueType and OpenType font files are searched.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/adrg/sysfont"
+)
+
+type command struct {
+	name  string
+	usage string
+	run   func(opts *options, args []string) error
+}
+
+var commands = []*command{
+	{
+		name:  "list",
+		usage: "list [--family name] [--ext .ttf,.otf]",
+		run:   runList,
+	},
+	{
+		name:  "match",
+		usage: "match [--ext .ttf,.pcf.gz] <query>...",
+		run:   runMatch,
+	},
+	{
+		name:  "info",
+		usage: "info <file>...",
+		run:   runInfo,
+	},
+}
+
+// options contains the flags shared by all commands.
+type options struct {
+	json   bool
+	format string
+	paths  stringList
+}
+
+// stringList is a flag value which can be specified multiple times.
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+func main() {
+	opts := &options{}
+
+	flags := flag.NewFlagSet("sysfont", flag.ExitOnError)
+	flags.BoolVar(&opts.json, "json", false, "print results in JSON format")
+	flags.StringVar(&opts.format, "format", "", "print fonts using the fontconfig `format` syntax (e.g. %{=fclist}\\n)")
+	flags.Var(&opts.paths, "path", "search for fonts in the specified `directory` (repeatable)")
+	flags.Usage = func() {
+		out := flags.Output()
+		fmt.Fprintln(out, "Usage:")
+		for _, cmd := range commands {
+			fmt.Fprintf(out, "  sysfont [flags] %s\n", cmd.usage)
+		}
+		fmt.Fprintln(out, "\nFlags:")
+		flags.PrintDefaults()
+	}
+	flags.Parse(os.Args[1:])
+
+	args := flags.Args()
+	if len(args) == 0 {
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	for _, cmd := range commands {
+		if cmd.name != args[0] {
+			continue
+		}
+
+		if err := cmd.run(opts, args[1:]); err != nil {
+			fmt.Fprintln(os.Stderr, "sysfont:", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	fmt.Fprintf(os.Stderr, "sysfont: unknown command %q\n", args[0])
+	flags.Usage()
+	os.Exit(2)
+}
+
+// newFinder returns a font finder using the specified search paths and
+// extensions. Default values are used for empty parameters.
+func newFinder(opts *options, extensions []string) *sysfont.Finder {
+	finderOpts := &sysfont.FinderOpts{
+		SearchPaths: opts.paths,
+		Extensions:  extensions,
+	}
+	if len(extensions) == 0 {
+		finderOpts.Extensions = []string{".ttf", ".ttc", ".otf", ".otc"}
+	}
+
+	return sysfont.NewFinder(finderOpts)
+}
+
+// parseCommandFlags parses the flags of the specified command. Usage errors
+// terminate the program.
+func parseCommandFlags(flags *flag.FlagSet, usage string, args []string) []string {
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage:\n  sysfont [flags] %s\n", usage)
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	return flags.Args()
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/adrg/sysfont"
+)
+
+// printJSON prints the specified value to the standard output, in indented
+// JSON format.
+func printJSON(v interface{}) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(v)
+}
+
+// printTable prints the specified rows to the standard output, as columns
+// aligned using tabs. The header is omitted if empty.
+func printTable(header []string, rows [][]string) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	if len(header) > 0 {
+		if _, err := w.Write([]byte(strings.Join(header, "\t") + "\n")); err != nil {
+			return err
+		}
+	}
+
+	for _, row := range rows {
+		if _, err := w.Write([]byte(strings.Join(row, "\t") + "\n")); err != nil {
+			return err
+		}
+	}
+
+	return w.Flush()
+}
+
+// printFormat prints the specified fonts to the standard output, using the
+// fontconfig format syntax.
+func printFormat(format string, fonts []*sysfont.Font) error {
+	for _, font := range fonts {
+		if _, err := os.Stdout.WriteString(sysfont.FormatFont(format, font)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,222 @@
+package sysfont
+
+import (
+	"encoding/binary"
+	"sort"
+)
+
+// runeRange represents an inclusive range of Unicode code points.
+type runeRange struct {
+	Lo rune
+	Hi rune
+}
+
+// coverage represents the set of Unicode code points supported by a font,
+// stored as a sorted list of non-overlapping ranges.
+type coverage []runeRange
+
+// contains reports whether the coverage includes the specified rune.
+func (c coverage) contains(r rune) bool {
+	i := sort.Search(len(c), func(i int) bool {
+		return c[i].Hi >= r
+	})
+
+	return i < len(c) && c[i].Lo <= r
+}
+
+// newCoverage returns a coverage set containing the specified ranges.
+// The ranges are sorted and merged.
+func newCoverage(ranges []runeRange) coverage {
+	if len(ranges) == 0 {
+		return nil
+	}
+
+	sort.Slice(ranges, func(i, j int) bool {
+		return ranges[i].Lo < ranges[j].Lo
+	})
+
+	merged := coverage{ranges[0]}
+	for _, r := range ranges[1:] {
+		last := &merged[len(merged)-1]
+		if r.Lo <= last.Hi+1 {
+			if r.Hi > last.Hi {
+				last.Hi = r.Hi
+			}
+			continue
+		}
+
+		merged = append(merged, r)
+	}
+
+	return merged
+}
+
+// parseCmapTable extracts the Unicode coverage of a font from its cmap table.
+// The most comprehensive Unicode subtable is used.
+func parseCmapTable(data []byte) (coverage, error) {
+	if len(data) < 4 {
+		return nil, errInvalidFont
+	}
+
+	numTables := int(binary.BigEndian.Uint16(data[2:]))
+	if len(data) < 4+numTables*8 {
+		return nil, errInvalidFont
+	}
+
+	// Identify the most suitable subtable.
+	var maxScore int
+	var subtable []byte
+
+	for i := 0; i < numTables; i++ {
+		record := data[4+i*8:]
+
+		platformID := binary.BigEndian.Uint16(record)
+		encodingID := binary.BigEndian.Uint16(record[2:])
+		offset := int(binary.BigEndian.Uint32(record[4:]))
+		if offset+4 > len(data) {
+			continue
+		}
+
+		score := getCmapSubtableScore(platformID, encodingID, binary.BigEndian.Uint16(data[offset:]))
+		if score > maxScore {
+			maxScore = score
+			subtable = data[offset:]
+		}
+	}
+	if subtable == nil {
+		return nil, errMissingTable
+	}
+
+	// Parse subtable.
+	var ranges []runeRange
+	switch binary.BigEndian.Uint16(subtable) {
+	case 4:
+		ranges = parseCmapFormat4(subtable)
+	case 12, 13:
+		ranges = parseCmapFormat12(subtable)
+	}
+	if ranges == nil {
+		return nil, errInvalidFont
+	}
+
+	return newCoverage(ranges), nil
+}
+
+func getCmapSubtableScore(platformID, encodingID, format uint16) int {
+	if format != 4 && format != 12 && format != 13 {
+		return 0
+	}
+
+	switch {
+	case platformID == 3 && encodingID == 10, platformID == 0 && encodingID == 4:
+		// Full Unicode repertoire.
+		return 4
+	case platformID == 3 && encodingID == 1, platformID == 0 && encodingID <= 3:
+		// Unicode BMP.
+		return 3
+	case platformID == 0 && encodingID == 6:
+		// Full Unicode repertoire, last resort fonts.
+		return 2
+	case platformID == 3 && encodingID == 0:
+		// Symbol.
+		return 1
+	}
+
+	return 0
+}
+
+// parseCmapFormat4 parses a segment mapping to delta values subtable.
+func parseCmapFormat4(data []byte) []runeRange {
+	if len(data) < 14 {
+		return nil
+	}
+
+	segCount := int(binary.BigEndian.Uint16(data[6:])) / 2
+	if len(data) < 16+segCount*8 {
+		return nil
+	}
+
+	endCodes := data[14:]
+	startCodes := data[16+segCount*2:]
+	idDeltas := data[16+segCount*4:]
+	idRangeOffsets := data[16+segCount*6:]
+
+	ranges := []runeRange{}
+	add := func(c rune) {
+		if n := len(ranges); n > 0 && ranges[n-1].Hi+1 == c {
+			ranges[n-1].Hi = c
+			return
+		}
+		ranges = append(ranges, runeRange{Lo: c, Hi: c})
+	}
+
+	for i := 0; i < segCount; i++ {
+		end := int(binary.BigEndian.Uint16(endCodes[i*2:]))
+		start := int(binary.BigEndian.Uint16(startCodes[i*2:]))
+		delta := int(binary.BigEndian.Uint16(idDeltas[i*2:]))
+		rangeOffset := int(binary.BigEndian.Uint16(idRangeOffsets[i*2:]))
+		if start > end || start == 0xFFFF {
+			continue
+		}
+
+		for c := start; c <= end; c++ {
+			glyph := 0
+			if rangeOffset == 0 {
+				glyph = (c + delta) & 0xFFFF
+			} else {
+				offset := 16 + segCount*6 + i*2 + rangeOffset + (c-start)*2
+				if offset+2 > len(data) {
+					break
+				}
+				if glyph = int(binary.BigEndian.Uint16(data[offset:])); glyph != 0 {
+					glyph = (glyph + delta) & 0xFFFF
+				}
+			}
+
+			if glyph != 0 {
+				add(rune(c))
+			}
+		}
+	}
+
+	return ranges
+}
+
+// parseCmapFormat12 parses a segmented coverage (format 12) or many-to-one
+// range mapping (format 13) subtable.
+func parseCmapFormat12(data []byte) []runeRange {
+	if len(data) < 16 {
+		return nil
+	}
+
+	numGroups := int(binary.BigEndian.Uint32(data[12:]))
+	if numGroups > (len(data)-16)/12 {
+		return nil
+	}
+
+	format := binary.BigEndian.Uint16(data)
+
+	ranges := []runeRange{}
+	for i := 0; i < numGroups; i++ {
+		group := data[16+i*12:]
+
+		start := rune(binary.BigEndian.Uint32(group))
+		end := rune(binary.BigEndian.Uint32(group[4:]))
+		glyph := binary.BigEndian.Uint32(group[8:])
+		if start > end || end > 0x10FFFF {
+			continue
+		}
+
+		// Skip code points mapped to the missing glyph.
+		if glyph == 0 {
+			if format == 13 || start == end {
+				continue
+			}
+			start++
+		}
+
+		ranges = append(ranges, runeRange{Lo: start, Hi: end})
+	}
+
+	return ranges
+}
@@ -0,0 +1,225 @@
+package sysfont
+
+import (
+	"encoding/binary"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// testCmapSubtable represents an encoding record of a cmap table built for
+// testing, along with its subtable.
+type testCmapSubtable struct {
+	platformID uint16
+	encodingID uint16
+	data       []byte
+}
+
+// buildCmapTable returns a cmap table containing the specified subtables.
+func buildCmapTable(subtables ...testCmapSubtable) []byte {
+	data := make([]byte, 4+len(subtables)*8)
+	binary.BigEndian.PutUint16(data[2:], uint16(len(subtables)))
+
+	for i, subtable := range subtables {
+		record := data[4+i*8:]
+		binary.BigEndian.PutUint16(record, subtable.platformID)
+		binary.BigEndian.PutUint16(record[2:], subtable.encodingID)
+		binary.BigEndian.PutUint32(record[4:], uint32(len(data)))
+		data = append(data, subtable.data...)
+	}
+
+	return data
+}
+
+// testCmapSegment represents a segment of a format 4 cmap subtable. If the
+// glyphs are specified, the segment maps characters through the glyph array.
+type testCmapSegment struct {
+	start, end uint16
+	delta      uint16
+	glyphs     []uint16
+}
+
+// buildCmapFormat4 returns a format 4 cmap subtable containing the specified
+// segments, followed by the final 0xFFFF segment.
+func buildCmapFormat4(segments ...testCmapSegment) []byte {
+	segments = append(segments, testCmapSegment{start: 0xFFFF, end: 0xFFFF, delta: 1})
+	segCount := len(segments)
+
+	data := make([]byte, 16+segCount*8)
+	binary.BigEndian.PutUint16(data, 4)
+	binary.BigEndian.PutUint16(data[6:], uint16(segCount*2))
+
+	var glyphs []uint16
+	for i, segment := range segments {
+		binary.BigEndian.PutUint16(data[14+i*2:], segment.end)
+		binary.BigEndian.PutUint16(data[16+segCount*2+i*2:], segment.start)
+		binary.BigEndian.PutUint16(data[16+segCount*4+i*2:], segment.delta)
+		if segment.glyphs == nil {
+			continue
+		}
+
+		// The range offset is relative to the position of the offset.
+		rangeOffset := (segCount-i)*2 + len(glyphs)*2
+		binary.BigEndian.PutUint16(data[16+segCount*6+i*2:], uint16(rangeOffset))
+		glyphs = append(glyphs, segment.glyphs...)
+	}
+	for _, glyph := range glyphs {
+		data = append(data, byte(glyph>>8), byte(glyph))
+	}
+	binary.BigEndian.PutUint16(data[2:], uint16(len(data)))
+
+	return data
+}
+
+// buildCmapFormat12 returns a format 12 or 13 cmap subtable containing the
+// specified groups of start code, end code and glyph.
+func buildCmapFormat12(format uint16, groups ...[3]uint32) []byte {
+	data := make([]byte, 16+len(groups)*12)
+	binary.BigEndian.PutUint16(data, format)
+	binary.BigEndian.PutUint32(data[4:], uint32(len(data)))
+	binary.BigEndian.PutUint32(data[12:], uint32(len(groups)))
+
+	for i, group := range groups {
+		for j, value := range group {
+			binary.BigEndian.PutUint32(data[16+i*12+j*4:], value)
+		}
+	}
+
+	return data
+}
+
+func TestParseCmapTable(t *testing.T) {
+	format4 := buildCmapFormat4(
+		testCmapSegment{start: 'A', end: 'C', delta: 10},
+		testCmapSegment{start: '0', end: '2', delta: 0x10000 - '1'},
+		testCmapSegment{start: 'a', end: 'c', glyphs: []uint16{5, 0, 7}},
+	)
+
+	tests := []struct {
+		name   string
+		data   []byte
+		ranges coverage
+		err    error
+	}{
+		{
+			name: "format 4",
+			data: buildCmapTable(testCmapSubtable{3, 1, format4}),
+			ranges: coverage{
+				{'0', '0'}, {'2', '2'}, {'A', 'C'}, {'a', 'a'}, {'c', 'c'},
+			},
+		},
+		{
+			name: "format 12",
+			data: buildCmapTable(testCmapSubtable{3, 10, buildCmapFormat12(12,
+				[3]uint32{0x1F600, 0x1F602, 10},
+				[3]uint32{0x20, 0x20, 0},
+				[3]uint32{0x30, 0x39, 0},
+				[3]uint32{0x3A, 0x3B, 20},
+				[3]uint32{0x110000, 0x110001, 30},
+			)}),
+			ranges: coverage{{0x31, 0x3B}, {0x1F600, 0x1F602}},
+		},
+		{
+			name: "format 13",
+			data: buildCmapTable(testCmapSubtable{0, 4, buildCmapFormat12(13,
+				[3]uint32{0x20, 0x7E, 1},
+				[3]uint32{0x80, 0xFF, 0},
+			)}),
+			ranges: coverage{{0x20, 0x7E}},
+		},
+		{
+			name: "full repertoire preferred",
+			data: buildCmapTable(
+				testCmapSubtable{3, 1, buildCmapFormat4(testCmapSegment{start: 'A', end: 'A', delta: 1})},
+				testCmapSubtable{3, 10, buildCmapFormat12(12, [3]uint32{'B', 'B', 1})},
+				testCmapSubtable{3, 0, buildCmapFormat4(testCmapSegment{start: 'C', end: 'C', delta: 1})},
+			),
+			ranges: coverage{{'B', 'B'}},
+		},
+		{
+			name: "symbol",
+			data: buildCmapTable(
+				testCmapSubtable{1, 0, buildCmapFormat4(testCmapSegment{start: 'A', end: 'A', delta: 1})},
+				testCmapSubtable{3, 0, buildCmapFormat4(testCmapSegment{start: 0xF020, end: 0xF021, delta: 1})},
+			),
+			ranges: coverage{{0xF020, 0xF021}},
+		},
+		{
+			name: "unsupported format",
+			data: buildCmapTable(testCmapSubtable{3, 1, []byte{0, 6, 0, 0}}),
+			err:  errMissingTable,
+		},
+		{
+			name: "subtable out of bounds",
+			data: buildCmapTable(testCmapSubtable{3, 1, []byte{0, 4}}),
+			err:  errMissingTable,
+		},
+		{
+			name: "truncated header",
+			data: []byte{0, 0, 0},
+			err:  errInvalidFont,
+		},
+		{
+			name: "truncated records",
+			data: buildCmapTable(testCmapSubtable{3, 1, format4})[:10],
+			err:  errInvalidFont,
+		},
+		{
+			name: "truncated format 4",
+			data: buildCmapTable(testCmapSubtable{3, 1, format4[:20]}),
+			err:  errInvalidFont,
+		},
+		{
+			name: "truncated format 12",
+			data: buildCmapTable(testCmapSubtable{3, 10, buildCmapFormat12(12, [3]uint32{'A', 'A', 1})[:20]}),
+			err:  errInvalidFont,
+		},
+		{
+			name: "oversized format 12 group count",
+			data: buildCmapTable(testCmapSubtable{3, 10, func() []byte {
+				data := buildCmapFormat12(12, [3]uint32{'A', 'A', 1})
+				binary.BigEndian.PutUint32(data[12:], 0xFFFFFFFF)
+				return data
+			}()}),
+			err: errInvalidFont,
+		},
+	}
+
+	for _, test := range tests {
+		cov, err := parseCmapTable(test.data)
+		if !errors.Is(err, test.err) {
+			t.Errorf("%s: got error %v, want %v", test.name, err, test.err)
+			continue
+		}
+		if err == nil && !reflect.DeepEqual(cov, test.ranges) {
+			t.Errorf("%s: got coverage %v, want %v", test.name, cov, test.ranges)
+		}
+	}
+}
+
+func TestCoverage(t *testing.T) {
+	cov := newCoverage([]runeRange{{'x', 'z'}, {'a', 'c'}, {'d', 'f'}, {'b', 'b'}, {'0', '9'}})
+	if want := (coverage{{'0', '9'}, {'a', 'f'}, {'x', 'z'}}); !reflect.DeepEqual(cov, want) {
+		t.Fatalf("got coverage %v, want %v", cov, want)
+	}
+
+	tests := []struct {
+		r    rune
+		want bool
+	}{
+		{'0', true},
+		{'5', true},
+		{':', false},
+		{'a', true},
+		{'f', true},
+		{'g', false},
+		{'z', true},
+		{0x10FFFF, false},
+	}
+
+	for _, test := range tests {
+		if got := cov.contains(test.r); got != test.want {
+			t.Errorf("contains(%q): got %t, want %t", test.r, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,236 @@
+package sysfont
+
+import "math"
+
+// cssObliqueAngle is the oblique angle used by CSS when none is specified.
+const cssObliqueAngle = 14
+
+// cssStretches maps font stretches to CSS font-stretch percentages.
+var cssStretches = map[int]float64{
+	StretchUltraCondensed: 50,
+	StretchExtraCondensed: 62.5,
+	StretchCondensed:      75,
+	StretchSemiCondensed:  87.5,
+	StretchNormal:         100,
+	StretchSemiExpanded:   112.5,
+	StretchExpanded:       125,
+	StretchExtraExpanded:  150,
+	StretchUltraExpanded:  200,
+}
+
+// matchCSS matches the specified fonts using the font matching algorithm
+// defined by the CSS Fonts Module Level 4 specification. The query families
+// are searched in order and the first family with installed fonts is used.
+// The fonts of that family are narrowed down based on font-stretch, then
+// font-style and finally font-weight.
+func (r *Registry) matchCSS(query Query, fonts []*Font) *Font {
+	for _, family := range query.Family {
+		// Identify fonts in the query family.
+		queryFamily := cleanQuery(family)
+		if queryFamily == "" {
+			continue
+		}
+
+		var matches []*Font
+		for _, font := range fonts {
+			if queryFamily == cleanQuery(font.Family) {
+				matches = append(matches, font)
+			}
+		}
+		if len(matches) == 0 {
+			continue
+		}
+
+		// Narrow down matches by stretch, style and weight.
+		matches = narrowCSSMatches(matches, func(font *Font) float64 {
+			return getCSSStretchRank(cssStretches[query.Stretch], cssStretches[font.Stretch])
+		})
+		matches = narrowCSSMatches(matches, func(font *Font) float64 {
+			return getCSSStyleRank(query, font)
+		})
+		matches = narrowCSSMatches(matches, func(font *Font) float64 {
+			return getCSSWeightRank(float64(query.Weight), float64(font.Weight))
+		})
+
+		return matches[0]
+	}
+
+	return nil
+}
+
+// narrowCSSMatches returns the fonts with the lowest rank.
+func narrowCSSMatches(fonts []*Font, rank func(*Font) float64) []*Font {
+	var matches []*Font
+	minRank := math.Inf(1)
+
+	for _, font := range fonts {
+		switch fontRank := rank(font); {
+		case fontRank < minRank:
+			minRank = fontRank
+			matches = []*Font{font}
+		case fontRank == minRank:
+			matches = append(matches, font)
+		}
+	}
+
+	return matches
+}
+
+// getCSSStretchRank ranks the actual font stretch based on the desired one.
+// If the desired stretch is less than or equal to 100%, narrower stretches
+// are checked in descending order, followed by wider stretches in ascending
+// order. Otherwise, wider stretches are checked in ascending order, followed
+// by narrower stretches in descending order.
+func getCSSStretchRank(desired, actual float64) float64 {
+	switch {
+	case actual == desired:
+		return 0
+	case desired <= 100:
+		if actual < desired {
+			return 1000 + desired - actual
+		}
+		return 2000 + actual - desired
+	default:
+		if actual > desired {
+			return 1000 + actual - desired
+		}
+		return 2000 + desired - actual
+	}
+}
+
+// getCSSWeightRank ranks the actual font weight based on the desired one.
+// If the desired weight is between 400 and 500 inclusive, weights between
+// the desired one and 500 are checked in ascending order, followed by
+// lighter weights in descending order, followed by weights greater than 500
+// in ascending order. If the desired weight is less than 400, lighter
+// weights are checked in descending order, followed by heavier weights in
+// ascending order. If the desired weight is greater than 500, heavier
+// weights are checked in ascending order, followed by lighter weights in
+// descending order.
+func getCSSWeightRank(desired, actual float64) float64 {
+	switch {
+	case actual == desired:
+		return 0
+	case desired >= 400 && desired <= 500:
+		if actual > desired && actual <= 500 {
+			return 1000 + actual - desired
+		}
+		if actual < desired {
+			return 2000 + desired - actual
+		}
+		return 3000 + actual - desired
+	case desired < 400:
+		if actual < desired {
+			return 1000 + desired - actual
+		}
+		return 2000 + actual - desired
+	default:
+		if actual > desired {
+			return 1000 + actual - desired
+		}
+		return 2000 + desired - actual
+	}
+}
+
+// getCSSStyleRank ranks the style of the font based on the query style.
+// If italic is requested, italic fonts are checked first, followed by the
+// fonts which would be checked for the default oblique angle. Otherwise,
+// fonts are ranked based on their oblique angle, normal fonts having an
+// angle of 0 degrees.
+func getCSSStyleRank(query Query, font *Font) float64 {
+	italic := font.Style == StyleItalic
+
+	switch query.Style {
+	case StyleItalic:
+		if italic {
+			return 0
+		}
+		return 10000 + getCSSObliqueRank(cssObliqueAngle, getCSSObliqueAngle(font), false)
+	case StyleOblique:
+		desired := query.ObliqueAngle
+		if desired == 0 {
+			desired = cssObliqueAngle
+		}
+		return getCSSObliqueRank(desired, getCSSObliqueAngle(font), italic)
+	default:
+		return getCSSObliqueRank(0, getCSSObliqueAngle(font), italic)
+	}
+}
+
+// getCSSObliqueRank ranks the actual oblique angle based on the desired one.
+//
+// If the desired angle is 0 (normal style), normal fonts are checked first,
+// followed by positive angles in ascending order, negative angles in
+// descending order and finally italic fonts.
+//
+// If the desired angle is greater than or equal to 11 degrees, greater angles
+// are checked in ascending order, followed by smaller positive angles in
+// descending order, italic fonts and finally angles less than or equal to 0
+// in descending order.
+//
+// If the desired angle is between 0 and 11 degrees, angles up to 11 degrees
+// are checked in ascending order, followed by smaller positive angles in
+// descending order, angles greater than or equal to 11 degrees in ascending
+// order, italic fonts and finally angles less than or equal to 0 in
+// descending order.
+//
+// Negative desired angles are handled symmetrically.
+func getCSSObliqueRank(desired, actual float64, italic bool) float64 {
+	if desired == 0 {
+		switch {
+		case italic:
+			return 3000
+		case actual == 0:
+			return 0
+		case actual > 0:
+			return 1000 + actual
+		default:
+			return 2000 - actual
+		}
+	}
+
+	// Mirror negative angles.
+	if desired < 0 {
+		desired, actual = -desired, -actual
+	}
+
+	if desired >= 11 {
+		switch {
+		case italic:
+			return 3000
+		case actual >= desired:
+			return 1000 + actual - desired
+		case actual > 0:
+			return 2000 + desired - actual
+		default:
+			return 4000 - actual
+		}
+	}
+
+	switch {
+	case italic:
+		return 4000
+	case actual >= desired && actual < 11:
+		return 1000 + actual - desired
+	case actual > 0 && actual < desired:
+		return 2000 + desired - actual
+	case actual >= 11:
+		return 3000 + actual
+	default:
+		return 5000 - actual
+	}
+}
+
+// getCSSObliqueAngle returns the oblique angle of the font, using the CSS
+// convention (positive values lean to the right). Oblique fonts with an
+// unknown angle are considered to have the default CSS oblique angle.
+func getCSSObliqueAngle(font *Font) float64 {
+	if font.Style == StyleNormal {
+		return 0
+	}
+	if font.ItalicAngle == 0 {
+		return cssObliqueAngle
+	}
+
+	return -font.ItalicAngle
+}
@@ -0,0 +1,157 @@
+package sysfont
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestMatchCSS(t *testing.T) {
+	fonts := []*Font{
+		{Family: "Test", Name: "Test Light", Weight: WeightLight, Stretch: StretchNormal},
+		{Family: "Test", Name: "Test Regular", Weight: WeightNormal, Stretch: StretchNormal},
+		{Family: "Test", Name: "Test Medium", Weight: WeightMedium, Stretch: StretchNormal},
+		{Family: "Test", Name: "Test Bold", Weight: WeightBold, Stretch: StretchNormal},
+		{Family: "Test", Name: "Test Italic", Weight: WeightNormal, Stretch: StretchNormal, Style: StyleItalic},
+		{Family: "Test", Name: "Test Oblique", Weight: WeightNormal, Stretch: StretchNormal, Style: StyleOblique, ItalicAngle: -8},
+		{Family: "Test", Name: "Test Condensed", Weight: WeightNormal, Stretch: StretchCondensed},
+		{Family: "Test", Name: "Test Expanded Bold", Weight: WeightBold, Stretch: StretchExpanded},
+		{Family: "Other", Name: "Other Regular", Weight: WeightNormal, Stretch: StretchNormal},
+	}
+
+	tests := []struct {
+		name  string
+		query Query
+		font  string
+	}{
+		{"regular", Query{Family: []string{"Test"}}, "Test Regular"},
+		{"weight between 400 and 500", Query{Family: []string{"Test"}, Weight: 450}, "Test Medium"},
+		{"weight above 500", Query{Family: []string{"Test"}, Weight: 600}, "Test Bold"},
+		{"weight below 400", Query{Family: []string{"Test"}, Weight: 350}, "Test Light"},
+		{"no heavier weight", Query{Family: []string{"Test"}, Weight: WeightBlack}, "Test Bold"},
+		{"italic", Query{Family: []string{"Test"}, Style: StyleItalic}, "Test Italic"},
+		{"oblique", Query{Family: []string{"Test"}, Style: StyleOblique}, "Test Oblique"},
+		{"narrower stretch", Query{Family: []string{"Test"}, Stretch: StretchSemiCondensed}, "Test Condensed"},
+		{"wider stretch", Query{Family: []string{"Test"}, Stretch: StretchSemiExpanded}, "Test Expanded Bold"},
+		{"family order", Query{Family: []string{"Missing", "other", "Test"}}, "Other Regular"},
+		{"missing family", Query{Family: []string{"Missing"}}, ""},
+	}
+
+	for _, test := range tests {
+		var name string
+		if font := fontRegistry.matchCSS(test.query.normalize(), fonts); font != nil {
+			name = font.Name
+		}
+		if name != test.font {
+			t.Errorf("%s: got %q, want %q", test.name, name, test.font)
+		}
+	}
+}
+
+func TestCSSRanks(t *testing.T) {
+	type value struct {
+		value  float64
+		italic bool
+	}
+
+	tests := []struct {
+		name    string
+		rank    func(desired float64, actual value) float64
+		desired float64
+		values  []value
+		want    []value
+	}{
+		{
+			name: "weight 400",
+			rank: func(desired float64, actual value) float64 {
+				return getCSSWeightRank(desired, actual.value)
+			},
+			desired: 400,
+			values:  []value{{value: 100}, {value: 300}, {value: 400}, {value: 500}, {value: 600}, {value: 900}},
+			want:    []value{{value: 400}, {value: 500}, {value: 300}, {value: 100}, {value: 600}, {value: 900}},
+		},
+		{
+			name: "weight 300",
+			rank: func(desired float64, actual value) float64 {
+				return getCSSWeightRank(desired, actual.value)
+			},
+			desired: 300,
+			values:  []value{{value: 100}, {value: 200}, {value: 300}, {value: 400}, {value: 900}},
+			want:    []value{{value: 300}, {value: 200}, {value: 100}, {value: 400}, {value: 900}},
+		},
+		{
+			name: "weight 600",
+			rank: func(desired float64, actual value) float64 {
+				return getCSSWeightRank(desired, actual.value)
+			},
+			desired: 600,
+			values:  []value{{value: 100}, {value: 500}, {value: 600}, {value: 700}, {value: 900}},
+			want:    []value{{value: 600}, {value: 700}, {value: 900}, {value: 500}, {value: 100}},
+		},
+		{
+			name: "stretch 100",
+			rank: func(desired float64, actual value) float64 {
+				return getCSSStretchRank(desired, actual.value)
+			},
+			desired: 100,
+			values:  []value{{value: 50}, {value: 75}, {value: 87.5}, {value: 100}, {value: 112.5}, {value: 200}},
+			want:    []value{{value: 100}, {value: 87.5}, {value: 75}, {value: 50}, {value: 112.5}, {value: 200}},
+		},
+		{
+			name: "stretch 125",
+			rank: func(desired float64, actual value) float64 {
+				return getCSSStretchRank(desired, actual.value)
+			},
+			desired: 125,
+			values:  []value{{value: 50}, {value: 100}, {value: 112.5}, {value: 125}, {value: 150}, {value: 200}},
+			want:    []value{{value: 125}, {value: 150}, {value: 200}, {value: 112.5}, {value: 100}, {value: 50}},
+		},
+		{
+			name: "oblique 0",
+			rank: func(desired float64, actual value) float64 {
+				return getCSSObliqueRank(desired, actual.value, actual.italic)
+			},
+			desired: 0,
+			values:  []value{{14, true}, {-14, false}, {-5, false}, {0, false}, {5, false}, {14, false}},
+			want:    []value{{0, false}, {5, false}, {14, false}, {-5, false}, {-14, false}, {14, true}},
+		},
+		{
+			name: "oblique 14",
+			rank: func(desired float64, actual value) float64 {
+				return getCSSObliqueRank(desired, actual.value, actual.italic)
+			},
+			desired: 14,
+			values:  []value{{14, true}, {-14, false}, {0, false}, {5, false}, {10, false}, {14, false}, {20, false}},
+			want:    []value{{14, false}, {20, false}, {10, false}, {5, false}, {14, true}, {0, false}, {-14, false}},
+		},
+		{
+			name: "oblique 5",
+			rank: func(desired float64, actual value) float64 {
+				return getCSSObliqueRank(desired, actual.value, actual.italic)
+			},
+			desired: 5,
+			values:  []value{{14, true}, {-5, false}, {0, false}, {3, false}, {5, false}, {8, false}, {14, false}},
+			want:    []value{{5, false}, {8, false}, {3, false}, {14, false}, {14, true}, {0, false}, {-5, false}},
+		},
+		{
+			name: "oblique -14",
+			rank: func(desired float64, actual value) float64 {
+				return getCSSObliqueRank(desired, actual.value, actual.italic)
+			},
+			desired: -14,
+			values:  []value{{14, true}, {14, false}, {-5, false}, {-14, false}, {-20, false}},
+			want:    []value{{-14, false}, {-20, false}, {-5, false}, {14, true}, {14, false}},
+		},
+	}
+
+	for _, test := range tests {
+		values := append([]value(nil), test.values...)
+		sort.SliceStable(values, func(i, j int) bool {
+			return test.rank(test.desired, values[i]) < test.rank(test.desired, values[j])
+		})
+
+		if !reflect.DeepEqual(values, test.want) {
+			t.Errorf("%s: got order %v, want %v", test.name, values, test.want)
+		}
+	}
+}
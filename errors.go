@@ -0,0 +1,43 @@
+package sysfont
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrFontNotFound is matched by the errors returned when no installed font
+// matches a query.
+var ErrFontNotFound = errors.New("sysfont: font not found")
+
+// FontNotFoundError is returned by strict matching methods when none of the
+// installed fonts match a query.
+type FontNotFoundError struct {
+	// Query contains the query which could not be matched.
+	Query Query
+
+	// Candidate contains the best installed font which was rejected. It is
+	// nil if no fonts are installed.
+	Candidate *Candidate
+}
+
+// Error returns the error message.
+func (e *FontNotFoundError) Error() string {
+	msg := fmt.Sprintf("%s: %q", ErrFontNotFound, strings.Join(e.Query.Family, ", "))
+	if e.Candidate != nil {
+		msg += fmt.Sprintf(" (closest: %q)", e.Candidate.Font.Name)
+	}
+
+	return msg
+}
+
+// Is reports whether the error matches the target error. The error matches
+// ErrFontNotFound.
+func (e *FontNotFoundError) Is(target error) bool {
+	return target == ErrFontNotFound
+}
+
+// ErrWatchUnsupported is returned by Finder.Watch on platforms which do not
+// support watching font directories for changes, and for finders which
+// search for fonts in a file system specified through FinderOpts.
+var ErrWatchUnsupported = errors.New("sysfont: watching font directories is not supported on this platform")
@@ -1,7 +1,11 @@
 package sysfont_test
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"os"
+	"time"
 
 	"github.com/adrg/sysfont"
 )
@@ -20,10 +24,70 @@ func ExampleNewFinder() {
 		SearchPaths: []string{"."},
 	})
 
+	// Create a new finder which caches identified fonts between runs.
+	finder = sysfont.NewFinder(&sysfont.FinderOpts{
+		Extensions: []string{".ttf", ".ttc", ".otf", ".otc"},
+		UseCache:   true,
+	})
+
+	// Create a new finder which identifies fonts using 4 goroutines.
+	finder = sysfont.NewFinder(&sysfont.FinderOpts{
+		Extensions: []string{".ttf", ".ttc", ".otf", ".otc"},
+		Workers:    4,
+	})
+
+	// Create a new finder which only searches for web fonts.
+	finder = sysfont.NewFinder(&sysfont.FinderOpts{
+		Extensions:  []string{".woff", ".woff2"},
+		SearchPaths: []string{"static/fonts"},
+	})
+	for _, font := range finder.List() {
+		fmt.Println(font.Family, font.Name, font.Container)
+	}
+
+	// Create a new finder which only searches for Type 1 fonts, along with
+	// their metrics files.
+	finder = sysfont.NewFinder(&sysfont.FinderOpts{
+		Extensions:  []string{".pfb", ".pfa"},
+		SearchPaths: []string{"/usr/share/texmf/fonts/type1"},
+	})
+	for _, font := range finder.List() {
+		fmt.Println(font.Family, font.Name, font.MetricsFilename)
+	}
+
+	// Create a new finder which searches for fonts in a file system
+	// (e.g. embed.FS, zip.Reader, fstest.MapFS).
+	finder = sysfont.NewFinder(&sysfont.FinderOpts{
+		Extensions:  []string{".ttf", ".ttc", ".otf", ".otc"},
+		FS:          os.DirFS("assets"),
+		SearchPaths: []string{"fonts"},
+	})
+
 	// List detected fonts.
 	for _, font := range finder.List() {
 		fmt.Println(font.Family, font.Name, font.Filename)
 	}
+
+	// List errors encountered while searching for fonts.
+	for _, err := range finder.Errors() {
+		fmt.Println(err)
+	}
+}
+
+func ExampleNewFinderContext() {
+	// Stop searching for fonts after 5 seconds.
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	finder, err := sysfont.NewFinderContext(ctx, nil)
+	if err != nil {
+		fmt.Println("Font search incomplete:", err)
+	}
+
+	// List the detected fonts, which may be partial.
+	for _, font := range finder.List() {
+		fmt.Println(font.Family, font.Name, font.Filename)
+	}
 }
 
 func ExampleFinder_List() {
@@ -75,3 +139,218 @@ func ExampleFinder_Match() {
 		fmt.Printf("%-30s -> %-30s (%s)\n", term, font.Name, font.Filename)
 	}
 }
+
+func ExampleFinder_MatchQuery() {
+	finder := sysfont.NewFinder(nil)
+
+	font := finder.MatchQuery(sysfont.Query{
+		Family: []string{"Helvetica", "Arial"},
+		Weight: sysfont.WeightBold,
+		Style:  sysfont.StyleItalic,
+	})
+	if font == nil {
+		// MatchQuery should always return a font. However, it is safer to check.
+		return
+	}
+
+	fmt.Printf("%s (%s)\n", font.Name, font.Filename)
+}
+
+func ExampleFinder_MatchCSS() {
+	finder := sysfont.NewFinder(nil)
+
+	// Match the font selected by the CSS declaration:
+	// font: oblique 600 condensed 12pt "Open Sans", Arial;
+	font := finder.MatchCSS(sysfont.Query{
+		Family:  []string{"Open Sans", "Arial"},
+		Weight:  600,
+		Style:   sysfont.StyleOblique,
+		Stretch: sysfont.StretchCondensed,
+	})
+	if font == nil {
+		return
+	}
+
+	fmt.Printf("%s (%s)\n", font.Name, font.Filename)
+}
+
+func ExampleFinder_MatchForText() {
+	finder := sysfont.NewFinder(nil)
+
+	match := finder.MatchForText("Arial", "Hello, 世界! Γειά σου!")
+	if match.Font != nil {
+		fmt.Printf("Font: %s (%s)\n", match.Font.Name, match.Font.Filename)
+	}
+	for _, font := range match.Fallbacks {
+		fmt.Printf("Fallback: %s (%s)\n", font.Name, font.Filename)
+	}
+	if len(match.Missing) > 0 {
+		fmt.Printf("Missing: %q\n", match.Missing)
+	}
+}
+
+func ExampleLoadDefaultFontConfig() {
+	// Load the system fontconfig configuration (e.g. /etc/fonts/fonts.conf).
+	config, err := sysfont.LoadDefaultFontConfig()
+	if err != nil {
+		return
+	}
+
+	// Create a new finder which uses the font directories, aliases and
+	// font selection rules of the configuration.
+	finder := sysfont.NewFinder(&sysfont.FinderOpts{
+		Extensions: []string{".ttf", ".ttc", ".otf", ".otc"},
+		FontConfig: config,
+	})
+
+	for _, font := range finder.List() {
+		fmt.Println(font.Family, font.Name, font.Filename)
+	}
+}
+
+func ExampleFinder_Match_genericFamilies() {
+	finder := sysfont.NewFinder(nil)
+
+	terms := []string{
+		"serif",
+		"sans-serif",
+		"monospace",
+		"monospace bold",
+		"cursive",
+		"fantasy",
+		"system-ui",
+		"emoji",
+		"math",
+	}
+
+	for _, term := range terms {
+		font := finder.Match(term)
+		if font == nil {
+			continue
+		}
+
+		fmt.Printf("%-30s -> %-30s (%s)\n", term, font.Name, font.Filename)
+	}
+}
+
+func ExampleFinder_MatchN() {
+	finder := sysfont.NewFinder(nil)
+
+	// Show the best 5 candidates for the query.
+	for _, candidate := range finder.MatchN("Helvetica Neue Bold", 5) {
+		fmt.Printf("%-30s family: %.2f style: %.2f score: %.2f\n",
+			candidate.Font.Name, candidate.FamilyScore, candidate.StyleScore, candidate.Score)
+	}
+}
+
+func ExampleFinder_MatchExact() {
+	finder := sysfont.NewFinder(nil)
+
+	font, err := finder.MatchExact("Helvetica Neue Bold")
+	if err != nil {
+		var notFound *sysfont.FontNotFoundError
+		if errors.As(err, &notFound) && notFound.Candidate != nil {
+			fmt.Println("Font not found. Closest match:", notFound.Candidate.Font.Name)
+		}
+		return
+	}
+
+	fmt.Printf("%s (%s)\n", font.Name, font.Filename)
+}
+
+func ExampleFinder_MatchDetails() {
+	finder := sysfont.NewFinder(nil)
+
+	terms := []string{
+		"Arial",
+		"sans-serif",
+		"Helvetica Neue Bold",
+		"Unknown Font",
+	}
+
+	for _, term := range terms {
+		result := finder.MatchDetails(term)
+		if result == nil {
+			continue
+		}
+
+		fmt.Printf("%-20s -> %-30s source: %s, group: %v\n",
+			term, result.Font.Name, result.Source, result.Group)
+	}
+}
+
+func ExampleFinder_Match_bitmapFonts() {
+	finder := sysfont.NewFinder(&sysfont.FinderOpts{
+		Extensions: []string{".pcf", ".pcf.gz", ".bdf"},
+	})
+
+	// Match bitmap fonts using X11 font names and aliases. Other X logical
+	// font descriptions are matched against all installed fonts.
+	names := []string{
+		"fixed",
+		"-misc-fixed-bold-r-normal--13-*",
+		"-adobe-helvetica-bold-r-normal--12-*-*-*-p-*-iso8859-1",
+	}
+
+	for _, name := range names {
+		if font := finder.Match(name); font != nil {
+			fmt.Println(name, "->", font.XLFD, font.PixelSize)
+		}
+	}
+}
+
+func ExampleRegistry() {
+	// Extend the built-in registry with custom fonts and alternatives.
+	registry := sysfont.DefaultRegistry()
+	registry.AddFont("Acme Sans", "Acme Sans Bold", "AcmeSans-Bold.ttf")
+	registry.AddAlternatives("Acme Sans", "Helvetica", "Arial")
+
+	// Alternatively, load the registry definitions from a JSON file.
+	// registry, err := sysfont.LoadRegistry("fonts.json")
+
+	finder := sysfont.NewFinder(&sysfont.FinderOpts{
+		Registry: registry,
+	})
+
+	if font := finder.Match("Acme Sans Bold"); font != nil {
+		fmt.Printf("%s (%s)\n", font.Name, font.Filename)
+	}
+}
+
+func ExampleParsePattern() {
+	finder := sysfont.NewFinder(nil)
+
+	// Fontconfig patterns are also accepted by the Match method.
+	query, err := sysfont.ParsePattern("DejaVu Sans,Arial:style=Bold:slant=100")
+	if err != nil {
+		return
+	}
+
+	if font := finder.MatchQuery(query); font != nil {
+		// Print the font in the same format as fc-match.
+		fmt.Println(sysfont.FormatFont("%{=fcmatch}", font))
+	}
+}
+
+func ExampleFinder_Watch() {
+	finder := sysfont.NewFinder(nil)
+
+	// Print font changes.
+	finder.Subscribe(func(event *sysfont.FontEvent) {
+		for _, font := range event.Added {
+			fmt.Println("Added:", font.Name, font.Filename)
+		}
+		for _, font := range event.Removed {
+			fmt.Println("Removed:", font.Name, font.Filename)
+		}
+	})
+
+	// Watch the search paths until the context is canceled.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := finder.Watch(ctx); err != nil {
+		fmt.Println(err)
+		return
+	}
+}
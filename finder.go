@@ -1,90 +1,507 @@
 package sysfont
 
 import (
+	"context"
+	"errors"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"runtime"
+	"sort"
 	"strings"
+	"sync"
+	"unicode"
 
 	"github.com/adrg/strutil"
 	"github.com/adrg/xdg"
 )
 
+// TextMatch contains the fonts required for rendering a text.
+type TextMatch struct {
+	// Font contains the font which best matches the query.
+	Font *Font
+
+	// Fallbacks contains the fonts, in order of preference, which cover the
+	// characters of the text not supported by the matched font.
+	Fallbacks []*Font
+
+	// Missing contains the characters of the text which are not supported
+	// by any of the installed fonts, in order of appearance.
+	Missing []rune
+}
+
 // Finder is used to identify installed fonts. It can match fonts based on user
 // queries and suggest alternative fonts if the requested fonts are not found.
 type Finder struct {
-	fonts []*Font
+	mu          sync.RWMutex
+	fonts       []*Font
+	errors      []error
+	registry    *Registry
+	searchPaths []string
+	extensions  []string
+	config      *FontConfig
+	fsys        fs.FS
+	watching    bool
+	subscribers []func(*FontEvent)
 }
 
 // FinderOpts contains options for configuring a font finder.
 type FinderOpts struct {
 	// Extensions controls which types of font files the finder reports.
+	// Besides TrueType and OpenType fonts, web fonts (.woff, .woff2),
+	// Type 1 fonts (.pfb, .pfa) and bitmap fonts (.pcf, .pcf.gz, .bdf) can
+	// be identified.
 	Extensions []string
 
 	// SearchPaths is a list of paths to search for fonts.
 	SearchPaths []string
+
+	// UseCache enables the caching of identified fonts between finder
+	// instances, including across process restarts. Directories whose
+	// modification time has not changed since they were cached are not
+	// read again, and neither are font files whose size and modification
+	// time have not changed.
+	UseCache bool
+
+	// FontConfig contains fontconfig settings used for configuring the
+	// finder. If SearchPaths is empty, the font directories of the
+	// configuration are used as search paths. The font family aliases of
+	// the configuration are used for identifying alternative fonts, with
+	// priority over the built-in alternatives. Fonts excluded by the
+	// configuration rules are not reported.
+	FontConfig *FontConfig
+
+	// CacheFile contains the path of the cache file. It is only used if
+	// UseCache is enabled. If it is empty, the cache is stored in the
+	// sysfont/fonts.cache file, relative to xdg.CacheHome.
+	CacheFile string
+
+	// Registry contains the font registry used by the finder for
+	// identifying font files and for substituting missing fonts. If it is
+	// nil, the built-in registry is used. Changes made to the registry after
+	// the finder is created do not affect the finder.
+	Registry *Registry
+
+	// Workers specifies the maximum number of goroutines used for traversing
+	// the search paths and for identifying font files. If it is 0, the number
+	// of available CPUs is used. Regardless of the number of workers, fonts
+	// are reported in the order in which the search paths are traversed.
+	Workers int
+
+	// FS specifies a file system (e.g. embed.FS, zip.Reader) in which to
+	// search for fonts, instead of the OS file system. If it is set, the
+	// search paths are paths inside the file system, defaulting to its
+	// root directory, and the fonts record the file system they were found
+	// in. Fonts found in file systems are not cached and cannot be watched.
+	FS fs.FS
 }
 
 // NewFinder returns a new font finder. If the opts parameter is nil, default
 // options are used.
 //
 // Default options:
-//   Extensions: []string{".ttf", ".ttc", ".otf"}
+//   Extensions: []string{".ttf", ".ttc", ".otf", ".otc"}
 //   SearchPaths: xdg.FontDirs
 //
 // NOTE: See https://github.com/adrg/xdg#other-directories for more information
 // about the default search paths.
 func NewFinder(opts *FinderOpts) *Finder {
+	finder, _ := NewFinderContext(context.Background(), opts)
+	return finder
+}
+
+// NewFinderContext returns a new font finder, in the same way as NewFinder.
+// The search for fonts is stopped if the specified context is canceled or
+// times out. In that case, a finder containing the fonts identified until
+// that point is returned, along with the error of the context. Operations
+// which are blocked (e.g. reading from unresponsive network mounts) are
+// abandoned, so the function returns shortly after the context is done.
+// The X11 font names and aliases are not read and the font cache is not
+// updated if the search is stopped.
+func NewFinderContext(ctx context.Context, opts *FinderOpts) (*Finder, error) {
 	if opts == nil {
-		opts = &FinderOpts{Extensions: []string{".ttf", ".ttc", ".otf"}}
+		opts = &FinderOpts{Extensions: []string{".ttf", ".ttc", ".otf", ".otc"}}
 	}
 
 	if len(opts.SearchPaths) == 0 {
-		opts.SearchPaths = xdg.FontDirs
+		if opts.FS != nil {
+			opts.SearchPaths = []string{"."}
+		} else if opts.FontConfig != nil && len(opts.FontConfig.Dirs) > 0 {
+			opts.SearchPaths = opts.FontConfig.Dirs
+		} else {
+			opts.SearchPaths = xdg.FontDirs
+		}
 	}
 
-	var fonts []*Font
-	walker := func(filename string, info os.FileInfo, err error) error {
-		if err != nil {
-			return err
+	// Use a copy of the custom registry, if specified.
+	reg := fontRegistry
+	if opts.Registry != nil {
+		reg = opts.Registry.clone()
+	}
+
+	// Add font configuration aliases to the alternative font families.
+	if opts.FontConfig != nil && len(opts.FontConfig.Aliases) > 0 {
+		groups := make([][]string, 0, len(opts.FontConfig.Aliases))
+		for _, alias := range opts.FontConfig.Aliases {
+			groups = append(groups, alias.Families())
 		}
-		if info.IsDir() {
-			return nil
+
+		reg = reg.withAlternatives(groups)
+	}
+
+	// Load font cache. Fonts found in file systems are not cached.
+	var cache *fontCache
+	if opts.UseCache && opts.FS == nil {
+		cacheFile := opts.CacheFile
+		if cacheFile == "" {
+			cacheFile = filepath.Join(xdg.CacheHome, "sysfont", "fonts.cache")
 		}
 
-		// Check file extension.
-		if extensions := opts.Extensions; len(extensions) > 0 {
-			extension := filepath.Ext(strings.ToLower(filename))
-			if !strutil.SliceContains(extensions, extension) {
+		cache = loadFontCache(cacheFile)
+	}
+
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	finder := &Finder{
+		registry:    reg,
+		searchPaths: opts.SearchPaths,
+		extensions:  opts.Extensions,
+		config:      opts.FontConfig,
+		fsys:        opts.FS,
+	}
+
+	// Traverse search paths.
+	walks := parallelize(ctx, len(opts.SearchPaths), workers, func(i int) interface{} {
+		return walkSearchPath(ctx, opts.FS, cache, opts.SearchPaths[i], opts.SearchPaths)
+	})
+
+	var files []*searchPathFile
+	for _, walk := range walks {
+		if walk, ok := walk.(*searchPathWalk); ok {
+			files = append(files, walk.files...)
+			finder.errors = append(finder.errors, walk.errors...)
+		}
+	}
+
+	// Identify fonts. The fonts are reported in traversal order.
+	results := parallelize(ctx, len(files), workers, func(i int) interface{} {
+		fonts, err := finder.readFonts(files[i].path, files[i].info, cache)
+		return &fontFileResult{fonts: fonts, err: err}
+	})
+	for _, result := range results {
+		if result, ok := result.(*fontFileResult); ok {
+			finder.fonts = append(finder.fonts, result.fonts...)
+			if result.err != nil {
+				finder.errors = append(finder.errors, result.err)
+			}
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return finder, err
+	}
+
+	// Assign the X11 font names and aliases of the font directories.
+	var xfontFiles []string
+	for _, file := range files {
+		if isXFontFile(file.path) {
+			xfontFiles = append(xfontFiles, file.path)
+		}
+	}
+	finder.readXFontFiles(xfontFiles)
+
+	// Update font cache.
+	if err := cache.save(); err != nil {
+		finder.errors = append(finder.errors, &os.PathError{Op: "save cache", Path: cache.path, Err: err})
+	}
+
+	return finder, nil
+}
+
+// searchPathFile represents a file found in a search path.
+type searchPathFile struct {
+	path string
+	info os.FileInfo
+}
+
+// searchPathWalk contains the files found by traversing a search path, along
+// with the errors encountered during the traversal.
+type searchPathWalk struct {
+	files  []*searchPathFile
+	errors []error
+}
+
+// fontFileResult contains the fonts identified in a font file, along with
+// the error encountered while reading the file.
+type fontFileResult struct {
+	fonts []*Font
+	err   error
+}
+
+// walkSearchPath traverses the specified search path, until the context is
+// done. Missing search paths are not reported as errors, as not all default
+// search paths exist on every system. The cache is used only for the OS file
+// system.
+func walkSearchPath(ctx context.Context, fsys fs.FS, cache *fontCache, dir string, searchPaths []string) *searchPathWalk {
+	walk := &searchPathWalk{}
+	if fsys != nil {
+		fs.WalkDir(fsys, dir, func(name string, d fs.DirEntry, err error) error {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return ctxErr
+			}
+			if err != nil {
+				if !errors.Is(err, fs.ErrNotExist) || !strutil.SliceContains(searchPaths, name) {
+					walk.errors = append(walk.errors, err)
+				}
+
+				// Skip unreadable directories and carry on with the walk.
+				if d != nil && d.IsDir() {
+					return fs.SkipDir
+				}
+				return nil
+			}
+			if d.IsDir() {
+				return nil
+			}
+
+			info, err := d.Info()
+			if err != nil {
+				walk.errors = append(walk.errors, err)
 				return nil
 			}
+			walk.files = append(walk.files, &searchPathFile{path: name, info: info})
+			return nil
+		})
+
+		return walk
+	}
+
+	if ctx.Err() != nil {
+		return walk
+	}
+
+	info, err := os.Lstat(dir)
+	if err != nil {
+		if !os.IsNotExist(err) || !strutil.SliceContains(searchPaths, dir) {
+			walk.errors = append(walk.errors, err)
+		}
+		return walk
+	}
+	if !info.IsDir() {
+		walk.files = append(walk.files, &searchPathFile{path: dir, info: info})
+		return walk
+	}
+	walk.walkDir(ctx, cache, dir, info)
+
+	return walk
+}
+
+// walkDir traverses the specified directory of the OS file system, until the
+// context is done. The entries of directories whose modification time has
+// not changed since they were cached are not read again.
+func (w *searchPathWalk) walkDir(ctx context.Context, cache *fontCache, dir string, info os.FileInfo) error {
+	names, ok := cache.dirNames(dir, info)
+	if !ok {
+		var err error
+		if names, err = readDirNames(dir); err != nil {
+			// Skip unreadable directories and carry on with the walk.
+			w.errors = append(w.errors, err)
+			return nil
 		}
+	}
+	cache.addDir(dir, info, names)
 
-		// Attempt to identify fonts by filename.
-		matches := fontRegistry.matchFontsByFilename(filename)
-		if len(matches) == 0 {
-			matches = append(matches, &Font{Filename: filename})
+	for _, name := range names {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		filename := filepath.Join(dir, name)
+		info, err := os.Lstat(filename)
+		switch {
+		case err != nil:
+			w.errors = append(w.errors, err)
+		case info.IsDir():
+			if err := w.walkDir(ctx, cache, filename, info); err != nil {
+				return err
+			}
+		default:
+			w.files = append(w.files, &searchPathFile{path: filename, info: info})
 		}
+	}
+
+	return nil
+}
+
+// readDirNames returns the sorted entry names of the specified directory.
+func readDirNames(dir string) ([]string, error) {
+	f, err := os.Open(dir)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	names, err := f.Readdirnames(-1)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
 
-		fonts = append(fonts, matches...)
+	return names, nil
+}
+
+// readFonts identifies the fonts contained in the specified file, using the
+// cached fonts if possible. Files with unsupported extensions and fonts
+// rejected by the font configuration of the finder are excluded. The
+// returned error is the error encountered while reading the file, if it
+// should be reported.
+func (f *Finder) readFonts(filename string, info os.FileInfo, cache *fontCache) ([]*Font, error) {
+	// Check file extension.
+	if extensions := f.extensions; len(extensions) > 0 {
+		extension := filepath.Ext(strings.ToLower(filename))
+		if !strutil.SliceContains(extensions, extension) &&
+			!strutil.SliceContains(extensions, fontFileExtension(filename)) {
+			return nil, nil
+		}
+	}
+
+	// Identify fonts, using the cached fonts if possible. Fonts which are
+	// not identified by reading the font file depend on the registry of the
+	// finder, so they are not cached.
+	var readErr error
+	fonts, ok := cache.fonts(filename, info)
+	if !ok {
+		var err error
+		if fonts, err = identifyFontFile(f.fsys, filename, f.registry); err == nil {
+			cache.add(filename, info, fonts)
+		}
+		readErr = fontFileError(filename, err)
+	}
+
+	// Exclude fonts rejected by the font configuration.
+	if config := f.config; config != nil {
+		accepted := fonts[:0:0]
+		for _, font := range fonts {
+			if !config.Rejected(font) {
+				accepted = append(accepted, font)
+			}
+		}
+		fonts = accepted
+	}
+
+	return fonts, readErr
+}
+
+// fontFileError returns the specified error encountered while reading a font
+// file, if it should be reported. Unsupported or invalid font data is not
+// reported, as such files are identified using the registry.
+func fontFileError(filename string, err error) error {
+	if err == nil || errors.Is(err, errUnsupportedFont) ||
+		errors.Is(err, errInvalidFont) || errors.Is(err, errMissingTable) {
 		return nil
 	}
 
-	// Traverse OS font directories.
-	for _, dir := range opts.SearchPaths {
-		if err := filepath.Walk(dir, walker); err != nil {
-			continue
+	var pathErr *fs.PathError
+	if errors.As(err, &pathErr) {
+		return err
+	}
+
+	return &os.PathError{Op: "read", Path: filename, Err: err}
+}
+
+// fontFileExtension returns the lowercase extension of the specified font
+// file. For gzip-compressed files, the extension includes the extension of
+// the compressed file (e.g. .pcf.gz).
+func fontFileExtension(filename string) string {
+	filename = strings.ToLower(filename)
+
+	ext := filepath.Ext(filename)
+	if ext == ".gz" {
+		ext = filepath.Ext(strings.TrimSuffix(filename, ext)) + ext
+	}
+
+	return ext
+}
+
+// Errors returns the errors encountered while searching for fonts. Each
+// error is associated with the path which caused it (e.g. unreadable font
+// directories or files) and is usually of type *os.PathError. Paths which
+// cause errors are skipped, and the search carries on with the remaining
+// paths, except for unreadable font files, which are identified by filename,
+// as described by the List method. Missing search paths and files which do
+// not contain supported font data are not reported.
+func (f *Finder) Errors() []error {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	errs := make([]error, len(f.errors))
+	copy(errs, f.errors)
+
+	return errs
+}
+
+// ReadFontFile reads the metadata of the fonts contained in the specified
+// font file (e.g. family, name, style attributes, supported characters).
+// Font collection files produce a font for each face in the collection.
+// Unlike the fonts reported by finders, the fonts are not identified using
+// the font registry if the file cannot be read.
+func ReadFontFile(filename string) ([]*Font, error) {
+	return readFontFile(nil, filename)
+}
+
+// ReadFontFileFS reads the metadata of the fonts contained in the specified
+// font file of the file system, in the same way as ReadFontFile.
+func ReadFontFileFS(fsys fs.FS, name string) ([]*Font, error) {
+	return readFontFile(fsys, name)
+}
+
+// identifyFontFile attempts to identify the fonts contained in the specified
+// file by reading the font file. If that fails, the fonts are identified by
+// filename, using the specified registry. Font collections are not identified
+// using the registry, as the index of their faces is not known. If
+// identification is not possible, a font containing only the filename and the
+// style attributes extracted from it is returned. If the file system is nil,
+// the file is read from the OS file system. The returned error is the error
+// encountered while reading the font file, if any.
+func identifyFontFile(fsys fs.FS, filename string, reg *Registry) ([]*Font, error) {
+	fonts, err := readFontFile(fsys, filename)
+	if err == nil && len(fonts) == 0 {
+		err = errInvalidFont
+	}
+	if ext := fontFileExtension(filename); err != nil && ext != ".ttc" && ext != ".otc" {
+		fonts = reg.matchFontsByFilename(filename)
+		for _, font := range fonts {
+			font.FS = fsys
 		}
 	}
+	if len(fonts) == 0 {
+		basename := filepath.Base(filename)
+		weight, stretch, style := parseStyle(strings.TrimSuffix(basename, filepath.Ext(basename)))
 
-	return &Finder{
-		fonts: fonts,
+		fonts = append(fonts, &Font{
+			Filename: filename,
+			FS:       fsys,
+			Weight:   weight,
+			Stretch:  stretch,
+			Style:    style,
+		})
 	}
+
+	return fonts, err
 }
 
 // List returns the list of installed fonts. The finder attempts to identify
-// the name and family of the returned fonts. If identification is not possible,
-// only the filename field will be filled.
+// the name and family of the returned fonts by reading the name table of the
+// font files. If that fails, the fonts are identified by filename, using the
+// built-in font registry. If identification is not possible, only the filename
+// field will be filled.
 func (f *Finder) List() []*Font {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
 	fonts := make([]*Font, 0, len(f.fonts))
 	for _, font := range f.fonts {
 		fonts = append(fonts, font.clone())
@@ -96,32 +513,362 @@ func (f *Finder) List() []*Font {
 // Match attempts to identify the best matching installed font based on the
 // specified query. If no close match is found, alternative fonts are searched.
 // If no alternative font is found, a suitable default font is returned.
+//
+// The font family and the style attributes (weight, stretch and slant) are
+// extracted from the query (e.g. "Arial Bold Italic") and used for matching
+// the installed fonts, as described by the MatchQuery method. Generic font
+// families (e.g. serif, sans-serif, monospace) are supported. Queries can also
+// be specified as fontconfig patterns (e.g. "DejaVu Sans:style=Bold"), as
+// described by the ParsePattern function.
+//
+// Queries which match the X11 font name (e.g. -misc-fixed-*-r-*--13-*) or
+// one of the aliases of an installed font (e.g. fixed) return that font,
+// in the same way as X servers resolve font names. The names can contain
+// the * and ? wildcards. Names and aliases are defined by bitmap fonts and
+// by the fonts.dir and fonts.alias files found in the search paths. Other
+// X logical font descriptions (e.g. -adobe-helvetica-bold-r-*--12-*) are
+// parsed into queries, using their family, weight, slant, setwidth, pixel
+// size and character set fields. Fields containing wildcards are ignored,
+// except for the family and character set fields, which are matched as
+// patterns. A field consisting of a single * can span multiple fields
+// (e.g. -*-fixed-bold-*).
 func (f *Finder) Match(query string) *Font {
-	font := fontRegistry.matchFont(query, f.fonts)
+	if result := f.MatchDetails(query); result != nil {
+		return result.Font
+	}
+
+	return nil
+}
+
+// MatchQuery attempts to identify the best matching installed font based on
+// the specified query. The families of the query are searched in order and
+// the first family with a close match is used. The installed fonts in that
+// family are scored based on the similarity between their style attributes
+// and the ones of the query. If no close match is found for any of the query
+// families, alternative fonts are searched. If no alternative font is found,
+// a suitable default font is returned.
+//
+// Generic font families (e.g. FamilySerif, FamilyMonospace) are replaced by
+// ordered lists of font families in the same category. Font family aliases
+// provided through fontconfig configurations take priority over the built-in
+// lists.
+func (f *Finder) MatchQuery(query Query) *Font {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	if result := f.matchQuery(query); result != nil {
+		return result.Font.clone()
+	}
+
+	return nil
+}
+
+// MatchDetails attempts to identify the best matching installed font based on
+// the specified query, in the same way as the Match method. Along with the
+// font, the result specifies whether the font was found directly, through
+// a generic family alias, an alternative family group or the default font
+// families. A nil result is returned if no font is found.
+func (f *Finder) MatchDetails(query string) *MatchResult {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	// Match X11 font names and aliases.
+	result := f.matchXFontName(query)
+	if result == nil {
+		result = f.matchQuery(parseQuery(query))
+	}
+	if result == nil {
+		return nil
+	}
+
+	result.Font = result.Font.clone()
+	result.Group = append([]string(nil), result.Group...)
+	return result
+}
+
+// MatchQueryDetails attempts to identify the best matching installed font
+// based on the specified query, in the same way as the MatchQuery method.
+// Along with the font, the result specifies how the font was found.
+// A nil result is returned if no font is found.
+func (f *Finder) MatchQueryDetails(query Query) *MatchResult {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	result := f.matchQuery(query)
+	if result == nil {
+		return nil
+	}
+
+	result.Font = result.Font.clone()
+	result.Group = append([]string(nil), result.Group...)
+	return result
+}
+
+// MatchN returns the best n installed fonts for the specified query, along
+// with their scores. The query is parsed in the same way as by the Match
+// method, and the font identified by an X11 font name or alias is returned
+// first. If n is less than or equal to 0, all installed fonts are returned.
+// Unlike Match, MatchN does not search for alternative or default fonts.
+// The returned candidates can be used for suggesting fonts or for explaining
+// why a font was matched.
+func (f *Finder) MatchN(query string, n int) []*Candidate {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	candidates := f.scoreQuery(parseQuery(query), false)
+
+	// Prefer the font identified by the query as an X11 font name or alias.
+	if result := f.matchXFontName(query); result != nil {
+		candidates = preferCandidate(candidates, result.Font)
+	}
+
+	return cloneCandidates(candidates, n)
+}
+
+// MatchQueryN returns the best n installed fonts for the specified query,
+// along with their scores. Candidates are ordered by query family preference
+// and by score, as described by the MatchQuery method. If n is less than or
+// equal to 0, all installed fonts are returned.
+func (f *Finder) MatchQueryN(query Query, n int) []*Candidate {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	return cloneCandidates(f.scoreQuery(query, false), n)
+}
+
+// MatchExact attempts to identify the installed font which matches the
+// specified query, without substituting missing fonts. The query is parsed in
+// the same way as by the Match method, including X11 font names and aliases.
+// If none of the installed fonts match the query, a *FontNotFoundError is
+// returned, which can be checked against ErrFontNotFound using errors.Is.
+func (f *Finder) MatchExact(query string) (*Font, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	// Match X11 font names and aliases.
+	if result := f.matchXFontName(query); result != nil {
+		return result.Font.clone(), nil
+	}
+
+	return f.matchQueryExact(parseQuery(query))
+}
+
+// MatchQueryExact attempts to identify the installed font which matches the
+// specified query, without substituting missing fonts. Unlike MatchQuery,
+// the method does not search for alternative or default fonts, and only minor
+// variations of the query families are accepted. If none of the installed
+// fonts match the query, a *FontNotFoundError is returned, containing the
+// best rejected candidate, if any.
+func (f *Finder) MatchQueryExact(query Query) (*Font, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	return f.matchQueryExact(query)
+}
+
+// MatchCSS attempts to identify the installed font which would be selected by
+// a web browser for the specified query, using the font matching algorithm
+// defined by the CSS Fonts Module Level 4 specification. The families of the
+// query are searched in order, using case-insensitive comparison. The fonts of
+// the first family which is installed are narrowed down based on stretch, then
+// style and finally weight. If none of the query families are installed,
+// alternative font families are searched in the same way. If no alternative
+// font is found, a suitable default font is returned.
+func (f *Finder) MatchCSS(query Query) *Font {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	query = query.normalize()
+	query.Family, _ = f.registry.expandFamilies(query.Family)
+
+	fonts := query.filterFonts(f.fonts)
+
+	font := f.registry.matchCSS(query, fonts)
 	if font == nil {
-		font = f.findAlternative(query)
+		// Match alternative fonts, in order of preference.
+		var families []string
+		alternatives, _, _ := f.getAlternatives(query)
+		for _, alternative := range alternatives {
+			if !strutil.SliceContains(families, alternative.Family) {
+				families = append(families, alternative.Family)
+			}
+		}
+
+		query.Family = families
+		font = f.registry.matchCSS(query, fonts)
 	}
 
 	return font.clone()
 }
 
-func (f *Finder) findAlternative(query string) *Font {
-	// Identify font family.
-	family, _ := fontRegistry.matchFamily(query)
+// MatchForText attempts to identify the fonts required for rendering the
+// specified text. The primary font is identified based on the query, in the
+// same way as by the Match method. The characters of the text which are not
+// supported by the primary font are covered by a chain of fallback fonts. At
+// each step, the installed font supporting most of the remaining characters
+// is added to the chain, with ties broken in favor of fonts with similar
+// style attributes.
+// Characters not supported by any installed font are reported as missing.
+// Control and whitespace characters are ignored.
+func (f *Finder) MatchForText(query, text string) *TextMatch {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	// Match primary font, including X11 font names and aliases.
+	parsedQuery := parseQuery(query).normalize()
+	result := f.matchXFontName(query)
+	if result == nil {
+		result = f.matchQuery(parsedQuery)
+	}
+
+	match := &TextMatch{}
+	if result != nil {
+		match.Font = result.Font.clone()
+	}
+
+	// Identify unique characters which require coverage.
+	var runes []rune
+	seen := map[rune]bool{}
+	for _, r := range text {
+		if seen[r] || unicode.IsControl(r) || unicode.IsSpace(r) {
+			continue
+		}
+		seen[r] = true
+
+		if match.Font == nil || !match.Font.Covers(r) {
+			runes = append(runes, r)
+		}
+	}
+
+	// Identify fallback fonts.
+	for len(runes) > 0 {
+		var maxCount int
+		var maxScore float64
+		var maxCountFont *Font
+
+		for _, font := range f.fonts {
+			var count int
+			for _, r := range runes {
+				if font.Covers(r) {
+					count++
+				}
+			}
+			if count == 0 || count < maxCount {
+				continue
+			}
+
+			score := getStyleScore(parsedQuery, font)
+			if count > maxCount || score > maxScore {
+				maxCount = count
+				maxScore = score
+				maxCountFont = font
+			}
+		}
+		if maxCountFont == nil {
+			break
+		}
+		match.Fallbacks = append(match.Fallbacks, maxCountFont.clone())
+
+		// Remove covered characters.
+		remaining := runes[:0]
+		for _, r := range runes {
+			if !maxCountFont.Covers(r) {
+				remaining = append(remaining, r)
+			}
+		}
+		runes = remaining
+	}
+	match.Missing = runes
+
+	return match
+}
+
+func (f *Finder) matchQuery(query Query) *MatchResult {
+	query = query.normalize()
+
+	var groups [][]string
+	query.Family, groups = f.registry.expandFamilies(query.Family)
+
+	// Match query families.
+	if candidate := f.registry.matchCandidate(query, groups, query.filterFonts(f.fonts)); candidate != nil {
+		result := &MatchResult{Font: candidate.Font, Score: candidate.Score}
+		if group := groups[candidate.rank]; group != nil {
+			result.Source = SourceAlias
+			result.Group = group
+		}
+
+		return result
+	}
+
+	// Match alternative families.
+	font, group, defaults := f.findAlternative(query)
+	if font == nil {
+		return nil
+	}
+
+	result := &MatchResult{
+		Font:   font,
+		Source: SourceAlternative,
+		Group:  group,
+		Score:  getStyleScore(query, font),
+	}
+	if defaults {
+		result.Source = SourceDefault
+	}
+
+	return result
+}
+
+func (f *Finder) matchQueryExact(query Query) (*Font, error) {
+	candidates := f.scoreQuery(query, true)
+	if len(candidates) > 0 && candidates[0].matched {
+		return candidates[0].Font.clone(), nil
+	}
+
+	err := &FontNotFoundError{Query: query}
+	if len(candidates) > 0 {
+		candidate := *candidates[0]
+		candidate.Font = candidate.Font.clone()
+		err.Candidate = &candidate
+	}
+
+	return nil, err
+}
+
+func (f *Finder) scoreQuery(query Query, strict bool) []*Candidate {
+	query = query.normalize()
+
+	var groups [][]string
+	query.Family, groups = f.registry.expandFamilies(query.Family)
+
+	return f.registry.scoreQuery(query, groups, query.filterFonts(f.fonts), strict)
+}
+
+func (f *Finder) getAlternatives(query Query) ([]*Font, []string, bool) {
+	// Identify font families.
+	families := make([]string, 0, len(query.Family))
+	for _, family := range query.Family {
+		family, _ = f.registry.matchFamily(family)
+		families = append(families, family)
+	}
+
+	// Identify alternate fonts based on the matched families.
+	return f.registry.getAlternatives(families, query.filterFonts(f.fonts))
+}
 
-	// Identify alternate fonts based on the matched family.
-	alternatives := fontRegistry.getAlternatives(family, f.fonts)
+func (f *Finder) findAlternative(query Query) (*Font, []string, bool) {
+	alternatives, group, defaults := f.getAlternatives(query)
 
 	// Identify best alternative.
 	var maxScore float64
 	var maxScoreFont *Font
 
 	for _, font := range alternatives {
-		if score := getFontStyleScore(query, font.Name); score > maxScore {
+		if score := getStyleScore(query, font); score > maxScore {
 			maxScore = score
 			maxScoreFont = font
 		}
 	}
 
-	return maxScoreFont
+	return maxScoreFont, group, defaults
 }
@@ -0,0 +1,733 @@
+package sysfont
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+// newTestFinder returns a finder for BDF fonts having the specified X logical
+// font descriptions.
+func newTestFinder(t *testing.T, names ...string) *Finder {
+	t.Helper()
+
+	fsys := fstest.MapFS{}
+	for i, name := range names {
+		fsys[string(rune('a'+i))+".bdf"] = &fstest.MapFile{
+			Data: []byte("STARTFONT 2.1\nFONT " + name + "\nCHARS 0\nENDFONT\n"),
+		}
+	}
+
+	return NewFinder(&FinderOpts{FS: fsys, Extensions: []string{".bdf"}})
+}
+
+func TestMatchExact(t *testing.T) {
+	finder := newTestFinder(t,
+		"-misc-DejaVu Sans-medium-r-normal--13-120-75-75-p-70-iso10646-1",
+		"-misc-DejaVu Sans-bold-r-normal--13-120-75-75-p-70-iso10646-1",
+		"-misc-ArialMT-medium-r-normal--13-120-75-75-p-70-iso10646-1",
+	)
+
+	tests := []struct {
+		query string
+		name  string
+	}{
+		{"DejaVu Sans", "DejaVu Sans"},
+		{"DejaVu Sans Bold", "DejaVu Sans Bold"},
+		{"dejavu-sans", "DejaVu Sans"},
+		{"Arial", "ArialMT"},
+		{"DejaVu Sans Mono", ""},
+		{"DejaVu", ""},
+		{"Helvetica", ""},
+	}
+
+	for _, test := range tests {
+		font, err := finder.MatchExact(test.query)
+		if test.name == "" {
+			if !errors.Is(err, ErrFontNotFound) {
+				t.Errorf("MatchExact(%q): got %v, %v, want ErrFontNotFound", test.query, font, err)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("MatchExact(%q): unexpected error: %v", test.query, err)
+			continue
+		}
+		if font.Name != test.name {
+			t.Errorf("MatchExact(%q): got %q, want %q", test.query, font.Name, test.name)
+		}
+	}
+}
+
+func TestMatchStyleWords(t *testing.T) {
+	finder := newTestFinder(t,
+		"-misc-DejaVu Sans-medium-r-normal--13-120-75-75-p-70-iso10646-1",
+		"-misc-DejaVu Sans-bold-r-normal--13-120-75-75-p-70-iso10646-1",
+		"-misc-DejaVu Sans-medium-r-condensed--13-120-75-75-p-70-iso10646-1",
+		"-misc-DejaVu Sans-black-r-normal--13-120-75-75-p-70-iso10646-1",
+		"-misc-DejaVu Sans Mono-medium-r-normal--13-120-75-75-c-70-iso10646-1",
+		"-misc-DejaVu Sans Mono-bold-r-normal--13-120-75-75-c-70-iso10646-1",
+	)
+
+	tests := []struct {
+		query string
+		name  string
+	}{
+		{"DejaVu Sans Book", "DejaVu Sans"},
+		{"DejaVu Sans Roman", "DejaVu Sans"},
+		{"DejaVu Sans Plain", "DejaVu Sans"},
+		{"DejaVu Sans Condensed", "DejaVu Sans Condensed"},
+		{"DejaVu Sans Narrow", "DejaVu Sans Condensed"},
+		{"DejaVu Sans Black", "DejaVu Sans Black"},
+		{"DejaVu Sans Mono Book", "DejaVu Sans Mono"},
+		{"DejaVu Sans Mono Roman Bold", "DejaVu Sans Mono Bold"},
+	}
+
+	for _, test := range tests {
+		if candidates := finder.MatchN(test.query, 1); len(candidates) != 1 || candidates[0].Font.Name != test.name {
+			t.Errorf("MatchN(%q): got %v, want %q", test.query, candidates, test.name)
+		}
+
+		result := finder.MatchDetails(test.query)
+		if result == nil || result.Font.Name != test.name || result.Source != SourceExact {
+			t.Errorf("MatchDetails(%q): got %+v, want exact match %q", test.query, result, test.name)
+		}
+
+		if font, err := finder.MatchExact(test.query); err != nil || font.Name != test.name {
+			t.Errorf("MatchExact(%q): got %v, %v, want %q", test.query, font, err, test.name)
+		}
+	}
+}
+
+// cancelFS wraps a file system, recording the opened files and canceling a
+// context when the specified file is opened.
+type cancelFS struct {
+	fs.FS
+	name   string
+	cancel context.CancelFunc
+
+	mu     sync.Mutex
+	opened map[string]bool
+}
+
+func (fsys *cancelFS) Open(name string) (fs.File, error) {
+	fsys.mu.Lock()
+	fsys.opened[name] = true
+	fsys.mu.Unlock()
+
+	if name == fsys.name {
+		fsys.cancel()
+	}
+	return fsys.FS.Open(name)
+}
+
+func (fsys *cancelFS) isOpened(name string) bool {
+	fsys.mu.Lock()
+	defer fsys.mu.Unlock()
+
+	return fsys.opened[name]
+}
+
+func TestNewFinderContext(t *testing.T) {
+	mapFS := fstest.MapFS{"fonts.dir": {Data: []byte("0\n")}}
+	for i, family := range []string{"Alpha", "Beta", "Gamma", "Delta"} {
+		mapFS[string(rune('a'+i))+".bdf"] = &fstest.MapFile{
+			Data: []byte("STARTFONT 2.1\nFONT -misc-" + family + "-medium-r-normal--13-120-75-75-p-70-iso10646-1\nCHARS 0\nENDFONT\n"),
+		}
+	}
+
+	tests := []struct {
+		name     string
+		cancelOn string
+		expired  bool
+		min      []string
+		max      []string
+		err      error
+	}{
+		{
+			name: "complete search",
+			min:  []string{"Alpha", "Beta", "Gamma", "Delta"},
+			max:  []string{"Alpha", "Beta", "Gamma", "Delta"},
+		},
+		{
+			name:     "canceled while walking",
+			cancelOn: ".",
+			err:      context.Canceled,
+		},
+		{
+			name:    "expired context",
+			expired: true,
+			err:     context.DeadlineExceeded,
+		},
+		{
+			name:     "canceled while reading fonts",
+			cancelOn: "c.bdf",
+			min:      []string{"Alpha", "Beta"},
+			max:      []string{"Alpha", "Beta", "Gamma", "Delta"},
+			err:      context.Canceled,
+		},
+	}
+
+	for _, test := range tests {
+		ctx, cancel := context.WithCancel(context.Background())
+		if test.expired {
+			ctx, cancel = context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+		}
+		fsys := &cancelFS{FS: mapFS, name: test.cancelOn, cancel: cancel, opened: map[string]bool{}}
+
+		finder, err := NewFinderContext(ctx, &FinderOpts{FS: fsys, Extensions: []string{".bdf"}, Workers: 1})
+		cancel()
+		if err != test.err {
+			t.Errorf("%s: got error %v, want %v", test.name, err, test.err)
+		}
+		if finder == nil {
+			t.Errorf("%s: got nil finder", test.name)
+			continue
+		}
+
+		// The fonts identified before the context was done are returned.
+		found := map[string]bool{}
+		for _, font := range finder.List() {
+			found[font.Family] = true
+		}
+		for _, family := range test.min {
+			if !found[family] {
+				t.Errorf("%s: missing font %q", test.name, family)
+			}
+		}
+		for _, family := range test.max {
+			delete(found, family)
+		}
+		for family := range found {
+			t.Errorf("%s: unexpected font %q", test.name, family)
+		}
+
+		// X11 font files are not read after the context is done.
+		if opened := fsys.isOpened(fontsDirFile); opened != (test.err == nil) {
+			t.Errorf("%s: got fonts.dir read %t, want %t", test.name, opened, test.err == nil)
+		}
+	}
+}
+
+// testFontFS returns a file system containing TrueType fonts, named after
+// their families, in nested directories.
+func testFontFS() fstest.MapFS {
+	font := func(family string) *fstest.MapFile {
+		return &fstest.MapFile{Data: buildSFNT(sfntVersionTrueType, map[string][]byte{
+			"name": testNames(map[uint16]string{nameFamily: family}),
+		})}
+	}
+
+	return fstest.MapFS{
+		"root.ttf":                 font("Root"),
+		"fonts/alpha.ttf":          font("Alpha"),
+		"fonts/readme.txt":         {Data: []byte("Alpha")},
+		"fonts/nested/beta.otf":    font("Beta"),
+		"fonts/nested/deep/go.ttf": font("Go"),
+		"other/gamma.ttf":          font("Gamma"),
+	}
+}
+
+func TestWalkSearchPathFS(t *testing.T) {
+	fsys := testFontFS()
+
+	tests := []struct {
+		name        string
+		dir         string
+		searchPaths []string
+		files       []string
+		errs        int
+	}{
+		{
+			name:        "root",
+			dir:         ".",
+			searchPaths: []string{"."},
+			files: []string{
+				"fonts/alpha.ttf",
+				"fonts/nested/beta.otf",
+				"fonts/nested/deep/go.ttf",
+				"fonts/readme.txt",
+				"other/gamma.ttf",
+				"root.ttf",
+			},
+		},
+		{
+			name:        "nested directory",
+			dir:         "fonts/nested",
+			searchPaths: []string{"fonts/nested", "other"},
+			files:       []string{"fonts/nested/beta.otf", "fonts/nested/deep/go.ttf"},
+		},
+		{
+			name:        "missing search path",
+			dir:         "missing",
+			searchPaths: []string{"fonts", "missing"},
+		},
+		{
+			name:        "missing directory",
+			dir:         "fonts/missing",
+			searchPaths: []string{"fonts"},
+			errs:        1,
+		},
+	}
+
+	for _, test := range tests {
+		walk := walkSearchPath(context.Background(), fsys, nil, test.dir, test.searchPaths)
+
+		var files []string
+		for _, file := range walk.files {
+			files = append(files, file.path)
+			if file.info == nil || file.info.Size() != int64(len(fsys[file.path].Data)) {
+				t.Errorf("%s: got invalid info %v for %q", test.name, file.info, file.path)
+			}
+		}
+		if !reflect.DeepEqual(files, test.files) {
+			t.Errorf("%s: got files %q, want %q", test.name, files, test.files)
+		}
+		if len(walk.errors) != test.errs {
+			t.Errorf("%s: got errors %v, want %d errors", test.name, walk.errors, test.errs)
+		}
+	}
+}
+
+func TestFinderFS(t *testing.T) {
+	fsys := testFontFS()
+
+	tests := []struct {
+		name        string
+		searchPaths []string
+		families    []string
+	}{
+		{
+			name:     "default search path",
+			families: []string{"Alpha", "Beta", "Go", "Gamma", "Root"},
+		},
+		{
+			name:        "nested search paths",
+			searchPaths: []string{"fonts/nested", "missing", "other"},
+			families:    []string{"Beta", "Go", "Gamma"},
+		},
+	}
+
+	for _, test := range tests {
+		finder := NewFinder(&FinderOpts{
+			FS:          fsys,
+			SearchPaths: test.searchPaths,
+			Extensions:  []string{".ttf", ".otf"},
+		})
+		if errs := finder.Errors(); len(errs) != 0 {
+			t.Errorf("%s: got errors %v", test.name, errs)
+		}
+
+		var families []string
+		for _, font := range finder.List() {
+			families = append(families, font.Family)
+			if font.FS == nil {
+				t.Errorf("%s: font %q has no file system", test.name, font.Filename)
+				continue
+			}
+
+			// Fonts are opened from the file system of the finder.
+			f, err := font.Open()
+			if err != nil {
+				t.Errorf("%s: cannot open font %q: %v", test.name, font.Filename, err)
+				continue
+			}
+			data, err := io.ReadAll(f)
+			f.Close()
+			if err != nil || !bytes.Equal(data, fsys[font.Filename].Data) {
+				t.Errorf("%s: got %d bytes for font %q, want %d", test.name, len(data), font.Filename, len(fsys[font.Filename].Data))
+			}
+		}
+		if !reflect.DeepEqual(families, test.families) {
+			t.Errorf("%s: got families %q, want %q", test.name, families, test.families)
+		}
+	}
+}
+
+func TestReadFontFileFS(t *testing.T) {
+	fsys := testFontFS()
+
+	tests := []struct {
+		file   string
+		family string
+		err    error
+	}{
+		{"root.ttf", "Root", nil},
+		{"fonts/nested/deep/go.ttf", "Go", nil},
+		{"fonts/readme.txt", "", errInvalidFont},
+		{"fonts/missing.ttf", "", fs.ErrNotExist},
+	}
+
+	for _, test := range tests {
+		fonts, err := ReadFontFileFS(fsys, test.file)
+		if !errors.Is(err, test.err) {
+			t.Errorf("%s: got error %v, want %v", test.file, err, test.err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+
+		if len(fonts) != 1 || fonts[0].Family != test.family || fonts[0].Filename != test.file || fonts[0].FS == nil {
+			t.Errorf("%s: got fonts %+v, want family %q", test.file, fonts, test.family)
+			continue
+		}
+
+		f, err := fonts[0].Open()
+		if err != nil {
+			t.Errorf("%s: cannot open font: %v", test.file, err)
+			continue
+		}
+		data, err := io.ReadAll(f)
+		f.Close()
+		if err != nil || !bytes.Equal(data, fsys[test.file].Data) {
+			t.Errorf("%s: got %d bytes, want %d", test.file, len(data), len(fsys[test.file].Data))
+		}
+	}
+
+	// Fonts read from the OS file system do not have a file system.
+	filename := filepath.Join(t.TempDir(), "root.ttf")
+	if err := os.WriteFile(filename, fsys["root.ttf"].Data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	fonts, err := ReadFontFile(filename)
+	if err != nil || len(fonts) != 1 || fonts[0].FS != nil {
+		t.Fatalf("%s: got fonts %+v, error %v", filename, fonts, err)
+	}
+	f, err := fonts[0].Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if info, err := f.Stat(); err != nil || info.Size() != int64(len(fsys["root.ttf"].Data)) {
+		t.Errorf("%s: got info %v, error %v", filename, info, err)
+	}
+}
+
+func TestFinderWorkers(t *testing.T) {
+	fsys := testFontFS()
+	for i := 0; i < 40; i++ {
+		name := fmt.Sprintf("dir%d/sub%d/font%02d.ttf", i%3, i%5, i)
+		fsys[name] = &fstest.MapFile{Data: buildSFNT(sfntVersionTrueType, map[string][]byte{
+			"name": testNames(map[uint16]string{nameFamily: fmt.Sprintf("Font %02d", i)}),
+		})}
+	}
+	searchPaths := []string{"other", "dir2", "fonts", "dir0", ".", "dir1"}
+
+	var want []string
+	for workers := 1; workers <= 8; workers++ {
+		finder := NewFinder(&FinderOpts{
+			FS:          fsys,
+			SearchPaths: searchPaths,
+			Extensions:  []string{".ttf", ".otf"},
+			Workers:     workers,
+		})
+
+		var got []string
+		for _, font := range finder.List() {
+			got = append(got, font.Filename+": "+font.Family)
+		}
+		if workers == 1 {
+			want = got
+			continue
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%d workers: got fonts %q, want %q", workers, got, want)
+		}
+	}
+
+	// The fonts are reported in search path order, followed by traversal order.
+	if len(want) == 0 || want[0] != "other/gamma.ttf: Gamma" || want[1] != "dir2/sub0/font05.ttf: Font 05" {
+		t.Errorf("got fonts %q", want)
+	}
+}
+
+func TestMatchN(t *testing.T) {
+	xlfd := func(family, weight string) string {
+		return "-misc-" + family + "-" + weight + "-r-normal--13-120-75-75-p-70-iso10646-1"
+	}
+	fsys := fstest.MapFS{
+		"fonts.alias": {Data: []byte("gothic " + xlfd("Go", "bold") + "\n")},
+	}
+	for i, name := range []string{
+		xlfd("Go", "medium"),
+		xlfd("Go", "bold"),
+		xlfd("Go Mono", "medium"),
+		xlfd("Gothic Sans", "medium"),
+		xlfd("Gothic Sans", "bold"),
+	} {
+		fsys[string(rune('a'+i))+".bdf"] = &fstest.MapFile{
+			Data: []byte("STARTFONT 2.1\nFONT " + name + "\nCHARS 0\nENDFONT\n"),
+		}
+	}
+	finder := NewFinder(&FinderOpts{FS: fsys, Extensions: []string{".bdf"}})
+
+	names := func(candidates []*Candidate) []string {
+		var names []string
+		for _, candidate := range candidates {
+			names = append(names, candidate.Font.Name)
+		}
+		return names
+	}
+
+	// Candidates are ordered by score.
+	all := finder.MatchN("Gothic Sans Bold", 0)
+	if want := []string{"Gothic Sans Bold", "Gothic Sans"}; len(all) != 5 || !reflect.DeepEqual(names(all[:2]), want) {
+		t.Errorf("got candidates %q, want %q first", names(all), want)
+	}
+	for i := 1; i < len(all); i++ {
+		if all[i-1].matched == all[i].matched && all[i-1].Score < all[i].Score {
+			t.Errorf("got candidate %q with score %v before %q with score %v",
+				all[i-1].Font.Name, all[i-1].Score, all[i].Font.Name, all[i].Score)
+		}
+	}
+
+	// The number of candidates is limited to n.
+	for _, n := range []int{1, 3, 5, 10} {
+		want := n
+		if want > len(all) {
+			want = len(all)
+		}
+		if got := finder.MatchN("Gothic Sans Bold", n); !reflect.DeepEqual(names(got), names(all[:want])) {
+			t.Errorf("n=%d: got candidates %q, want %q", n, names(got), names(all[:want]))
+		}
+	}
+
+	// The font identified by an X11 alias is moved to the front, keeping
+	// its own scores.
+	scored := finder.MatchQueryN(parseQuery("gothic"), 0)
+	aliased := finder.MatchN("gothic", 0)
+	if len(aliased) != len(scored) || aliased[0].Font.Name != "Go Bold" {
+		t.Fatalf("got candidates %q, want Go Bold first", names(aliased))
+	}
+
+	var rest []string
+	for _, candidate := range scored {
+		if candidate.Font.Name == "Go Bold" {
+			if aliased[0].Font.Filename != candidate.Font.Filename || aliased[0].Score != candidate.Score ||
+				aliased[0].FamilyScore != candidate.FamilyScore || aliased[0].StyleScore != candidate.StyleScore {
+				t.Errorf("got alias candidate %+v, want scores of %+v", aliased[0], candidate)
+			}
+			continue
+		}
+		rest = append(rest, candidate.Font.Name)
+	}
+	if !reflect.DeepEqual(names(aliased[1:]), rest) {
+		t.Errorf("got candidates %q after alias, want %q", names(aliased[1:]), rest)
+	}
+	if got := finder.MatchN("gothic", 1); len(got) != 1 || got[0].Font.Name != "Go Bold" || got[0].Score >= 2 {
+		t.Errorf("n=1: got alias candidates %+v", got)
+	}
+}
+
+func TestMatchDetails(t *testing.T) {
+	finder := newTestFinder(t,
+		"-misc-DejaVu Sans-medium-r-normal--13-120-75-75-p-70-iso10646-1",
+		"-misc-DejaVu Sans-bold-r-normal--13-120-75-75-p-70-iso10646-1",
+		"-misc-DejaVu Sans Mono-medium-r-normal--13-120-75-75-p-70-iso10646-1",
+		"-misc-Liberation Serif-medium-r-normal--13-120-75-75-p-70-iso10646-1",
+	)
+
+	// Use a registry with known alternatives and defaults.
+	reg := NewRegistry()
+	reg.AddAlternatives("Verdana", "Tahoma", "DejaVu Sans")
+	reg.AddAlternatives(FamilyMonospace, "Courier New", "DejaVu Sans Mono")
+	reg.SetDefaults("Courier New", "Liberation Serif")
+	finder.registry = reg
+
+	tests := []struct {
+		name   string
+		query  Query
+		font   string
+		source MatchSource
+		group  []string
+	}{
+		{
+			name:   "exact",
+			query:  parseQuery("DejaVu Sans Bold"),
+			font:   "DejaVu Sans Bold",
+			source: SourceExact,
+		},
+		{
+			name:   "alias",
+			query:  parseQuery("monospace"),
+			font:   "DejaVu Sans Mono",
+			source: SourceAlias,
+			group:  []string{"Courier New", "DejaVu Sans Mono"},
+		},
+		{
+			name:   "alias variant",
+			query:  parseQuery("Mono Bold"),
+			font:   "DejaVu Sans Mono",
+			source: SourceAlias,
+			group:  []string{"Courier New", "DejaVu Sans Mono"},
+		},
+		{
+			name:   "exact before alias",
+			query:  Query{Family: []string{"Liberation Serif", "monospace"}},
+			font:   "Liberation Serif",
+			source: SourceExact,
+		},
+		{
+			name:   "alias after missing family",
+			query:  Query{Family: []string{"Tahoma", "monospace"}},
+			font:   "DejaVu Sans Mono",
+			source: SourceAlias,
+			group:  []string{"Courier New", "DejaVu Sans Mono"},
+		},
+		{
+			name:   "alternative",
+			query:  parseQuery("Verdana Bold"),
+			font:   "DejaVu Sans Bold",
+			source: SourceAlternative,
+			group:  []string{"Verdana", "Tahoma", "DejaVu Sans"},
+		},
+		{
+			name:   "default",
+			query:  parseQuery("Comic Sans"),
+			font:   "Liberation Serif",
+			source: SourceDefault,
+			group:  []string{"Courier New", "Liberation Serif"},
+		},
+		{
+			name:   "generic family without alternatives",
+			query:  parseQuery("sans-serif"),
+			font:   "Liberation Serif",
+			source: SourceDefault,
+			group:  []string{"Courier New", "Liberation Serif"},
+		},
+	}
+
+	for _, test := range tests {
+		result := finder.MatchQueryDetails(test.query)
+		if result == nil {
+			t.Errorf("%s: got no result", test.name)
+			continue
+		}
+		if result.Font.Name != test.font || result.Source != test.source || !reflect.DeepEqual(result.Group, test.group) {
+			t.Errorf("%s: got %q from %s group %q, want %q from %s group %q",
+				test.name, result.Font.Name, result.Source, result.Group, test.font, test.source, test.group)
+		}
+	}
+}
+
+var errTestFS = errors.New("test file system error")
+
+// faultyFS wraps a file system, failing the operations on the specified
+// files and directories.
+type faultyFS struct {
+	fstest.MapFS
+	faulty map[string]bool
+}
+
+func (fsys faultyFS) Open(name string) (fs.File, error) {
+	if fsys.faulty[name] {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: errTestFS}
+	}
+	return fsys.MapFS.Open(name)
+}
+
+func (fsys faultyFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	if fsys.faulty[name] {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: errTestFS}
+	}
+	return fsys.MapFS.ReadDir(name)
+}
+
+func TestFinderErrors(t *testing.T) {
+	fsys := fstest.MapFS{}
+	for name, family := range map[string]string{
+		"a/one.bdf":       "One",
+		"b/two.bdf":       "Two",
+		"b/sub/three.bdf": "Three",
+		"c/bad.bdf":       "Bad",
+		"c/four.bdf":      "Four",
+		"d/five.bdf":      "Five",
+	} {
+		fsys[name] = &fstest.MapFile{
+			Data: []byte("STARTFONT 2.1\nFONT -misc-" + family + "-medium-r-normal--13-120-75-75-p-70-iso10646-1\nCHARS 0\nENDFONT\n"),
+		}
+	}
+
+	finder := NewFinder(&FinderOpts{
+		FS:          faultyFS{MapFS: fsys, faulty: map[string]bool{"b": true, "c/bad.bdf": true}},
+		SearchPaths: []string{".", "missing"},
+		Extensions:  []string{".bdf"},
+	})
+
+	// The walk carries on past the unreadable entries.
+	var families []string
+	for _, font := range finder.List() {
+		if font.Family != "" {
+			families = append(families, font.Family)
+		}
+	}
+	if want := []string{"One", "Four", "Five"}; !reflect.DeepEqual(families, want) {
+		t.Errorf("got families %q, want %q", families, want)
+	}
+
+	// The errors of the unreadable entries are collected, in traversal
+	// order. Missing search paths are not reported.
+	var paths []string
+	for _, err := range finder.Errors() {
+		var pathErr *fs.PathError
+		if !errors.Is(err, errTestFS) || !errors.As(err, &pathErr) {
+			t.Errorf("got unexpected error %v", err)
+			continue
+		}
+		paths = append(paths, pathErr.Path)
+	}
+	if want := []string{"b", "c/bad.bdf"}; !reflect.DeepEqual(paths, want) {
+		t.Errorf("got errors for %q, want %q", paths, want)
+	}
+}
+
+func TestMatchQuery(t *testing.T) {
+	finder := newTestFinder(t,
+		"-misc-Go-medium-r-normal--13-120-75-75-p-70-iso10646-1",
+		"-misc-Go-bold-r-normal--13-120-75-75-p-70-iso10646-1",
+		"-misc-Go-light-r-normal--13-120-75-75-p-70-iso10646-1",
+		"-misc-Go-black-r-normal--13-120-75-75-p-70-iso10646-1",
+		"-misc-Go-medium-i-normal--13-120-75-75-p-70-iso10646-1",
+		"-misc-Go-bold-o-normal--13-120-75-75-p-70-iso10646-1",
+		"-misc-Go-medium-r-condensed--13-120-75-75-p-70-iso10646-1",
+		"-misc-Go Mono-medium-r-normal--13-120-75-75-p-70-iso10646-1",
+	)
+
+	tests := []struct {
+		name  string
+		query Query
+		font  string
+	}{
+		{"normal style", Query{Family: []string{"Go"}}, "Go"},
+		{"family order", Query{Family: []string{"Missing", "Go Mono", "Go"}, Weight: WeightBold}, "Go Mono"},
+		{"first family", Query{Family: []string{"go", "Go Mono"}}, "Go"},
+		{"similar family", Query{Family: []string{"Go Mono"}}, "Go Mono"},
+		{"bold weight", Query{Family: []string{"Go"}, Weight: WeightBold}, "Go Bold"},
+		{"closest weight", Query{Family: []string{"Go"}, Weight: WeightSemiBold}, "Go Bold"},
+		{"heavier weight", Query{Family: []string{"Go"}, Weight: 850}, "Go Black"},
+		{"lighter weight", Query{Family: []string{"Go"}, Weight: 250}, "Go Light"},
+		{"italic style", Query{Family: []string{"Go"}, Style: StyleItalic}, "Go Italic"},
+		{"oblique style", Query{Family: []string{"Go"}, Style: StyleOblique}, "Go Italic"},
+		{"bold oblique style", Query{Family: []string{"Go"}, Weight: WeightBold, Style: StyleOblique}, "Go Bold Oblique"},
+		{"condensed stretch", Query{Family: []string{"Go"}, Stretch: StretchCondensed}, "Go Condensed"},
+		{"weight over stretch", Query{Family: []string{"Go"}, Weight: WeightBold, Stretch: StretchCondensed}, "Go Bold"},
+	}
+
+	for _, test := range tests {
+		if font := finder.MatchQuery(test.query); font == nil || font.Name != test.font {
+			t.Errorf("%s: got %v, want %q", test.name, font, test.font)
+		}
+	}
+}
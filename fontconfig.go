@@ -0,0 +1,440 @@
+package sysfont
+
+import (
+	"encoding/xml"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/adrg/strutil"
+	"github.com/adrg/xdg"
+)
+
+// FontConfig contains font settings read from fontconfig configuration files
+// (e.g. /etc/fonts/fonts.conf). It can be used for configuring font finders
+// through the FontConfig field of FinderOpts.
+type FontConfig struct {
+	// Dirs contains the font directories specified by the configuration.
+	Dirs []string
+
+	// Aliases contains the font family aliases specified by the
+	// configuration. Aliases of the same family are merged.
+	Aliases []*FontAlias
+
+	// Rejects contains the rules used for excluding fonts.
+	Rejects []*FontRule
+
+	// Accepts contains the rules used for including fonts which would
+	// otherwise be excluded by the reject rules.
+	Accepts []*FontRule
+}
+
+// FontAlias represents a font family alias. The families which can be used
+// in place of the aliased family are grouped by preference.
+type FontAlias struct {
+	// Family contains the aliased font family.
+	Family string
+
+	// Prefer contains the families preferred over the aliased family.
+	Prefer []string
+
+	// Accept contains the families used after the aliased family.
+	Accept []string
+
+	// Default contains the families used as a last resort.
+	Default []string
+}
+
+// Families returns the alias families in order of preference: the preferred
+// families, followed by the aliased family, the accepted families and the
+// default families.
+func (a *FontAlias) Families() []string {
+	families := make([]string, 0, len(a.Prefer)+len(a.Accept)+len(a.Default)+1)
+	families = append(families, a.Prefer...)
+	families = append(families, a.Family)
+	families = append(families, a.Accept...)
+	families = append(families, a.Default...)
+
+	return families
+}
+
+// FontRule represents a rule used for selecting fonts.
+type FontRule struct {
+	// Globs contains file path patterns. The * wildcard matches any sequence
+	// of characters, including path separators, while the ? wildcard matches
+	// any single character.
+	Globs []string
+
+	// Patterns contains font property patterns. Each pattern maps property
+	// names (family, style, fullname, file) to their accepted values.
+	// A pattern matches a font if all its properties match.
+	Patterns []map[string][]string
+}
+
+// Matches reports whether the specified font matches the rule.
+func (r *FontRule) Matches(font *Font) bool {
+	for _, glob := range r.Globs {
+		if matchGlob(glob, font.Filename) {
+			return true
+		}
+	}
+
+	for _, pattern := range r.Patterns {
+		if matchFontPattern(pattern, font) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Rejected reports whether the specified font is excluded by the
+// configuration. Fonts are excluded if they match any of the reject rules
+// and none of the accept rules.
+func (c *FontConfig) Rejected(font *Font) bool {
+	for _, rule := range c.Accepts {
+		if rule.Matches(font) {
+			return false
+		}
+	}
+	for _, rule := range c.Rejects {
+		if rule.Matches(font) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// LoadFontConfig reads the fontconfig configuration file found at the
+// specified path. The files referenced by include elements are also read.
+func LoadFontConfig(filename string) (*FontConfig, error) {
+	config := &FontConfig{}
+	if err := config.load(filename, map[string]bool{}); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// LoadDefaultFontConfig reads the default fontconfig configuration file.
+// The file specified by the FONTCONFIG_FILE environment variable is used,
+// if set. Otherwise, the fonts.conf file is searched for in the directory
+// specified by the FONTCONFIG_PATH environment variable, falling back to
+// the /etc/fonts directory.
+func LoadDefaultFontConfig() (*FontConfig, error) {
+	filename := os.Getenv("FONTCONFIG_FILE")
+	if filename == "" {
+		dir := os.Getenv("FONTCONFIG_PATH")
+		if dir == "" {
+			dir = "/etc/fonts"
+		}
+		filename = filepath.Join(dir, "fonts.conf")
+	}
+
+	return LoadFontConfig(filename)
+}
+
+// fcConfig represents the elements of a fontconfig configuration file.
+type fcConfig struct {
+	Dirs        []fcPath       `xml:"dir"`
+	Includes    []fcPath       `xml:"include"`
+	Aliases     []fcAlias      `xml:"alias"`
+	SelectFonts []fcSelectFont `xml:"selectfont"`
+}
+
+type fcPath struct {
+	Path          string `xml:",chardata"`
+	Prefix        string `xml:"prefix,attr"`
+	IgnoreMissing string `xml:"ignore_missing,attr"`
+}
+
+type fcAlias struct {
+	Family  []string   `xml:"family"`
+	Prefer  fcFamilies `xml:"prefer"`
+	Accept  fcFamilies `xml:"accept"`
+	Default fcFamilies `xml:"default"`
+}
+
+type fcFamilies struct {
+	Family []string `xml:"family"`
+}
+
+type fcSelectFont struct {
+	Accept []fcRule `xml:"acceptfont"`
+	Reject []fcRule `xml:"rejectfont"`
+}
+
+type fcRule struct {
+	Globs    []string    `xml:"glob"`
+	Patterns []fcPattern `xml:"pattern"`
+}
+
+type fcPattern struct {
+	Elements []struct {
+		Name   string   `xml:"name,attr"`
+		Values []string `xml:",any"`
+	} `xml:"patelt"`
+}
+
+func (c *FontConfig) load(filename string, visited map[string]bool) error {
+	if filename, err := filepath.Abs(filename); err == nil {
+		if visited[filename] {
+			return nil
+		}
+		visited[filename] = true
+	}
+
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+
+	var fc fcConfig
+	if err := xml.Unmarshal(data, &fc); err != nil {
+		return &os.PathError{Op: "parse", Path: filename, Err: err}
+	}
+	baseDir := filepath.Dir(filename)
+
+	// Add font directories.
+	for _, dir := range fc.Dirs {
+		if path := resolveFontConfigPath(dir, baseDir, xdg.DataHome); path != "" &&
+			!strutil.SliceContains(c.Dirs, path) {
+			c.Dirs = append(c.Dirs, path)
+		}
+	}
+
+	// Add font family aliases.
+	for _, alias := range fc.Aliases {
+		for _, family := range alias.Family {
+			if family = strings.TrimSpace(family); family == "" {
+				continue
+			}
+
+			a := c.alias(family)
+			a.Prefer = appendFamilies(a.Prefer, alias.Prefer.Family)
+			a.Accept = appendFamilies(a.Accept, alias.Accept.Family)
+			a.Default = appendFamilies(a.Default, alias.Default.Family)
+		}
+	}
+
+	// Add font selection rules.
+	for _, selectFont := range fc.SelectFonts {
+		for _, rule := range selectFont.Accept {
+			c.Accepts = append(c.Accepts, newFontRule(rule))
+		}
+		for _, rule := range selectFont.Reject {
+			c.Rejects = append(c.Rejects, newFontRule(rule))
+		}
+	}
+
+	// Process included files.
+	for _, include := range fc.Includes {
+		path := resolveFontConfigPath(include, baseDir, xdg.ConfigHome)
+		if path == "" {
+			continue
+		}
+
+		ignoreMissing := include.IgnoreMissing == "yes"
+		if err := c.include(path, visited); err != nil {
+			if ignoreMissing && errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// include loads the specified configuration file. If the path points to a
+// directory, the files in the directory which start with a digit and have
+// the .conf extension are loaded in lexical order.
+func (c *FontConfig) include(path string, visited map[string]bool) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return c.load(path, visited)
+	}
+
+	files, err := ioutil.ReadDir(path)
+	if err != nil {
+		return err
+	}
+
+	var names []string
+	for _, file := range files {
+		name := file.Name()
+		if file.IsDir() || name == "" || name[0] < '0' || name[0] > '9' ||
+			filepath.Ext(name) != ".conf" {
+			continue
+		}
+
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if err := c.load(filepath.Join(path, name), visited); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *FontConfig) alias(family string) *FontAlias {
+	for _, alias := range c.Aliases {
+		if strings.EqualFold(alias.Family, family) {
+			return alias
+		}
+	}
+
+	alias := &FontAlias{Family: family}
+	c.Aliases = append(c.Aliases, alias)
+	return alias
+}
+
+// resolveFontConfigPath resolves the specified configuration path based on
+// its prefix. Paths with the xdg prefix are resolved relative to the
+// specified XDG base directory.
+func resolveFontConfigPath(p fcPath, baseDir, xdgDir string) string {
+	path := strings.TrimSpace(p.Path)
+	if path == "" {
+		return ""
+	}
+
+	switch p.Prefix {
+	case "xdg":
+		path = filepath.Join(xdgDir, path)
+	case "relative":
+		path = filepath.Join(baseDir, path)
+	case "cwd":
+		if wd, err := os.Getwd(); err == nil {
+			path = filepath.Join(wd, path)
+		}
+	default:
+		if path == "~" || strings.HasPrefix(path, "~/") {
+			path = filepath.Join(xdg.Home, path[1:])
+		} else if !filepath.IsAbs(path) {
+			path = filepath.Join(baseDir, path)
+		}
+	}
+
+	return filepath.Clean(path)
+}
+
+func newFontRule(rule fcRule) *FontRule {
+	fontRule := &FontRule{}
+	for _, glob := range rule.Globs {
+		if glob = strings.TrimSpace(glob); glob != "" {
+			fontRule.Globs = append(fontRule.Globs, glob)
+		}
+	}
+
+	for _, pattern := range rule.Patterns {
+		elements := map[string][]string{}
+		for _, element := range pattern.Elements {
+			for _, value := range element.Values {
+				if value = strings.TrimSpace(value); value != "" {
+					elements[element.Name] = append(elements[element.Name], value)
+				}
+			}
+		}
+
+		if len(elements) > 0 {
+			fontRule.Patterns = append(fontRule.Patterns, elements)
+		}
+	}
+
+	return fontRule
+}
+
+func appendFamilies(families []string, values []string) []string {
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" && !strutil.SliceContains(families, value) {
+			families = append(families, value)
+		}
+	}
+
+	return families
+}
+
+// matchFontPattern reports whether the font matches all the properties of
+// the specified pattern. Unknown properties never match.
+func matchFontPattern(pattern map[string][]string, font *Font) bool {
+	for name, values := range pattern {
+		var value string
+		switch name {
+		case "family":
+			value = font.Family
+		case "fullname":
+			value = font.Name
+		case "file":
+			value = font.Filename
+		case "style":
+			value = fontStyleName(font)
+		default:
+			return false
+		}
+
+		var matched bool
+		for _, v := range values {
+			if strings.EqualFold(cleanQuery(v), cleanQuery(value)) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	return true
+}
+
+// matchGlob reports whether the specified name matches the glob pattern.
+// The * wildcard matches any sequence of characters, including path
+// separators, while the ? wildcard matches any single character.
+func matchGlob(pattern, name string) bool {
+	px, nx := 0, 0
+	nextPx, nextNx := -1, -1
+
+	for px < len(pattern) || nx < len(name) {
+		if px < len(pattern) {
+			switch c := pattern[px]; c {
+			case '*':
+				nextPx, nextNx = px, nx+1
+				px++
+				continue
+			case '?':
+				if nx < len(name) {
+					px++
+					nx++
+					continue
+				}
+			default:
+				if nx < len(name) && name[nx] == c {
+					px++
+					nx++
+					continue
+				}
+			}
+		}
+
+		// Backtrack to the last star, if any.
+		if nextNx > 0 && nextNx <= len(name) {
+			px, nx = nextPx, nextNx
+			continue
+		}
+
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,167 @@
+package sysfont
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// writeTestFiles creates the specified files, relative to the specified
+// directory.
+func writeTestFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+
+	for name, data := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestLoadFontConfig(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFiles(t, dir, map[string]string{
+		"fonts.conf": `<?xml version="1.0"?>
+<!DOCTYPE fontconfig SYSTEM "urn:fontconfig:fonts.dtd">
+<fontconfig>
+	<dir>/usr/share/fonts</dir>
+	<dir prefix="relative">local</dir>
+	<dir>/usr/share/fonts</dir>
+	<alias>
+		<family>serif</family>
+		<prefer><family>DejaVu Serif</family></prefer>
+		<default><family>Times</family></default>
+	</alias>
+	<selectfont>
+		<rejectfont><glob>/usr/share/fonts/bad/*</glob></rejectfont>
+		<acceptfont>
+			<pattern><patelt name="family"><string>Good</string></patelt></pattern>
+		</acceptfont>
+	</selectfont>
+	<include ignore_missing="yes">conf.d</include>
+	<include ignore_missing="yes">missing.conf</include>
+	<include>fonts.conf</include>
+</fontconfig>`,
+		"conf.d/10-first.conf": `<fontconfig>
+	<alias>
+		<family>Serif</family>
+		<prefer><family>Noto Serif</family><family>DejaVu Serif</family></prefer>
+		<accept><family>Liberation Serif</family></accept>
+	</alias>
+</fontconfig>`,
+		"conf.d/20-second.conf": `<fontconfig>
+	<dir>/opt/fonts</dir>
+	<selectfont>
+		<rejectfont>
+			<pattern>
+				<patelt name="family"><string>Bad</string></patelt>
+				<patelt name="style"><string>Bold</string></patelt>
+			</pattern>
+		</rejectfont>
+	</selectfont>
+</fontconfig>`,
+		"conf.d/README":         "not a configuration file",
+		"conf.d/disabled.conf":  "<fontconfig><dir>/disabled</dir></fontconfig>",
+		"conf.d/30-dir.conf/x":  "",
+		"broken.conf":           "<fontconfig><dir>",
+		"missing-include.conf":  "<fontconfig><include>missing.conf</include></fontconfig>",
+		"included-broken.conf":  "<fontconfig><include>broken.conf</include></fontconfig>",
+		"conf.d/00-nested.conf": "<fontconfig><include>../conf.d/10-first.conf</include></fontconfig>",
+	})
+
+	config, err := LoadFontConfig(filepath.Join(dir, "fonts.conf"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []string{"/usr/share/fonts", filepath.Join(dir, "local"), "/opt/fonts"}; !reflect.DeepEqual(config.Dirs, want) {
+		t.Errorf("got dirs %q, want %q", config.Dirs, want)
+	}
+
+	wantAliases := []*FontAlias{{
+		Family:  "serif",
+		Prefer:  []string{"DejaVu Serif", "Noto Serif"},
+		Accept:  []string{"Liberation Serif"},
+		Default: []string{"Times"},
+	}}
+	if !reflect.DeepEqual(config.Aliases, wantAliases) {
+		t.Errorf("got aliases %+v, want %+v", config.Aliases[0], wantAliases[0])
+	}
+	if families, want := config.Aliases[0].Families(), []string{
+		"DejaVu Serif", "Noto Serif", "serif", "Liberation Serif", "Times",
+	}; !reflect.DeepEqual(families, want) {
+		t.Errorf("got alias families %q, want %q", families, want)
+	}
+
+	if len(config.Rejects) != 2 || len(config.Accepts) != 1 {
+		t.Fatalf("got %d reject and %d accept rules, want 2 and 1", len(config.Rejects), len(config.Accepts))
+	}
+
+	rejectTests := []struct {
+		font     *Font
+		rejected bool
+	}{
+		{&Font{Family: "Any", Filename: "/usr/share/fonts/bad/any.ttf"}, true},
+		{&Font{Family: "Good", Filename: "/usr/share/fonts/bad/good.ttf"}, false},
+		{&Font{Family: "Bad", Name: "Bad Bold", Filename: "/opt/fonts/bad-bold.ttf"}, true},
+		{&Font{Family: "Bad", Name: "Bad", Filename: "/opt/fonts/bad.ttf"}, false},
+		{&Font{Family: "Any", Filename: "/usr/share/fonts/any.ttf"}, false},
+	}
+	for _, test := range rejectTests {
+		if rejected := config.Rejected(test.font); rejected != test.rejected {
+			t.Errorf("%s: got rejected %t, want %t", test.font.Filename, rejected, test.rejected)
+		}
+	}
+
+	// Check invalid configurations.
+	errorTests := []struct {
+		name  string
+		path  string
+		check func(error) bool
+	}{
+		{"missing file", "missing.conf", func(err error) bool { return errors.Is(err, os.ErrNotExist) }},
+		{"missing include", "missing-include.conf", func(err error) bool { return errors.Is(err, os.ErrNotExist) }},
+		{"invalid file", "broken.conf", isParseError},
+		{"invalid include", "included-broken.conf", isParseError},
+	}
+	for _, test := range errorTests {
+		if _, err := LoadFontConfig(filepath.Join(dir, test.path)); !test.check(err) {
+			t.Errorf("%s: got unexpected error %v", test.name, err)
+		}
+	}
+}
+
+func isParseError(err error) bool {
+	var pathErr *os.PathError
+	return errors.As(err, &pathErr) && pathErr.Op == "parse"
+}
+
+func TestMatchGlob(t *testing.T) {
+	tests := []struct {
+		pattern string
+		name    string
+		match   bool
+	}{
+		{"/usr/share/fonts/*", "/usr/share/fonts/a/b.ttf", true},
+		{"*.pcf.gz", "/usr/share/fonts/X11/misc/6x13.pcf.gz", true},
+		{"*.pcf.gz", "/usr/share/fonts/X11/misc/6x13.pcf", false},
+		{"/fonts/?.ttf", "/fonts/a.ttf", true},
+		{"/fonts/?.ttf", "/fonts/ab.ttf", false},
+		{"/fonts/*b*.ttf", "/fonts/abc.ttf", true},
+		{"", "", true},
+		{"*", "", true},
+		{"?", "", false},
+	}
+
+	for _, test := range tests {
+		if match := matchGlob(test.pattern, test.name); match != test.match {
+			t.Errorf("matchGlob(%q, %q): got %t, want %t", test.pattern, test.name, match, test.match)
+		}
+	}
+}
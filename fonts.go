@@ -1,9 +1,12 @@
 package sysfont
 
-import "strings"
+// The font definitions of the registry are merged with the definitions of
+// the internal/genfonts/fonts.json manifest. Use the -dir and -system flags
+// of ./internal/genfonts for adding fonts from font directories.
+//go:generate go run ./internal/genfonts -manifest internal/genfonts/fonts.json
 
-var fontRegistry = &registry{
-	fonts: []*Font{
+var fontRegistry = &Registry{
+	fonts: []*registryFont{
 		{".Al Bayan PUA", ".Al Bayan PUA Bold", "AlBayan.ttc"},
 		{".Al Bayan PUA", ".Al Bayan PUA Plain", "AlBayan.ttc"},
 		{".Al Nile PUA", ".Al Nile PUA", "Al Nile.ttc"},
@@ -79,10 +82,10 @@ var fontRegistry = &registry{
 		{".Helvetica Neue DeskInterface", ".Helvetica Neue DeskInterface Thin", "HelveticaNeueDeskInterface.ttc"},
 		{".Helvetica Neue DeskInterface", ".Helvetica Neue DeskInterface UltraLight", "HelveticaNeueDeskInterface.ttc"},
 		{".Hiragino Kaku Gothic Interface", ".Hiragino Kaku Gothic Interface W0", "ヒラギノ角ゴシック W0.ttc"},
-		{".Hiragino Kaku Gothic Interface", ".Hiragino Kaku Gothic Interface W1", "ヒラギノ角ゴシック W1.ttc"},
 		{".Hiragino Kaku Gothic Interface", ".Hiragino Kaku Gothic Interface W1", "HiraKakuInterface-W1.otf"},
-		{".Hiragino Kaku Gothic Interface", ".Hiragino Kaku Gothic Interface W2", "ヒラギノ角ゴシック W2.ttc"},
+		{".Hiragino Kaku Gothic Interface", ".Hiragino Kaku Gothic Interface W1", "ヒラギノ角ゴシック W1.ttc"},
 		{".Hiragino Kaku Gothic Interface", ".Hiragino Kaku Gothic Interface W2", "HiraKakuInterface-W2.otf"},
+		{".Hiragino Kaku Gothic Interface", ".Hiragino Kaku Gothic Interface W2", "ヒラギノ角ゴシック W2.ttc"},
 		{".Hiragino Kaku Gothic Interface", ".Hiragino Kaku Gothic Interface W3", "ヒラギノ角ゴシック W3.ttc"},
 		{".Hiragino Kaku Gothic Interface", ".Hiragino Kaku Gothic Interface W4", "ヒラギノ角ゴシック W4.ttc"},
 		{".Hiragino Kaku Gothic Interface", ".Hiragino Kaku Gothic Interface W5", "ヒラギノ角ゴシック W5.ttc"},
@@ -268,36 +271,36 @@ var fontRegistry = &registry{
 		{".SF NS", ".SF NS Ultrathin G3", "SFNS.ttf"},
 		{".SF NS", ".SF NS Ultrathin G4", "SFNS.ttf"},
 		{".SF NS", ".SF NS Ultrathin Italic", "SFNSItalic.ttf"},
-		{".SF NS Display", ".SF NS Display Black", "SFNSDisplay.ttf"},
 		{".SF NS Display", ".SF NS Display Black", "SFNSDisplay-Black.otf"},
+		{".SF NS Display", ".SF NS Display Black", "SFNSDisplay.ttf"},
 		{".SF NS Display", ".SF NS Display Black Italic", "SFNSDisplay-BlackItalic.otf"},
-		{".SF NS Display", ".SF NS Display Bold", "SFNSDisplay.ttf"},
 		{".SF NS Display", ".SF NS Display Bold", "SFNSDisplay-Bold.otf"},
+		{".SF NS Display", ".SF NS Display Bold", "SFNSDisplay.ttf"},
 		{".SF NS Display", ".SF NS Display Bold Italic", "SFNSDisplay-BoldItalic.otf"},
-		{".SF NS Display", ".SF NS Display Heavy", "SFNSDisplay.ttf"},
 		{".SF NS Display", ".SF NS Display Heavy", "SFNSDisplay-Heavy.otf"},
+		{".SF NS Display", ".SF NS Display Heavy", "SFNSDisplay.ttf"},
 		{".SF NS Display", ".SF NS Display Heavy Italic", "SFNSDisplay-HeavyItalic.otf"},
 		{".SF NS Display", ".SF NS Display Italic", "SFNSDisplay-RegularItalic.otf"},
-		{".SF NS Display", ".SF NS Display Light", "SFNSDisplay.ttf"},
 		{".SF NS Display", ".SF NS Display Light", "SFNSDisplay-Light.otf"},
+		{".SF NS Display", ".SF NS Display Light", "SFNSDisplay.ttf"},
 		{".SF NS Display", ".SF NS Display Light Italic", "SFNSDisplay-LightItalic.otf"},
-		{".SF NS Display", ".SF NS Display Medium", "SFNSDisplay.ttf"},
 		{".SF NS Display", ".SF NS Display Medium", "SFNSDisplay-Medium.otf"},
+		{".SF NS Display", ".SF NS Display Medium", "SFNSDisplay.ttf"},
 		{".SF NS Display", ".SF NS Display Medium Italic", "SFNSDisplay-MediumItalic.otf"},
-		{".SF NS Display", ".SF NS Display Regular", "SFNSDisplay.ttf"},
 		{".SF NS Display", ".SF NS Display Regular", "SFNSDisplay-Regular.otf"},
-		{".SF NS Display", ".SF NS Display Semibold", "SFNSDisplay.ttf"},
+		{".SF NS Display", ".SF NS Display Regular", "SFNSDisplay.ttf"},
 		{".SF NS Display", ".SF NS Display Semibold", "SFNSDisplay-Semibold.otf"},
+		{".SF NS Display", ".SF NS Display Semibold", "SFNSDisplay.ttf"},
 		{".SF NS Display", ".SF NS Display Semibold Italic", "SFNSDisplay-SemiboldItalic.otf"},
-		{".SF NS Display", ".SF NS Display Thin", "SFNSDisplay.ttf"},
 		{".SF NS Display", ".SF NS Display Thin", "SFNSDisplay-Thin.otf"},
+		{".SF NS Display", ".SF NS Display Thin", "SFNSDisplay.ttf"},
 		{".SF NS Display", ".SF NS Display Thin G1", "SFNSDisplay-ThinG1.otf"},
 		{".SF NS Display", ".SF NS Display Thin G2", "SFNSDisplay-ThinG2.otf"},
 		{".SF NS Display", ".SF NS Display Thin G3", "SFNSDisplay-ThinG3.otf"},
 		{".SF NS Display", ".SF NS Display Thin G4", "SFNSDisplay-ThinG4.otf"},
 		{".SF NS Display", ".SF NS Display Thin Italic", "SFNSDisplay-ThinItalic.otf"},
-		{".SF NS Display", ".SF NS Display Ultralight", "SFNSDisplay.ttf"},
 		{".SF NS Display", ".SF NS Display Ultralight", "SFNSDisplay-Ultralight.otf"},
+		{".SF NS Display", ".SF NS Display Ultralight", "SFNSDisplay.ttf"},
 		{".SF NS Display", ".SF NS Display Ultralight Italic", "SFNSDisplay-UltralightItalic.otf"},
 		{".SF NS Display Condensed", ".SF NS Display Condensed Black", "SFNSDisplayCondensed-Black.otf"},
 		{".SF NS Display Condensed", ".SF NS Display Condensed Bold", "SFNSDisplayCondensed-Bold.otf"},
@@ -375,32 +378,32 @@ var fontRegistry = &registry{
 		{".SF NS Symbols", ".SF NS Symbols Semibold", "SFNSSymbols-Semibold.otf"},
 		{".SF NS Symbols", ".SF NS Symbols Thin", "SFNSSymbols-Thin.otf"},
 		{".SF NS Symbols", ".SF NS Symbols Ultralight", "SFNSSymbols-Ultralight.otf"},
-		{".SF NS Text", ".SF NS Text Bold", "SFNSText.ttf"},
 		{".SF NS Text", ".SF NS Text Bold", "SFNSText-Bold.otf"},
+		{".SF NS Text", ".SF NS Text Bold", "SFNSText.ttf"},
 		{".SF NS Text", ".SF NS Text Bold G1", "SFNSText-BoldG1.otf"},
 		{".SF NS Text", ".SF NS Text Bold G2", "SFNSText-BoldG2.otf"},
 		{".SF NS Text", ".SF NS Text Bold G3", "SFNSText-BoldG3.otf"},
-		{".SF NS Text", ".SF NS Text Heavy", "SFNSText.ttf"},
 		{".SF NS Text", ".SF NS Text Heavy", "SFNSText-Heavy.otf"},
+		{".SF NS Text", ".SF NS Text Heavy", "SFNSText.ttf"},
 		{".SF NS Text", ".SF NS Text Heavy Italic", "SFNSText-HeavyItalic.otf"},
-		{".SF NS Text", ".SF NS Text Italic", "SFNSTextItalic.ttf"},
 		{".SF NS Text", ".SF NS Text Italic", "SFNSText-BoldItalic.otf"},
+		{".SF NS Text", ".SF NS Text Italic", "SFNSTextItalic.ttf"},
 		{".SF NS Text", ".SF NS Text Italic G1", "SFNSText-BoldItalicG1.otf"},
 		{".SF NS Text", ".SF NS Text Italic G2", "SFNSText-BoldItalicG2.otf"},
 		{".SF NS Text", ".SF NS Text Italic G3", "SFNSText-BoldItalicG3.otf"},
-		{".SF NS Text", ".SF NS Text Light", "SFNSText.ttf"},
 		{".SF NS Text", ".SF NS Text Light", "SFNSText-Light.otf"},
+		{".SF NS Text", ".SF NS Text Light", "SFNSText.ttf"},
 		{".SF NS Text", ".SF NS Text Light Bold Italic", "SFNSTextItalic.ttf"},
 		{".SF NS Text", ".SF NS Text Light Heavy Italic", "SFNSTextItalic.ttf"},
-		{".SF NS Text", ".SF NS Text Light Italic", "SFNSTextItalic.ttf"},
 		{".SF NS Text", ".SF NS Text Light Italic", "SFNSText-LightItalic.otf"},
+		{".SF NS Text", ".SF NS Text Light Italic", "SFNSTextItalic.ttf"},
 		{".SF NS Text", ".SF NS Text Light Semibold Italic", "SFNSTextItalic.ttf"},
-		{".SF NS Text", ".SF NS Text Medium", "SFNSText.ttf"},
 		{".SF NS Text", ".SF NS Text Medium", "SFNSText-Medium.otf"},
-		{".SF NS Text", ".SF NS Text Medium Italic", "SFNSTextItalic.ttf"},
+		{".SF NS Text", ".SF NS Text Medium", "SFNSText.ttf"},
 		{".SF NS Text", ".SF NS Text Medium Italic", "SFNSText-MediumItalic.otf"},
-		{".SF NS Text", ".SF NS Text Regular", "SFNSText.ttf"},
+		{".SF NS Text", ".SF NS Text Medium Italic", "SFNSTextItalic.ttf"},
 		{".SF NS Text", ".SF NS Text Regular", "SFNSText-Regular.otf"},
+		{".SF NS Text", ".SF NS Text Regular", "SFNSText.ttf"},
 		{".SF NS Text", ".SF NS Text Regular G1", "SFNSText-RegularG1.otf"},
 		{".SF NS Text", ".SF NS Text Regular G2", "SFNSText-RegularG2.otf"},
 		{".SF NS Text", ".SF NS Text Regular G3", "SFNSText-RegularG3.otf"},
@@ -408,8 +411,8 @@ var fontRegistry = &registry{
 		{".SF NS Text", ".SF NS Text Regular Italic G1", "SFNSText-RegularItalicG1.otf"},
 		{".SF NS Text", ".SF NS Text Regular Italic G2", "SFNSText-RegularItalicG2.otf"},
 		{".SF NS Text", ".SF NS Text Regular Italic G3", "SFNSText-RegularItalicG3.otf"},
-		{".SF NS Text", ".SF NS Text Semibold", "SFNSText.ttf"},
 		{".SF NS Text", ".SF NS Text Semibold", "SFNSText-Semibold.otf"},
+		{".SF NS Text", ".SF NS Text Semibold", "SFNSText.ttf"},
 		{".SF NS Text", ".SF NS Text Semibold Italic", "SFNSText-SemiboldItalic.otf"},
 		{".SF NS Text Condensed", ".SF NS Text Condensed Bold", "SFNSTextCondensed-Bold.otf"},
 		{".SF NS Text Condensed", ".SF NS Text Condensed Heavy", "SFNSTextCondensed-Heavy.otf"},
@@ -434,13 +437,13 @@ var fontRegistry = &registry{
 		{"Abyssinica SIL", "Abyssinica SIL", "Abyssinica_SIL.ttf"},
 		{"Aharoni Bold", "Aharoni Bold", "ahronbd.ttf"},
 		{"Aharoni CLM", "Aharoni CLM", "AharoniCLM-Bold.pfa"},
+		{"Aharoni CLM", "Aharoni CLM", "AharoniCLM-BoldOblique.pfa"},
 		{"Aharoni CLM", "Aharoni CLM", "AharoniCLM-Book.pfa"},
 		{"Aharoni CLM", "Aharoni CLM", "AharoniCLM-BookOblique.pfa"},
-		{"Aharoni CLM", "Aharoni CLM", "AharoniCLM-BoldOblique.pfa"},
-		{"Al Bayan", "Al Bayan Bold", "AlBayanBold.ttf"},
 		{"Al Bayan", "Al Bayan Bold", "AlBayan.ttc"},
-		{"Al Bayan", "Al Bayan Plain", "AlBayan.ttf"},
+		{"Al Bayan", "Al Bayan Bold", "AlBayanBold.ttf"},
 		{"Al Bayan", "Al Bayan Plain", "AlBayan.ttc"},
+		{"Al Bayan", "Al Bayan Plain", "AlBayan.ttf"},
 		{"Al Nile", "Al Nile", "Al Nile.ttc"},
 		{"Al Nile", "Al Nile Bold", "Al Nile.ttc"},
 		{"Al Tarikh", "Al Tarikh", "Al Tarikh.ttc"},
@@ -469,22 +472,22 @@ var fontRegistry = &registry{
 		{"Amiri", "Amiri Slanted", "Amiri-Slanted.ttf"},
 		{"Amiri Quran", "Amiri Quran", "AmiriQuran.ttf"},
 		{"Amiri Quran Colored", "Amiri Quran Colored", "AmiriQuranColored.ttf"},
-		{"Andale Mono", "Andale Mono", "Andale Mono.ttf"},
 		{"Andale Mono", "Andale Mono", "Andale Mono"},
-		{"Andale Mono", "Andale Mono", "andalemo.ttf"},
+		{"Andale Mono", "Andale Mono", "Andale Mono.ttf"},
 		{"Andale Mono", "Andale Mono", "Andale_Mono.ttf"},
+		{"Andale Mono", "Andale Mono", "andalemo.ttf"},
 		{"Andalus", "Andalus", "andlso.ttf"},
 		{"Andika", "Andika", "Andika-R.ttf"},
 		{"Andika", "Andika", "Andika-R.woff"},
 		{"Android Emoji", "Android Emoji", "AndroidEmoji.ttf"},
-		{"Angsana New", "Angsana New", "angsana.ttc"},
 		{"Angsana New", "Angsana New", "angsa.ttf"},
-		{"Angsana New", "Angsana New Bold", "angsana.ttc"},
+		{"Angsana New", "Angsana New", "angsana.ttc"},
 		{"Angsana New", "Angsana New Bold", "angsab.ttf"},
+		{"Angsana New", "Angsana New Bold", "angsana.ttc"},
 		{"Angsana New", "Angsana New Bold Italic", "angsana.ttc"},
 		{"Angsana New", "Angsana New Bold Italic", "angsaz.ttf"},
-		{"Angsana New", "Angsana New Italic", "angsana.ttc"},
 		{"Angsana New", "Angsana New Italic", "angsai.ttf"},
+		{"Angsana New", "Angsana New Italic", "angsana.ttc"},
 		{"AngsanaUPC", "AngsanaUPC", "angsana.ttc"},
 		{"AngsanaUPC", "AngsanaUPC", "angsau.ttf"},
 		{"AngsanaUPC", "AngsanaUPC Bold", "angsana.ttc"},
@@ -507,8 +510,8 @@ var fontRegistry = &registry{
 		{"Apple Braille", "Apple Braille Pinpoint 8 Dot", "Apple Braille Pinpoint 8 Dot.ttf"},
 		{"Apple Chancery", "Apple Chancery", "Apple Chancery.dfont"},
 		{"Apple Chancery", "Apple Chancery", "Apple Chancery.ttf"},
-		{"Apple Color Emoji", "Apple Color Emoji", "Apple Color Emoji.ttf"},
 		{"Apple Color Emoji", "Apple Color Emoji", "Apple Color Emoji.ttc"},
+		{"Apple Color Emoji", "Apple Color Emoji", "Apple Color Emoji.ttf"},
 		{"Apple LiGothic", "Apple LiGothic Medium", "Apple LiGothic Medium.dfont"},
 		{"Apple LiGothic", "Apple LiGothic Medium", "Apple LiGothic Medium.ttf"},
 		{"Apple LiGothic", "Apple LiGothic Medium", "AppleLiGothic-Medium.ttf"},
@@ -533,47 +536,47 @@ var fontRegistry = &registry{
 		{"Apple SD Gothic Neo", "Apple SD Gothic Neo Thin", "AppleSDGothicNeo.ttc"},
 		{"Apple SD Gothic Neo", "Apple SD Gothic Neo UltraLight", "AppleSDGothicNeo-UltraLight.otf"},
 		{"Apple SD Gothic Neo", "Apple SD Gothic Neo UltraLight", "AppleSDGothicNeo.ttc"},
-		{"Apple Symbols", "Apple Symbols", "Apple Symbols.ttf"},
 		{"Apple Symbols", "Apple Symbols", "Apple Symbols.ttc"},
-		{"AppleGothic", "AppleGothic Regular", "AppleGothic.ttf"},
+		{"Apple Symbols", "Apple Symbols", "Apple Symbols.ttf"},
 		{"AppleGothic", "AppleGothic Regular", "AppleGothic.dfont"},
-		{"AppleMyungjo", "AppleMyungjo Regular", "AppleMyungjo.ttf"},
+		{"AppleGothic", "AppleGothic Regular", "AppleGothic.ttf"},
 		{"AppleMyungjo", "AppleMyungjo Regular", "AppleMyungjo.dfont"},
-		{"AquaKana", "AquaKana", "AquaKanaRegular.otf"},
+		{"AppleMyungjo", "AppleMyungjo Regular", "AppleMyungjo.ttf"},
 		{"AquaKana", "AquaKana", "AquaKana.ttc"},
-		{"AquaKana", "AquaKana Bold", "AquaKanaBold.otf"},
+		{"AquaKana", "AquaKana", "AquaKanaRegular.otf"},
 		{"AquaKana", "AquaKana Bold", "AquaKana.ttc"},
+		{"AquaKana", "AquaKana Bold", "AquaKanaBold.otf"},
 		{"Arab", "Arab", "ae_Arab.ttf"},
 		{"Arabic Transparent", "Arabic Transparent", "artro.ttf"},
 		{"Arabic Transparent", "Arabic Transparent Bold", "artrbdo.ttf"},
 		{"Arabic Typesetting", "Arabic Typesetting", "arabtype.ttf"},
-		{"Arial", "Arial", "arial.ttf"},
-		{"Arial", "Arial", "Arial.ttf"},
 		{"Arial", "Arial", "Arial"},
-		{"Arial", "Arial Black", "Arial Black.ttf"},
+		{"Arial", "Arial", "Arial.ttf"},
+		{"Arial", "Arial", "arial.ttf"},
 		{"Arial", "Arial Black", "Arial Black"},
-		{"Arial", "Arial Bold", "arialbd.ttf"},
+		{"Arial", "Arial Black", "Arial Black.ttf"},
 		{"Arial", "Arial Bold", "Arial Bold.ttf"},
 		{"Arial", "Arial Bold", "Arial_Bold.ttf"},
-		{"Arial", "Arial Bold Italic", "arialbi.ttf"},
+		{"Arial", "Arial Bold", "arialbd.ttf"},
 		{"Arial", "Arial Bold Italic", "Arial Bold Italic.ttf"},
 		{"Arial", "Arial Bold Italic", "Arial_Bold_Italic.ttf"},
-		{"Arial", "Arial Italic", "ariali.ttf"},
+		{"Arial", "Arial Bold Italic", "arialbi.ttf"},
 		{"Arial", "Arial Italic", "Arial Italic.ttf"},
 		{"Arial", "Arial Italic", "Arial_Italic.ttf"},
+		{"Arial", "Arial Italic", "ariali.ttf"},
 		{"Arial", "Arial Unicode MS", "Arial Unicode.ttf"},
-		{"Arial Black", "Arial Black", "ariblk.ttf"},
 		{"Arial Black", "Arial Black", "Arial_Black.ttf"},
-		{"Arial Hebrew", "Arial Hebrew", "ArialHB.ttf"},
+		{"Arial Black", "Arial Black", "ariblk.ttf"},
 		{"Arial Hebrew", "Arial Hebrew", "ArialHB.ttc"},
-		{"Arial Hebrew", "Arial Hebrew Bold", "ArialHBBold.ttf"},
+		{"Arial Hebrew", "Arial Hebrew", "ArialHB.ttf"},
 		{"Arial Hebrew", "Arial Hebrew Bold", "ArialHB.ttc"},
+		{"Arial Hebrew", "Arial Hebrew Bold", "ArialHBBold.ttf"},
 		{"Arial Hebrew", "Arial Hebrew Light", "ArialHB.ttc"},
 		{"Arial Hebrew Scholar", "Arial Hebrew Scholar", "ArialHB.ttc"},
 		{"Arial Hebrew Scholar", "Arial Hebrew Scholar Bold", "ArialHB.ttc"},
 		{"Arial Hebrew Scholar", "Arial Hebrew Scholar Light", "ArialHB.ttc"},
-		{"Arial Narrow", "Arial Narrow", "Arial Narrow.ttf"},
 		{"Arial Narrow", "Arial Narrow", "Arial Narrow"},
+		{"Arial Narrow", "Arial Narrow", "Arial Narrow.ttf"},
 		{"Arial Narrow", "Arial Narrow Bold", "Arial Narrow Bold.ttf"},
 		{"Arial Narrow", "Arial Narrow Bold Italic", "Arial Narrow Bold Italic.ttf"},
 		{"Arial Narrow", "Arial Narrow Italic", "Arial Narrow Italic.ttf"},
@@ -589,8 +592,8 @@ var fontRegistry = &registry{
 		{"Arial Nova", "Arial Nova Italic", "arialnova-italic.ttf"},
 		{"Arial Nova", "Arial Nova Light", "arialnova-light.ttf"},
 		{"Arial Nova", "Arial Nova Light Italic", "arialnova-lightitalic.ttf"},
-		{"Arial Rounded MT Bold", "Arial Rounded MT Bold", "Arial Rounded Bold.ttf"},
 		{"Arial Rounded MT Bold", "Arial Rounded MT Bold", "Arial Rounded Bold"},
+		{"Arial Rounded MT Bold", "Arial Rounded MT Bold", "Arial Rounded Bold.ttf"},
 		{"Arundina", "Arundina Sans", "ArundinaSans.ttf"},
 		{"Arundina", "Arundina Sans Bold", "ArundinaSans-Bold.ttf"},
 		{"Arundina", "Arundina Sans Bold Italic", "ArundinaSans-BoldOblique.ttf"},
@@ -675,8 +678,8 @@ var fontRegistry = &registry{
 		{"BPG Sans Regular GPL&GNU", "BPG Sans Regular GPL&GNU", "BPG_Sans_Regular_GPL&GNU.ttf"},
 		{"BPG Serif GPL&GNU", "BPG Serif GPL&GNU", "BPG_Serif_GPL&GNU.ttf"},
 		{"BPG Serif Modern GPL&GNU", "BPG Serif Modern GPL&GNU", "BPG_Serif_Modern_GPL&GNU.ttf"},
-		{"Baghdad", "Baghdad Regular", "Baghdad.ttf"},
 		{"Baghdad", "Baghdad Regular", "Baghdad.ttc"},
+		{"Baghdad", "Baghdad Regular", "Baghdad.ttf"},
 		{"Bahnschrift", "Bahnschrift", "bahnschrift.ttf"},
 		{"Bangla MN", "Bangla MN", "Bangla MN.ttc"},
 		{"Bangla MN", "Bangla MN Bold", "Bangla MN.ttc"},
@@ -704,10 +707,10 @@ var fontRegistry = &registry{
 		{"BiauKai", "BiauKai", "BiauKai.ttf"},
 		{"Big Caslon", "Big Caslon Medium", "BigCaslon.dfont"},
 		{"Big Caslon", "Big Caslon Medium", "BigCaslon.ttf"},
-		{"Bitstream Charter", "Bitstream Charter", "c0649bt_.pfb"},
 		{"Bitstream Charter", "Bitstream Charter", "c0632bt_.pfb"},
 		{"Bitstream Charter", "Bitstream Charter", "c0633bt_.pfb"},
 		{"Bitstream Charter", "Bitstream Charter", "c0648bt_.pfb"},
+		{"Bitstream Charter", "Bitstream Charter", "c0649bt_.pfb"},
 		{"Bodoni 72", "Bodoni 72 Bold", "Bodoni 72.ttc"},
 		{"Bodoni 72", "Bodoni 72 Book", "Bodoni 72.ttc"},
 		{"Bodoni 72", "Bodoni 72 Book Italic", "Bodoni 72.ttc"},
@@ -725,24 +728,24 @@ var fontRegistry = &registry{
 		{"Browallia New", "Browallia New", "browalia.ttc"},
 		{"Browallia New", "Browallia New Bold", "browab.ttf"},
 		{"Browallia New", "Browallia New Bold", "browalia.ttc"},
-		{"Browallia New", "Browallia New Bold Italic", "browaz.ttf"},
 		{"Browallia New", "Browallia New Bold Italic", "browalia.ttc"},
+		{"Browallia New", "Browallia New Bold Italic", "browaz.ttf"},
 		{"Browallia New", "Browallia New Italic", "browai.ttf"},
 		{"Browallia New", "Browallia New Italic", "browalia.ttc"},
-		{"BrowalliaUPC", "BrowalliaUPC", "browau.ttf"},
 		{"BrowalliaUPC", "BrowalliaUPC", "browalia.ttc"},
-		{"BrowalliaUPC", "BrowalliaUPC Bold", "browaub.ttf"},
+		{"BrowalliaUPC", "BrowalliaUPC", "browau.ttf"},
 		{"BrowalliaUPC", "BrowalliaUPC Bold", "browalia.ttc"},
-		{"BrowalliaUPC", "BrowalliaUPC Bold Italic", "browauz.ttf"},
+		{"BrowalliaUPC", "BrowalliaUPC Bold", "browaub.ttf"},
 		{"BrowalliaUPC", "BrowalliaUPC Bold Italic", "browalia.ttc"},
-		{"BrowalliaUPC", "BrowalliaUPC Italic", "browaui.ttf"},
+		{"BrowalliaUPC", "BrowalliaUPC Bold Italic", "browauz.ttf"},
 		{"BrowalliaUPC", "BrowalliaUPC Italic", "browalia.ttc"},
-		{"Brush Script MT", "Brush Script MT Italic", "Brush Script.ttf"},
+		{"BrowalliaUPC", "BrowalliaUPC Italic", "browaui.ttf"},
 		{"Brush Script MT", "Brush Script MT Italic", "Brush Script"},
-		{"C059", "C059", "C059-Roman.t1"},
-		{"C059", "C059", "C059-Italic.t1"},
+		{"Brush Script MT", "Brush Script MT Italic", "Brush Script.ttf"},
 		{"C059", "C059", "C059-BdIta.t1"},
 		{"C059", "C059", "C059-Bold.t1"},
+		{"C059", "C059", "C059-Italic.t1"},
+		{"C059", "C059", "C059-Roman.t1"},
 		{"C059", "C059-BdIta", "C059-BdIta.otf"},
 		{"C059", "C059-Bold", "C059-Bold.otf"},
 		{"C059", "C059-Italic", "C059-Italic.otf"},
@@ -789,10 +792,10 @@ var fontRegistry = &registry{
 		{"Century Schoolbook L", "Century Schoolbook L", "c059016l.pfb"},
 		{"Century Schoolbook L", "Century Schoolbook L", "c059033l.pfb"},
 		{"Century Schoolbook L", "Century Schoolbook L", "c059036l.pfb"},
-		{"Chalkboard", "Chalkboard", "Chalkboard.ttf"},
 		{"Chalkboard", "Chalkboard", "Chalkboard.ttc"},
-		{"Chalkboard", "Chalkboard Bold", "ChalkboardBold.ttf"},
+		{"Chalkboard", "Chalkboard", "Chalkboard.ttf"},
 		{"Chalkboard", "Chalkboard Bold", "Chalkboard.ttc"},
+		{"Chalkboard", "Chalkboard Bold", "ChalkboardBold.ttf"},
 		{"Chalkboard", "Chalkboard SE Bold", "ChalkboardSE.ttc"},
 		{"Chalkboard", "Chalkboard SE Light", "ChalkboardSE.ttc"},
 		{"Chalkboard", "Chalkboard SE Regular", "ChalkboardSE.ttc"},
@@ -819,13 +822,13 @@ var fontRegistry = &registry{
 		{"Comfortaa", "Comfortaa Bold", "Comfortaa-Bold.ttf"},
 		{"Comfortaa", "Comfortaa Light", "Comfortaa-Light.ttf"},
 		{"Comfortaa", "Comfortaa Regular", "Comfortaa-Regular.ttf"},
-		{"Comic Sans MS", "Comic Sans MS", "comic.ttf"},
-		{"Comic Sans MS", "Comic Sans MS", "Comic Sans MS.ttf"},
 		{"Comic Sans MS", "Comic Sans MS", "Comic Sans MS"},
+		{"Comic Sans MS", "Comic Sans MS", "Comic Sans MS.ttf"},
 		{"Comic Sans MS", "Comic Sans MS", "Comic_Sans_MS.ttf"},
-		{"Comic Sans MS", "Comic Sans MS Bold", "comicbd.ttf"},
+		{"Comic Sans MS", "Comic Sans MS", "comic.ttf"},
 		{"Comic Sans MS", "Comic Sans MS Bold", "Comic Sans MS Bold.ttf"},
 		{"Comic Sans MS", "Comic Sans MS Bold", "Comic_Sans_MS_Bold.ttf"},
+		{"Comic Sans MS", "Comic Sans MS Bold", "comicbd.ttf"},
 		{"Comic Sans MS", "Comic Sans MS Bold Italic", "comicz.ttf"},
 		{"Comic Sans MS", "Comic Sans MS Italic", "comici.ttf"},
 		{"Consolas", "Consolas", "consola.ttf"},
@@ -850,51 +853,51 @@ var fontRegistry = &registry{
 		{"Corbel", "Corbel Italic", "corbeli.ttf"},
 		{"Corbel", "Corbel Light", "corbell.ttf"},
 		{"Corbel", "Corbel Light Italic", "corbelli.ttf"},
-		{"Cordia New", "Cordia New", "cordia.ttf"},
 		{"Cordia New", "Cordia New", "cordia.ttc"},
-		{"Cordia New", "Cordia New Bold", "cordiab.ttf"},
+		{"Cordia New", "Cordia New", "cordia.ttf"},
 		{"Cordia New", "Cordia New Bold", "cordia.ttc"},
-		{"Cordia New", "Cordia New Bold Italic", "cordiaz.ttf"},
+		{"Cordia New", "Cordia New Bold", "cordiab.ttf"},
 		{"Cordia New", "Cordia New Bold Italic", "cordia.ttc"},
-		{"Cordia New", "Cordia New Italic", "cordiai.ttf"},
+		{"Cordia New", "Cordia New Bold Italic", "cordiaz.ttf"},
 		{"Cordia New", "Cordia New Italic", "cordia.ttc"},
-		{"CordiaUPC", "CordiaUPC", "cordiau.ttf"},
+		{"Cordia New", "Cordia New Italic", "cordiai.ttf"},
 		{"CordiaUPC", "CordiaUPC", "cordia.ttc"},
-		{"CordiaUPC", "CordiaUPC Bold", "cordiaub.ttf"},
+		{"CordiaUPC", "CordiaUPC", "cordiau.ttf"},
 		{"CordiaUPC", "CordiaUPC Bold", "cordia.ttc"},
-		{"CordiaUPC", "CordiaUPC Bold Italic", "cordiauz.ttf"},
+		{"CordiaUPC", "CordiaUPC Bold", "cordiaub.ttf"},
 		{"CordiaUPC", "CordiaUPC Bold Italic", "cordia.ttc"},
-		{"CordiaUPC", "CordiaUPC Italic", "cordiaui.ttf"},
+		{"CordiaUPC", "CordiaUPC Bold Italic", "cordiauz.ttf"},
 		{"CordiaUPC", "CordiaUPC Italic", "cordia.ttc"},
-		{"Corsiva Hebrew", "Corsiva Hebrew", "Corsiva.ttf"},
+		{"CordiaUPC", "CordiaUPC Italic", "cordiaui.ttf"},
 		{"Corsiva Hebrew", "Corsiva Hebrew", "Corsiva.ttc"},
-		{"Corsiva Hebrew", "Corsiva Hebrew Bold", "CorsivaBold.ttf"},
+		{"Corsiva Hebrew", "Corsiva Hebrew", "Corsiva.ttf"},
 		{"Corsiva Hebrew", "Corsiva Hebrew Bold", "Corsiva.ttc"},
+		{"Corsiva Hebrew", "Corsiva Hebrew Bold", "CorsivaBold.ttf"},
 		{"Cortoba", "Cortoba", "ae_Cortoba.ttf"},
 		{"Courier", "Courier", "Courier.dfont"},
 		{"Courier", "Courier Bold", "Courier.dfont"},
 		{"Courier", "Courier Bold Oblique", "Courier.dfont"},
 		{"Courier", "Courier Oblique", "Courier.dfont"},
 		{"Courier 10 Pitch", "Courier 10 Pitch", "c0419bt_.pfb"},
-		{"Courier 10 Pitch", "Courier 10 Pitch", "c0611bt_.pfb"},
-		{"Courier 10 Pitch", "Courier 10 Pitch", "c0583bt_.pfb"},
 		{"Courier 10 Pitch", "Courier 10 Pitch", "c0582bt_.pfb"},
-		{"Courier New", "Courier New", "cour.ttf"},
-		{"Courier New", "Courier New", "Courier New.ttf"},
+		{"Courier 10 Pitch", "Courier 10 Pitch", "c0583bt_.pfb"},
+		{"Courier 10 Pitch", "Courier 10 Pitch", "c0611bt_.pfb"},
 		{"Courier New", "Courier New", "Courier New"},
+		{"Courier New", "Courier New", "Courier New.ttf"},
 		{"Courier New", "Courier New", "Courier_New.ttf"},
-		{"Courier New", "Courier New Bold", "courbd.ttf"},
+		{"Courier New", "Courier New", "cour.ttf"},
 		{"Courier New", "Courier New Bold", "Courier New Bold.ttf"},
 		{"Courier New", "Courier New Bold", "Courier_New_Bold.ttf"},
-		{"Courier New", "Courier New Bold Italic", "courbi.ttf"},
+		{"Courier New", "Courier New Bold", "courbd.ttf"},
 		{"Courier New", "Courier New Bold Italic", "Courier New Bold Italic.ttf"},
 		{"Courier New", "Courier New Bold Italic", "Courier_New_Bold_Italic.ttf"},
-		{"Courier New", "Courier New Italic", "couri.ttf"},
+		{"Courier New", "Courier New Bold Italic", "courbi.ttf"},
 		{"Courier New", "Courier New Italic", "Courier New Italic.ttf"},
 		{"Courier New", "Courier New Italic", "Courier_New_Italic.ttf"},
+		{"Courier New", "Courier New Italic", "couri.ttf"},
 		{"Cursor", "Cursor", "cursor.pfa"},
-		{"D050000L", "D050000L", "D050000L.t1"},
 		{"D050000L", "D050000L", "D050000L.otf"},
+		{"D050000L", "D050000L", "D050000L.t1"},
 		{"DFKai-SB", "DFKai-SB", "kaiu.ttf"},
 		{"DIN Alternate", "DIN Alternate Bold", "DIN Alternate Bold.ttf"},
 		{"DIN Condensed", "DIN Condensed Bold", "DIN Condensed Bold.ttf"},
@@ -915,8 +918,8 @@ var fontRegistry = &registry{
 		{"David CLM", "David CLM Medium", "DavidCLM-Medium.ttf"},
 		{"David CLM", "David CLM Medium Italic", "DavidCLM-MediumItalic.otf"},
 		{"David CLM", "David CLM Medium Italic", "DavidCLM-MediumItalic.ttf"},
-		{"DecoType Naskh", "DecoType Naskh Regular", "DecoTypeNaskh.ttf"},
 		{"DecoType Naskh", "DecoType Naskh Regular", "DecoTypeNaskh.ttc"},
+		{"DecoType Naskh", "DecoType Naskh Regular", "DecoTypeNaskh.ttf"},
 		{"DejaVu Math TeX Gyre", "DejaVuMathTeXGyre-Regular", "DejaVuMathTeXGyre.ttf"},
 		{"DejaVu Sans", "DejaVu Sans", "DejaVuSans.ttf"},
 		{"DejaVu Sans", "DejaVu Sans Bold", "DejaVuSans-Bold.ttf"},
@@ -942,10 +945,10 @@ var fontRegistry = &registry{
 		{"DejaVu Serif", "DejaVu Serif Italic Condensed", "DejaVuSerifCondensed-Italic.ttf"},
 		{"DengXian", "DengXian Bold", "dengb.ttf"},
 		{"DengXian", "DengXian Light", "dengl.ttf"},
-		{"Devanagari MT", "Devanagari MT", "DevanagariMT.ttf"},
 		{"Devanagari MT", "Devanagari MT", "DevanagariMT.ttc"},
-		{"Devanagari MT", "Devanagari MT Bold", "DevanagariMTBold.ttf"},
+		{"Devanagari MT", "Devanagari MT", "DevanagariMT.ttf"},
 		{"Devanagari MT", "Devanagari MT Bold", "DevanagariMT.ttc"},
+		{"Devanagari MT", "Devanagari MT Bold", "DevanagariMTBold.ttf"},
 		{"Devanagari Sangam MN", "Devanagari Sangam MN", "Devanagari Sangam MN.ttc"},
 		{"Devanagari Sangam MN", "Devanagari Sangam MN Bold", "Devanagari Sangam MN.ttc"},
 		{"Didot", "Didot", "Didot.dfont"},
@@ -962,8 +965,8 @@ var fontRegistry = &registry{
 		{"Dingbats", "Dingbats", "d050000l.pfb"},
 		{"Diwan Kufi", "Diwan Kufi Regular", "Diwan Kufi.ttc"},
 		{"Diwan Mishafi", "Diwan Mishafi", "Mishafi.ttc"},
-		{"Diwan Thuluth", "Diwan Thuluth Regular", "Diwan Thuluth.ttf"},
 		{"Diwan Thuluth", "Diwan Thuluth Regular", "Diwan Thuluth.ttc"},
+		{"Diwan Thuluth", "Diwan Thuluth Regular", "Diwan Thuluth.ttf"},
 		{"DokChampa", "DokChampa", "dokchamp.ttf"},
 		{"Dotum", "Dotum", "gulim.ttc"},
 		{"Dotum", "DotumChe", "gulim.ttc"},
@@ -972,21 +975,21 @@ var fontRegistry = &registry{
 		{"Droid Arabic Naskh", "Droid Arabic Naskh", "DroidNaskh-Regular.ttf"},
 		{"Droid Arabic Naskh", "Droid Arabic Naskh Bold", "DroidNaskh-Bold.ttf"},
 		{"Droid Naskh Shift Alt", "Droid Naskh Shift Alt", "DroidNaskhUI-Regular.ttf"},
-		{"Droid Sans", "Droid Sans", "DroidSansHebrew-Regular.ttf"},
-		{"Droid Sans", "Droid Sans", "DroidSansThai.ttf"},
+		{"Droid Sans", "Droid Sans", "DroidSans.ttf"},
 		{"Droid Sans", "Droid Sans", "DroidSansArabic.ttf"},
 		{"Droid Sans", "Droid Sans", "DroidSansArmenian.ttf"},
-		{"Droid Sans", "Droid Sans", "DroidSansTamil-Regular.ttf"},
-		{"Droid Sans", "Droid Sans", "DroidSansFallback.ttf"},
-		{"Droid Sans", "Droid Sans", "DroidSans.ttf"},
 		{"Droid Sans", "Droid Sans", "DroidSansDevanagari-Regular.ttf"},
-		{"Droid Sans", "Droid Sans", "DroidSansJapanese.ttf"},
-		{"Droid Sans", "Droid Sans", "DroidSansGeorgian.ttf"},
 		{"Droid Sans", "Droid Sans", "DroidSansEthiopic-Regular.ttf"},
-		{"Droid Sans", "Droid Sans Bold", "DroidSansTamil-Bold.ttf"},
-		{"Droid Sans", "Droid Sans Bold", "DroidSansHebrew-Bold.ttf"},
-		{"Droid Sans", "Droid Sans Bold", "DroidSansEthiopic-Bold.ttf"},
+		{"Droid Sans", "Droid Sans", "DroidSansFallback.ttf"},
+		{"Droid Sans", "Droid Sans", "DroidSansGeorgian.ttf"},
+		{"Droid Sans", "Droid Sans", "DroidSansHebrew-Regular.ttf"},
+		{"Droid Sans", "Droid Sans", "DroidSansJapanese.ttf"},
+		{"Droid Sans", "Droid Sans", "DroidSansTamil-Regular.ttf"},
+		{"Droid Sans", "Droid Sans", "DroidSansThai.ttf"},
 		{"Droid Sans", "Droid Sans Bold", "DroidSans-Bold.ttf"},
+		{"Droid Sans", "Droid Sans Bold", "DroidSansEthiopic-Bold.ttf"},
+		{"Droid Sans", "Droid Sans Bold", "DroidSansHebrew-Bold.ttf"},
+		{"Droid Sans", "Droid Sans Bold", "DroidSansTamil-Bold.ttf"},
 		{"Droid Sans Arabic", "Droid Sans Arabic", "DroidSansArabic.ttf"},
 		{"Droid Sans Armenian", "Droid Sans Armenian", "DroidSansArmenian.ttf"},
 		{"Droid Sans Ethiopic", "Droid Sans Ethiopic", "DroidSansEthiopic-Regular.ttf"},
@@ -1002,61 +1005,61 @@ var fontRegistry = &registry{
 		{"Droid Serif", "Droid Serif Bold", "DroidSerif-Bold.ttf"},
 		{"Droid Serif", "Droid Serif Bold Italic", "DroidSerif-BoldItalic.ttf"},
 		{"Droid Serif", "Droid Serif Italic", "DroidSerif-Italic.ttf"},
-		{"Drugulin CLM", "Drugulin CLM", "DrugulinCLM-BoldItalic.pfa"},
 		{"Drugulin CLM", "Drugulin CLM", "DrugulinCLM-Bold.pfa"},
+		{"Drugulin CLM", "Drugulin CLM", "DrugulinCLM-BoldItalic.pfa"},
 		{"Dyuthi", "Dyuthi", "Dyuthi-Regular.ttf"},
 		{"Dyuthi", "Dyuthi", "Dyuthi.ttf"},
 		{"Ebrima", "Ebrima", "ebrima.ttf"},
 		{"Ebrima", "Ebrima Bold", "ebrimabd.ttf"},
 		{"Eeyek Unicode", "Eeyek Unicode", "eeyek.ttf"},
 		{"Electron", "Electron", "ae_Electron.ttf"},
-		{"Ellinia CLM", "Ellinia CLM", "ElliniaCLM-BoldItalic.pfa"},
-		{"Ellinia CLM", "Ellinia CLM", "ElliniaCLM-LightItalic.pfa"},
 		{"Ellinia CLM", "Ellinia CLM", "ElliniaCLM-Bold.pfa"},
+		{"Ellinia CLM", "Ellinia CLM", "ElliniaCLM-BoldItalic.pfa"},
 		{"Ellinia CLM", "Ellinia CLM", "ElliniaCLM-Light.pfa"},
+		{"Ellinia CLM", "Ellinia CLM", "ElliniaCLM-LightItalic.pfa"},
 		{"Estrangelo Edessa", "Estrangelo Edessa", "estre.ttf"},
 		{"EucrosiaUPC", "EucrosiaUPC", "upcel.ttf"},
 		{"EucrosiaUPC", "EucrosiaUPC Bold", "upceb.ttf"},
 		{"EucrosiaUPC", "EucrosiaUPC Bold Italic", "upcebi.ttf"},
 		{"EucrosiaUPC", "EucrosiaUPC Italic", "upcei.ttf"},
 		{"Euphemia", "Euphemia", "euphemia.ttf"},
-		{"Euphemia UCAS", "Euphemia UCAS", "EuphemiaCASRegular.ttf"},
 		{"Euphemia UCAS", "Euphemia UCAS", "EuphemiaCAS.ttc"},
-		{"Euphemia UCAS", "Euphemia UCAS Bold", "EuphemiaCASBold.ttf"},
+		{"Euphemia UCAS", "Euphemia UCAS", "EuphemiaCASRegular.ttf"},
 		{"Euphemia UCAS", "Euphemia UCAS Bold", "EuphemiaCAS.ttc"},
-		{"Euphemia UCAS", "Euphemia UCAS Italic", "EuphemiaCASItalic.ttf"},
+		{"Euphemia UCAS", "Euphemia UCAS Bold", "EuphemiaCASBold.ttf"},
 		{"Euphemia UCAS", "Euphemia UCAS Italic", "EuphemiaCAS.ttc"},
-		{"FangSong", "FangSong", "simfang.ttf"},
+		{"Euphemia UCAS", "Euphemia UCAS Italic", "EuphemiaCASItalic.ttf"},
 		{"FangSong", "FangSong", "Fang Song.dfont"},
+		{"FangSong", "FangSong", "simfang.ttf"},
 		{"Farah", "Farah Regular", "Farah.ttc"},
-		{"Farisi", "Farisi Regular", "Farisi.ttf"},
 		{"Farisi", "Farisi Regular", "Farisi.ttc"},
-		{"Fixed", "Fixed", "9x18.bdf"},
-		{"Fixed", "Fixed", "9x15.bdf"},
-		{"Fixed", "Fixed", "8x13O.bdf"},
-		{"Fixed", "Fixed", "6x9.bdf"},
+		{"Farisi", "Farisi Regular", "Farisi.ttf"},
 		{"Fixed", "Fixed", "10x20.bdf"},
-		{"Fixed", "Fixed", "5x8.bdf"},
-		{"Fixed", "Fixed", "8x13B.bdf"},
-		{"Fixed", "Fixed", "9x18B.bdf"},
-		{"Fixed", "Fixed", "6x13O.bdf"},
+		{"Fixed", "Fixed", "4x6.bdf"},
 		{"Fixed", "Fixed", "5x7.bdf"},
-		{"Fixed", "Fixed", "9x15B.bdf"},
-		{"Fixed", "Fixed", "7x13O.bdf"},
+		{"Fixed", "Fixed", "5x8.bdf"},
+		{"Fixed", "Fixed", "6x10.bdf"},
+		{"Fixed", "Fixed", "6x12.bdf"},
+		{"Fixed", "Fixed", "6x13.bdf"},
 		{"Fixed", "Fixed", "6x13B.bdf"},
-		{"Fixed", "Fixed", "4x6.bdf"},
+		{"Fixed", "Fixed", "6x13O.bdf"},
+		{"Fixed", "Fixed", "6x9.bdf"},
+		{"Fixed", "Fixed", "7x13.bdf"},
 		{"Fixed", "Fixed", "7x13B.bdf"},
+		{"Fixed", "Fixed", "7x13O.bdf"},
+		{"Fixed", "Fixed", "7x14.bdf"},
 		{"Fixed", "Fixed", "7x14B.bdf"},
-		{"Fixed", "Fixed", "6x13.bdf"},
-		{"Fixed", "Fixed", "6x12.bdf"},
-		{"Fixed", "Fixed", "6x10.bdf"},
 		{"Fixed", "Fixed", "8x13.bdf"},
-		{"Fixed", "Fixed", "7x13.bdf"},
-		{"Fixed", "Fixed", "7x14.bdf"},
+		{"Fixed", "Fixed", "8x13B.bdf"},
+		{"Fixed", "Fixed", "8x13O.bdf"},
+		{"Fixed", "Fixed", "9x15.bdf"},
+		{"Fixed", "Fixed", "9x15B.bdf"},
+		{"Fixed", "Fixed", "9x18.bdf"},
+		{"Fixed", "Fixed", "9x18B.bdf"},
 		{"Fixed Miriam Transparent", "Fixed Miriam Transparent", "mriamfx.ttf"},
-		{"FontAwesome", "FontAwesome", "fontawesome-webfont.woff"},
 		{"FontAwesome", "FontAwesome", "FontAwesome.otf"},
 		{"FontAwesome", "FontAwesome", "fontawesome-webfont.ttf"},
+		{"FontAwesome", "FontAwesome", "fontawesome-webfont.woff"},
 		{"Frank Ruehl CLM", "Frank Ruehl CLM Bold", "FrankRuehlCLM-Bold.ttf"},
 		{"Frank Ruehl CLM", "Frank Ruehl CLM Bold Oblique", "FrankRuehlCLM-BoldOblique.ttf"},
 		{"Frank Ruehl CLM", "Frank Ruehl CLM Medium", "FrankRuehlCLM-Medium.ttf"},
@@ -1064,14 +1067,14 @@ var fontRegistry = &registry{
 		{"FrankRuehl", "FrankRuehl", "frank.ttf"},
 		{"Franklin Gothic Medium", "Franklin Gothic Medium", "framd.ttf"},
 		{"Franklin Gothic Medium", "Franklin Gothic Medium Italic", "framdit.ttf"},
-		{"Free Avant Garde", "Free Avant Garde", "a010033d.pfb"},
-		{"Free Avant Garde", "Free Avant Garde", "a010035d.pfb"},
 		{"Free Avant Garde", "Free Avant Garde", "a010013d.pfb"},
 		{"Free Avant Garde", "Free Avant Garde", "a010015d.pfb"},
-		{"Free Bookman", "Free Bookman", "b018032d.pfb"},
-		{"Free Bookman", "Free Bookman", "b018035d.pfb"},
+		{"Free Avant Garde", "Free Avant Garde", "a010033d.pfb"},
+		{"Free Avant Garde", "Free Avant Garde", "a010035d.pfb"},
 		{"Free Bookman", "Free Bookman", "b018012d.pfb"},
 		{"Free Bookman", "Free Bookman", "b018015d.pfb"},
+		{"Free Bookman", "Free Bookman", "b018032d.pfb"},
+		{"Free Bookman", "Free Bookman", "b018035d.pfb"},
 		{"Free Chancery", "Free Chancery", "z003034d.pfb"},
 		{"Free Courier", "Free Courier", "n022003d.pfb"},
 		{"Free Courier", "Free Courier", "n022004d.pfb"},
@@ -1081,22 +1084,22 @@ var fontRegistry = &registry{
 		{"Free Helvetian", "Free Helvetian", "n019004d.pfb"},
 		{"Free Helvetian", "Free Helvetian", "n019023d.pfb"},
 		{"Free Helvetian", "Free Helvetian", "n019024d.pfb"},
-		{"Free Helvetian Condensed", "Free Helvetian Condensed", "n019064d.pfb"},
-		{"Free Helvetian Condensed", "Free Helvetian Condensed", "n019063d.pfb"},
-		{"Free Helvetian Condensed", "Free Helvetian Condensed", "n019044d.pfb"},
 		{"Free Helvetian Condensed", "Free Helvetian Condensed", "n019043d.pfb"},
-		{"Free Paladin", "Free Paladin", "p052023d.pfb"},
-		{"Free Paladin", "Free Paladin", "p052024d.pfb"},
+		{"Free Helvetian Condensed", "Free Helvetian Condensed", "n019044d.pfb"},
+		{"Free Helvetian Condensed", "Free Helvetian Condensed", "n019063d.pfb"},
+		{"Free Helvetian Condensed", "Free Helvetian Condensed", "n019064d.pfb"},
 		{"Free Paladin", "Free Paladin", "p052003d.pfb"},
 		{"Free Paladin", "Free Paladin", "p052004d.pfb"},
+		{"Free Paladin", "Free Paladin", "p052023d.pfb"},
+		{"Free Paladin", "Free Paladin", "p052024d.pfb"},
 		{"Free Schoolbook", "Free Schoolbook", "c059013d.pfb"},
 		{"Free Schoolbook", "Free Schoolbook", "c059016d.pfb"},
 		{"Free Schoolbook", "Free Schoolbook", "c059033d.pfb"},
 		{"Free Schoolbook", "Free Schoolbook", "c059036d.pfb"},
-		{"Free Times", "Free Times", "n021004d.pfb"},
 		{"Free Times", "Free Times", "n021003d.pfb"},
-		{"Free Times", "Free Times", "n021024d.pfb"},
+		{"Free Times", "Free Times", "n021004d.pfb"},
 		{"Free Times", "Free Times", "n021023d.pfb"},
+		{"Free Times", "Free Times", "n021024d.pfb"},
 		{"FreeMono", "Free Monospaced", "FreeMono.ttf"},
 		{"FreeMono", "Free Monospaced Bold", "FreeMonoBold.ttf"},
 		{"FreeMono", "Free Monospaced Bold Oblique", "FreeMonoBoldOblique.ttf"},
@@ -1152,11 +1155,11 @@ var fontRegistry = &registry{
 		{"Gautami", "Gautami", "gautami.ttf"},
 		{"Gautami", "Gautami Bold", "gautamib.ttf"},
 		{"Geeza Pro", "Geeza Pro Bold", "Geeza Pro Bold.ttf"},
-		{"Geeza Pro", "Geeza Pro Bold", "GeezaPro.ttc"},
 		{"Geeza Pro", "Geeza Pro Bold", "Geeza Pro.ttc"},
+		{"Geeza Pro", "Geeza Pro Bold", "GeezaPro.ttc"},
+		{"Geeza Pro", "Geeza Pro Regular", "Geeza Pro.ttc"},
 		{"Geeza Pro", "Geeza Pro Regular", "Geeza Pro.ttf"},
 		{"Geeza Pro", "Geeza Pro Regular", "GeezaPro.ttc"},
-		{"Geeza Pro", "Geeza Pro Regular", "Geeza Pro.ttc"},
 		{"Geneva", "Geneva", "Geneva.dfont"},
 		{"Geneva CY", "Geneva CY", "GenevaCY.dfont"},
 		{"Gentium", "Gentium", "GenR102.ttf"},
@@ -1175,18 +1178,18 @@ var fontRegistry = &registry{
 		{"GentiumAlt", "GentiumAlt", "GentiumAlt-R.ttf"},
 		{"GentiumAlt", "GentiumAlt Italic", "GenAI102.ttf"},
 		{"GentiumAlt", "GentiumAlt Italic", "GentiumAlt-I.ttf"},
-		{"Georgia", "Georgia", "georgia.ttf"},
-		{"Georgia", "Georgia", "Georgia.ttf"},
 		{"Georgia", "Georgia", "Georgia"},
-		{"Georgia", "Georgia Bold", "georgiab.ttf"},
+		{"Georgia", "Georgia", "Georgia.ttf"},
+		{"Georgia", "Georgia", "georgia.ttf"},
 		{"Georgia", "Georgia Bold", "Georgia Bold.ttf"},
 		{"Georgia", "Georgia Bold", "Georgia_Bold.ttf"},
-		{"Georgia", "Georgia Bold Italic", "georgiaz.ttf"},
+		{"Georgia", "Georgia Bold", "georgiab.ttf"},
 		{"Georgia", "Georgia Bold Italic", "Georgia Bold Italic.ttf"},
 		{"Georgia", "Georgia Bold Italic", "Georgia_Bold_Italic.ttf"},
-		{"Georgia", "Georgia Italic", "georgiai.ttf"},
+		{"Georgia", "Georgia Bold Italic", "georgiaz.ttf"},
 		{"Georgia", "Georgia Italic", "Georgia Italic.ttf"},
 		{"Georgia", "Georgia Italic", "Georgia_Italic.ttf"},
+		{"Georgia", "Georgia Italic", "georgiai.ttf"},
 		{"Georgia Pro", "Georgia Pro", "georgiapro-regular.ttf"},
 		{"Georgia Pro", "Georgia Pro Black", "georgiapro-black.ttf"},
 		{"Georgia Pro", "Georgia Pro Black Italic", "georgiapro-blackitalic.ttf"},
@@ -1243,10 +1246,10 @@ var fontRegistry = &registry{
 		{"Granada", "Granada", "ae_Granada.ttf"},
 		{"Graph", "Graph", "ae_Graph.ttf"},
 		{"Gubbi", "Gubbi", "Gubbi.ttf"},
-		{"Gujarati MT", "Gujarati MT", "GujaratiMT.ttf"},
 		{"Gujarati MT", "Gujarati MT", "GujaratiMT.ttc"},
-		{"Gujarati MT", "Gujarati MT Bold", "GujaratiMTBold.ttf"},
+		{"Gujarati MT", "Gujarati MT", "GujaratiMT.ttf"},
 		{"Gujarati MT", "Gujarati MT Bold", "GujaratiMT.ttc"},
+		{"Gujarati MT", "Gujarati MT Bold", "GujaratiMTBold.ttf"},
 		{"Gujarati Sangam MN", "Gujarati Sangam MN", "Gujarati Sangam MN.ttc"},
 		{"Gujarati Sangam MN", "Gujarati Sangam MN", "Gujarati Sangam MN.ttf"},
 		{"Gujarati Sangam MN", "Gujarati Sangam MN Bold", "Gujarati Sangam MN.ttc"},
@@ -1394,14 +1397,14 @@ var fontRegistry = &registry{
 		{"Hoefler Text", "Hoefler Text Black Italic", "Hoefler Text.ttc"},
 		{"Hoefler Text", "Hoefler Text Italic", "Hoefler Text.dfont"},
 		{"Hoefler Text", "Hoefler Text Italic", "Hoefler Text.ttc"},
-		{"Hoefler Text Ornaments", "Hoefler Text Ornaments", "Hoefler Text.dfont"},
 		{"Hoefler Text Ornaments", "Hoefler Text Ornaments", "Hoefler Text Ornaments.ttf"},
+		{"Hoefler Text Ornaments", "Hoefler Text Ornaments", "Hoefler Text.dfont"},
 		{"Homa", "Homa", "homa.ttf"},
 		{"Hor", "Hor", "ae_Hor.ttf"},
-		{"IPAGothic", "IPAGothic", "ipag.ttf"},
 		{"IPAGothic", "IPAGothic", "fonts-japanese-gothic.ttf"},
-		{"IPAMincho", "IPAMincho", "ipam.ttf"},
+		{"IPAGothic", "IPAGothic", "ipag.ttf"},
 		{"IPAMincho", "IPAMincho", "fonts-japanese-mincho.ttf"},
+		{"IPAMincho", "IPAMincho", "ipam.ttf"},
 		{"IPAPGothic", "IPAPGothic", "ipagp.ttf"},
 		{"IPAPMincho", "IPAPMincho", "ipamp.ttf"},
 		{"ITF Devanagari", "ITF Devanagari Bold", "ITFDevanagari.ttc"},
@@ -1414,11 +1417,11 @@ var fontRegistry = &registry{
 		{"ITF Devanagari Marathi", "ITF Devanagari Marathi Demi", "ITFDevanagari.ttc"},
 		{"ITF Devanagari Marathi", "ITF Devanagari Marathi Light", "ITFDevanagari.ttc"},
 		{"ITF Devanagari Marathi", "ITF Devanagari Marathi Medium", "ITFDevanagari.ttc"},
-		{"Impact", "Impact", "impact.ttf"},
-		{"Impact", "Impact", "Impact.ttf"},
 		{"Impact", "Impact", "Impact"},
-		{"InaiMathi", "InaiMathi", "InaiMathi.ttf"},
+		{"Impact", "Impact", "Impact.ttf"},
+		{"Impact", "Impact", "impact.ttf"},
 		{"InaiMathi", "InaiMathi", "InaiMathi-MN.ttc"},
+		{"InaiMathi", "InaiMathi", "InaiMathi.ttf"},
 		{"InaiMathi", "InaiMathi Bold", "InaiMathi-MN.ttc"},
 		{"Ink Free", "Ink Free", "inkfree.ttf"},
 		{"Iowan Old Style", "Iowan Old Style Black", "Iowan Old Style.ttc"},
@@ -1463,8 +1466,8 @@ var fontRegistry = &registry{
 		{"Kai", "Kai Regular", "Kai.ttf"},
 		{"KaiTi", "KaiTi", "simkai.ttf"},
 		{"Kailasa", "Kailasa Bold", "Kailasa.ttc"},
-		{"Kailasa", "Kailasa Regular", "Kailasa.ttf"},
 		{"Kailasa", "Kailasa Regular", "Kailasa.ttc"},
+		{"Kailasa", "Kailasa Regular", "Kailasa.ttf"},
 		{"Kaiti SC", "Kaiti SC Black", "Kaiti.ttc"},
 		{"Kaiti SC", "Kaiti SC Bold", "Kaiti.ttc"},
 		{"Kaiti SC", "Kaiti SC Regular", "Kaiti.ttc"},
@@ -1554,8 +1557,8 @@ var fontRegistry = &registry{
 		{"Kokila", "Kokila Italic", "kokilai.ttf"},
 		{"Kokonor", "Kokonor Regular", "Kokonor.ttf"},
 		{"Krungthep", "Krungthep", "Krungthep.ttf"},
-		{"KufiStandardGK", "KufiStandardGK Regular", "KufiStandardGK.ttf"},
 		{"KufiStandardGK", "KufiStandardGK Regular", "KufiStandardGK.ttc"},
+		{"KufiStandardGK", "KufiStandardGK Regular", "KufiStandardGK.ttf"},
 		{"LKLUG", "LKLUG", "lklug.ttf"},
 		{"Laksaman", "Laksaman", "Laksaman.ttf"},
 		{"Laksaman", "Laksaman Bold", "Laksaman-Bold.ttf"},
@@ -1573,8 +1576,8 @@ var fontRegistry = &registry{
 		{"Lao UI", "Lao UI", "laoui.ttf"},
 		{"Lao UI", "Lao UI Bold", "laouib.ttf"},
 		{"LastResort", "LastResort", "LastResort.dfont"},
-		{"LastResort", "LastResort", "LastResort.ttf"},
 		{"LastResort", "LastResort", "LastResort.otf"},
+		{"LastResort", "LastResort", "LastResort.ttf"},
 		{"Latha", "Latha", "latha.ttf"},
 		{"Latha", "Latha Bold", "lathab.ttf"},
 		{"Lato", "Lato Black", "Lato-Black.ttf"},
@@ -1602,10 +1605,10 @@ var fontRegistry = &registry{
 		{"Leelawadee UI", "Leelawadee UI Semilight", "leeluisl.ttf"},
 		{"Levenim MT", "Levenim MT", "lvnm.ttf"},
 		{"Levenim MT", "Levenim MT Bold", "lvnmbd.ttf"},
-		{"LiHei Pro", "LiHei Pro", "儷黑 Pro.ttf"},
 		{"LiHei Pro", "LiHei Pro", "LiHeiPro.ttf"},
-		{"LiSong Pro", "LiSong Pro", "儷宋 Pro.ttf"},
+		{"LiHei Pro", "LiHei Pro", "儷黑 Pro.ttf"},
 		{"LiSong Pro", "LiSong Pro", "LiSongPro.ttf"},
+		{"LiSong Pro", "LiSong Pro", "儷宋 Pro.ttf"},
 		{"Liberation Mono", "Liberation Mono", "LiberationMono-Regular.ttf"},
 		{"Liberation Mono", "Liberation Mono Bold", "LiberationMono-Bold.ttf"},
 		{"Liberation Mono", "Liberation Mono Bold Italic", "LiberationMono-BoldItalic.ttf"},
@@ -1681,8 +1684,8 @@ var fontRegistry = &registry{
 		{"Lucida Sans", "Lucida Sans Demibold Italic", "lsansdi.ttf"},
 		{"Lucida Sans", "Lucida Sans Demibold Roman", "lsansd.ttf"},
 		{"Lucida Sans", "Lucida Sans Italic", "lsansi.ttf"},
-		{"Lucida Sans", "Lucida Sans Regular", "lsansi.ttf"},
 		{"Lucida Sans", "Lucida Sans Regular", "lsans.ttf"},
+		{"Lucida Sans", "Lucida Sans Regular", "lsansi.ttf"},
 		{"Lucida Sans Typewriter", "Lucida Sans Typewriter Regular", "ltype.ttf"},
 		{"Lucida Sans Unicode", "Lucida Sans Unicode", "l_10646.ttf"},
 		{"Luminari", "Luminari", "Luminari.ttf"},
@@ -1718,8 +1721,8 @@ var fontRegistry = &registry{
 		{"Marlett", "Marlett", "marlett.ttf"},
 		{"Mashq", "Mashq", "ae_Mashq.ttf"},
 		{"Mashq", "Mashq Bold", "ae_Mashq-Bold.ttf"},
-		{"Meera", "Meera", "Meera.ttf"},
 		{"Meera", "Meera", "Meera-Regular.ttf"},
+		{"Meera", "Meera", "Meera.ttf"},
 		{"Meera", "Meera", "Meera_04.ttf"},
 		{"Meiryo", "Meiryo", "meiryo.ttc"},
 		{"Meiryo", "Meiryo Bold", "meiryob.ttc"},
@@ -1747,8 +1750,8 @@ var fontRegistry = &registry{
 		{"Microsoft New Tai Lue", "Microsoft New Tai Lue Bold", "ntailub.ttf"},
 		{"Microsoft PhagsPa", "Microsoft PhagsPa", "phagspa.ttf"},
 		{"Microsoft PhagsPa", "Microsoft PhagsPa Bold", "phagspab.ttf"},
-		{"Microsoft Sans Serif", "Microsoft Sans Serif", "micross.ttf"},
 		{"Microsoft Sans Serif", "Microsoft Sans Serif", "Microsoft Sans Serif.ttf"},
+		{"Microsoft Sans Serif", "Microsoft Sans Serif", "micross.ttf"},
 		{"Microsoft Tai Le", "Microsoft Tai Le", "taile.ttf"},
 		{"Microsoft Tai Le", "Microsoft Tai Le Bold", "taileb.ttf"},
 		{"Microsoft Uighur", "Microsoft Uighur", "msuighur.ttf"},
@@ -1790,34 +1793,34 @@ var fontRegistry = &registry{
 		{"Montserrat", "Montserrat Black", "Montserrat-Black.ttf"},
 		{"Montserrat", "Montserrat Black Italic", "Montserrat-BlackItalic.otf"},
 		{"Montserrat", "Montserrat Black Italic", "Montserrat-BlackItalic.ttf"},
-		{"Montserrat", "Montserrat Bold", "Montserrat-Bold.ttf"},
 		{"Montserrat", "Montserrat Bold", "Montserrat-Bold.otf"},
+		{"Montserrat", "Montserrat Bold", "Montserrat-Bold.ttf"},
 		{"Montserrat", "Montserrat Bold Italic", "Montserrat-BoldItalic.otf"},
 		{"Montserrat", "Montserrat Bold Italic", "Montserrat-BoldItalic.ttf"},
-		{"Montserrat", "Montserrat ExtraBold", "Montserrat-ExtraBold.ttf"},
 		{"Montserrat", "Montserrat ExtraBold", "Montserrat-ExtraBold.otf"},
+		{"Montserrat", "Montserrat ExtraBold", "Montserrat-ExtraBold.ttf"},
 		{"Montserrat", "Montserrat ExtraBold Italic", "Montserrat-ExtraBoldItalic.otf"},
 		{"Montserrat", "Montserrat ExtraBold Italic", "Montserrat-ExtraBoldItalic.ttf"},
 		{"Montserrat", "Montserrat ExtraLight", "Montserrat-ExtraLight.otf"},
 		{"Montserrat", "Montserrat ExtraLight", "Montserrat-ExtraLight.ttf"},
-		{"Montserrat", "Montserrat ExtraLight Italic", "Montserrat-ExtraLightItalic.ttf"},
 		{"Montserrat", "Montserrat ExtraLight Italic", "Montserrat-ExtraLightItalic.otf"},
+		{"Montserrat", "Montserrat ExtraLight Italic", "Montserrat-ExtraLightItalic.ttf"},
 		{"Montserrat", "Montserrat Italic", "Montserrat-Italic.otf"},
 		{"Montserrat", "Montserrat Italic", "Montserrat-Italic.ttf"},
 		{"Montserrat", "Montserrat Light", "Montserrat-Light.otf"},
 		{"Montserrat", "Montserrat Light", "Montserrat-Light.ttf"},
-		{"Montserrat", "Montserrat Light Italic", "Montserrat-LightItalic.ttf"},
 		{"Montserrat", "Montserrat Light Italic", "Montserrat-LightItalic.otf"},
+		{"Montserrat", "Montserrat Light Italic", "Montserrat-LightItalic.ttf"},
 		{"Montserrat", "Montserrat Medium", "Montserrat-Medium.otf"},
 		{"Montserrat", "Montserrat Medium", "Montserrat-Medium.ttf"},
 		{"Montserrat", "Montserrat Medium Italic", "Montserrat-MediumItalic.otf"},
 		{"Montserrat", "Montserrat Medium Italic", "Montserrat-MediumItalic.ttf"},
 		{"Montserrat", "Montserrat Regular", "Montserrat-Regular.otf"},
 		{"Montserrat", "Montserrat Regular", "Montserrat-Regular.ttf"},
-		{"Montserrat", "Montserrat SemiBold", "Montserrat-SemiBold.ttf"},
 		{"Montserrat", "Montserrat SemiBold", "Montserrat-SemiBold.otf"},
-		{"Montserrat", "Montserrat SemiBold Italic", "Montserrat-SemiBoldItalic.ttf"},
+		{"Montserrat", "Montserrat SemiBold", "Montserrat-SemiBold.ttf"},
 		{"Montserrat", "Montserrat SemiBold Italic", "Montserrat-SemiBoldItalic.otf"},
+		{"Montserrat", "Montserrat SemiBold Italic", "Montserrat-SemiBoldItalic.ttf"},
 		{"Montserrat", "Montserrat Thin", "Montserrat-Thin.otf"},
 		{"Montserrat", "Montserrat Thin", "Montserrat-Thin.ttf"},
 		{"Montserrat", "Montserrat Thin Italic", "Montserrat-ThinItalic.otf"},
@@ -1826,79 +1829,79 @@ var fontRegistry = &registry{
 		{"Montserrat", "Montserrat-Black", "Montserrat-Black.ttf"},
 		{"Montserrat", "Montserrat-ExtraBold", "Montserrat-ExtraBold.otf"},
 		{"Montserrat", "Montserrat-ExtraBold", "Montserrat-ExtraBold.ttf"},
-		{"Montserrat", "Montserrat-Light", "Montserrat-Light.ttf"},
 		{"Montserrat", "Montserrat-Light", "Montserrat-Light.otf"},
+		{"Montserrat", "Montserrat-Light", "Montserrat-Light.ttf"},
 		{"Montserrat", "Montserrat-Medium", "Montserrat-Medium.otf"},
 		{"Montserrat", "Montserrat-Medium", "Montserrat-Medium.ttf"},
 		{"Montserrat", "Montserrat-Regular", "Montserrat-Regular.otf"},
 		{"Montserrat", "Montserrat-Regular", "Montserrat-Regular.ttf"},
 		{"Montserrat", "Montserrat-SemiBold", "Montserrat-SemiBold.otf"},
 		{"Montserrat", "Montserrat-SemiBold", "Montserrat-SemiBold.ttf"},
-		{"Montserrat", "Montserrat-Thin", "Montserrat-Thin.ttf"},
 		{"Montserrat", "Montserrat-Thin", "Montserrat-Thin.otf"},
+		{"Montserrat", "Montserrat-Thin", "Montserrat-Thin.ttf"},
 		{"Montserrat", "Montserrat-UltraLight", "Montserrat-UltraLight.otf"},
 		{"Montserrat", "Montserrat-UltraLight", "Montserrat-UltraLight.ttf"},
 		{"Montserrat Alternates", "Montserrat Alternates Black", "MontserratAlternates-Black.otf"},
 		{"Montserrat Alternates", "Montserrat Alternates Black", "MontserratAlternates-Black.ttf"},
-		{"Montserrat Alternates", "Montserrat Alternates Black Italic", "MontserratAlternates-BlackItalic.ttf"},
 		{"Montserrat Alternates", "Montserrat Alternates Black Italic", "MontserratAlternates-BlackItalic.otf"},
-		{"Montserrat Alternates", "Montserrat Alternates Bold", "MontserratAlternates-Bold.ttf"},
+		{"Montserrat Alternates", "Montserrat Alternates Black Italic", "MontserratAlternates-BlackItalic.ttf"},
 		{"Montserrat Alternates", "Montserrat Alternates Bold", "MontserratAlternates-Bold.otf"},
-		{"Montserrat Alternates", "Montserrat Alternates Bold Italic", "MontserratAlternates-BoldItalic.ttf"},
+		{"Montserrat Alternates", "Montserrat Alternates Bold", "MontserratAlternates-Bold.ttf"},
 		{"Montserrat Alternates", "Montserrat Alternates Bold Italic", "MontserratAlternates-BoldItalic.otf"},
+		{"Montserrat Alternates", "Montserrat Alternates Bold Italic", "MontserratAlternates-BoldItalic.ttf"},
 		{"Montserrat Alternates", "Montserrat Alternates ExtraBold", "MontserratAlternates-ExtraBold.otf"},
 		{"Montserrat Alternates", "Montserrat Alternates ExtraBold", "MontserratAlternates-ExtraBold.ttf"},
 		{"Montserrat Alternates", "Montserrat Alternates ExtraBold Italic", "MontserratAlternates-ExtraBoldItalic.otf"},
 		{"Montserrat Alternates", "Montserrat Alternates ExtraBold Italic", "MontserratAlternates-ExtraBoldItalic.ttf"},
-		{"Montserrat Alternates", "Montserrat Alternates ExtraLight", "MontserratAlternates-ExtraLight.ttf"},
 		{"Montserrat Alternates", "Montserrat Alternates ExtraLight", "MontserratAlternates-ExtraLight.otf"},
+		{"Montserrat Alternates", "Montserrat Alternates ExtraLight", "MontserratAlternates-ExtraLight.ttf"},
 		{"Montserrat Alternates", "Montserrat Alternates ExtraLight Italic", "MontserratAlternates-ExtraLightItalic.otf"},
 		{"Montserrat Alternates", "Montserrat Alternates ExtraLight Italic", "MontserratAlternates-ExtraLightItalic.ttf"},
-		{"Montserrat Alternates", "Montserrat Alternates Italic", "MontserratAlternates-Italic.ttf"},
 		{"Montserrat Alternates", "Montserrat Alternates Italic", "MontserratAlternates-Italic.otf"},
+		{"Montserrat Alternates", "Montserrat Alternates Italic", "MontserratAlternates-Italic.ttf"},
 		{"Montserrat Alternates", "Montserrat Alternates Light", "MontserratAlternates-Light.otf"},
 		{"Montserrat Alternates", "Montserrat Alternates Light", "MontserratAlternates-Light.ttf"},
 		{"Montserrat Alternates", "Montserrat Alternates Light Italic", "MontserratAlternates-LightItalic.otf"},
 		{"Montserrat Alternates", "Montserrat Alternates Light Italic", "MontserratAlternates-LightItalic.ttf"},
 		{"Montserrat Alternates", "Montserrat Alternates Medium", "MontserratAlternates-Medium.otf"},
 		{"Montserrat Alternates", "Montserrat Alternates Medium", "MontserratAlternates-Medium.ttf"},
-		{"Montserrat Alternates", "Montserrat Alternates Medium Italic", "MontserratAlternates-MediumItalic.ttf"},
 		{"Montserrat Alternates", "Montserrat Alternates Medium Italic", "MontserratAlternates-MediumItalic.otf"},
+		{"Montserrat Alternates", "Montserrat Alternates Medium Italic", "MontserratAlternates-MediumItalic.ttf"},
 		{"Montserrat Alternates", "Montserrat Alternates Regular", "MontserratAlternates-Regular.otf"},
 		{"Montserrat Alternates", "Montserrat Alternates Regular", "MontserratAlternates-Regular.ttf"},
 		{"Montserrat Alternates", "Montserrat Alternates SemiBold", "MontserratAlternates-SemiBold.otf"},
 		{"Montserrat Alternates", "Montserrat Alternates SemiBold", "MontserratAlternates-SemiBold.ttf"},
 		{"Montserrat Alternates", "Montserrat Alternates SemiBold Italic", "MontserratAlternates-SemiBoldItalic.otf"},
 		{"Montserrat Alternates", "Montserrat Alternates SemiBold Italic", "MontserratAlternates-SemiBoldItalic.ttf"},
-		{"Montserrat Alternates", "Montserrat Alternates Thin", "MontserratAlternates-Thin.ttf"},
 		{"Montserrat Alternates", "Montserrat Alternates Thin", "MontserratAlternates-Thin.otf"},
-		{"Montserrat Alternates", "Montserrat Alternates Thin Italic", "MontserratAlternates-ThinItalic.ttf"},
+		{"Montserrat Alternates", "Montserrat Alternates Thin", "MontserratAlternates-Thin.ttf"},
 		{"Montserrat Alternates", "Montserrat Alternates Thin Italic", "MontserratAlternates-ThinItalic.otf"},
-		{"Montserrat Alternates", "MontserratAlternates-Black", "MontserratAlternates-Black.ttf"},
+		{"Montserrat Alternates", "Montserrat Alternates Thin Italic", "MontserratAlternates-ThinItalic.ttf"},
 		{"Montserrat Alternates", "MontserratAlternates-Black", "MontserratAlternates-Black.otf"},
-		{"Montserrat Alternates", "MontserratAlternates-ExtraBold", "MontserratAlternates-ExtraBold.ttf"},
+		{"Montserrat Alternates", "MontserratAlternates-Black", "MontserratAlternates-Black.ttf"},
 		{"Montserrat Alternates", "MontserratAlternates-ExtraBold", "MontserratAlternates-ExtraBold.otf"},
-		{"Montserrat Alternates", "MontserratAlternates-Light", "MontserratAlternates-Light.ttf"},
+		{"Montserrat Alternates", "MontserratAlternates-ExtraBold", "MontserratAlternates-ExtraBold.ttf"},
 		{"Montserrat Alternates", "MontserratAlternates-Light", "MontserratAlternates-Light.otf"},
+		{"Montserrat Alternates", "MontserratAlternates-Light", "MontserratAlternates-Light.ttf"},
 		{"Montserrat Alternates", "MontserratAlternates-Medium", "MontserratAlternates-Medium.otf"},
 		{"Montserrat Alternates", "MontserratAlternates-Medium", "MontserratAlternates-Medium.ttf"},
 		{"Montserrat Alternates", "MontserratAlternates-Regular", "MontserratAlternates-Regular.otf"},
 		{"Montserrat Alternates", "MontserratAlternates-Regular", "MontserratAlternates-Regular.ttf"},
 		{"Montserrat Alternates", "MontserratAlternates-SemiBold", "MontserratAlternates-SemiBold.otf"},
 		{"Montserrat Alternates", "MontserratAlternates-SemiBold", "MontserratAlternates-SemiBold.ttf"},
-		{"Montserrat Alternates", "MontserratAlternates-Thin", "MontserratAlternates-Thin.ttf"},
 		{"Montserrat Alternates", "MontserratAlternates-Thin", "MontserratAlternates-Thin.otf"},
+		{"Montserrat Alternates", "MontserratAlternates-Thin", "MontserratAlternates-Thin.ttf"},
 		{"Montserrat Alternates", "MontserratAlternates-UltraLight", "MontserratAlternates-UltraLight.otf"},
 		{"Montserrat Alternates", "MontserratAlternates-UltraLight", "MontserratAlternates-UltraLight.ttf"},
 		{"MoolBoran", "MoolBoran", "moolbor.ttf"},
-		{"Mshtakan", "Mshtakan Bold", "MshtakanBold.ttf"},
 		{"Mshtakan", "Mshtakan Bold", "Mshtakan.ttc"},
-		{"Mshtakan", "Mshtakan Bold Oblique", "MshtakanBoldOblique.ttf"},
+		{"Mshtakan", "Mshtakan Bold", "MshtakanBold.ttf"},
 		{"Mshtakan", "Mshtakan Bold Oblique", "Mshtakan.ttc"},
-		{"Mshtakan", "Mshtakan Oblique", "MshtakanOblique.ttf"},
+		{"Mshtakan", "Mshtakan Bold Oblique", "MshtakanBoldOblique.ttf"},
 		{"Mshtakan", "Mshtakan Oblique", "Mshtakan.ttc"},
-		{"Mshtakan", "Mshtakan Regular", "MshtakanRegular.ttf"},
+		{"Mshtakan", "Mshtakan Oblique", "MshtakanOblique.ttf"},
 		{"Mshtakan", "Mshtakan Regular", "Mshtakan.ttc"},
+		{"Mshtakan", "Mshtakan Regular", "MshtakanRegular.ttf"},
 		{"MuktaMahee", "MuktaMahee Bold", "MuktaMahee.ttc"},
 		{"MuktaMahee", "MuktaMahee ExtraBold", "MuktaMahee.ttc"},
 		{"MuktaMahee", "MuktaMahee ExtraLight", "MuktaMahee.ttc"},
@@ -1913,8 +1916,8 @@ var fontRegistry = &registry{
 		{"Muna", "Muna Regular", "Muna.ttc"},
 		{"Myanmar MN", "Myanmar MN", "Myanmar MN.ttc"},
 		{"Myanmar MN", "Myanmar MN Bold", "Myanmar MN.ttc"},
-		{"Myanmar Sangam MN", "Myanmar Sangam MN", "Myanmar Sangam MN.ttf"},
 		{"Myanmar Sangam MN", "Myanmar Sangam MN", "Myanmar Sangam MN.ttc"},
+		{"Myanmar Sangam MN", "Myanmar Sangam MN", "Myanmar Sangam MN.ttf"},
 		{"Myanmar Sangam MN", "Myanmar Sangam MN Bold", "Myanmar Sangam MN.ttc"},
 		{"Myanmar Text", "Myanmar Text", "mmrtext.ttf"},
 		{"Myanmar Text", "Myanmar Text Bold", "mmrtextb.ttf"},
@@ -1928,17 +1931,17 @@ var fontRegistry = &registry{
 		{"Myriad Arabic", "Myriad Arabic Light Italic", "MyriadArabic.ttc"},
 		{"Myriad Arabic", "Myriad Arabic Semibold", "MyriadArabic.ttc"},
 		{"Myriad Arabic", "Myriad Arabic Semibold Italic", "MyriadArabic.ttc"},
-		{"Nachlieli CLM", "Nachlieli CLM", "NachlieliCLM-Light.pfa"},
 		{"Nachlieli CLM", "Nachlieli CLM", "NachlieliCLM-Bold.pfa"},
-		{"Nachlieli CLM", "Nachlieli CLM", "NachlieliCLM-LightOblique.pfa"},
 		{"Nachlieli CLM", "Nachlieli CLM", "NachlieliCLM-BoldOblique.pfa"},
+		{"Nachlieli CLM", "Nachlieli CLM", "NachlieliCLM-Light.pfa"},
+		{"Nachlieli CLM", "Nachlieli CLM", "NachlieliCLM-LightOblique.pfa"},
 		{"Nachlieli CLM", "Nachlieli CLM Bold", "NachlieliCLM-Bold.otf"},
 		{"Nachlieli CLM", "Nachlieli CLM Bold Oblique", "NachlieliCLM-BoldOblique.otf"},
 		{"Nachlieli CLM", "Nachlieli CLM Light", "NachlieliCLM-Light.otf"},
 		{"Nachlieli CLM", "Nachlieli CLM Light Oblique", "NachlieliCLM-LightOblique.otf"},
 		{"Nada", "Nada", "ae_Nada.ttf"},
-		{"Nadeem", "Nadeem Regular", "Nadeem.ttf"},
 		{"Nadeem", "Nadeem Regular", "Nadeem.ttc"},
+		{"Nadeem", "Nadeem Regular", "Nadeem.ttf"},
 		{"Nagham", "Nagham", "ae_Nagham.ttf"},
 		{"Nakula", "Nakula", "nakula.ttf"},
 		{"Nanum Gothic", "NanumGothic", "NanumGothic.ttc"},
@@ -1978,14 +1981,14 @@ var fontRegistry = &registry{
 		{"Neue Haas Grotesk Text Pro", "Neue Haas Grotesk Text Pro Italic", "nhaasgrotesktxpro-56it.ttf"},
 		{"Neue Haas Grotesk Text Pro", "Neue Haas Grotesk Text Pro Medium", "nhaasgrotesktxpro-65md.ttf"},
 		{"Neue Haas Grotesk Text Pro", "Neue Haas Grotesk Text Pro Medium Italic", "nhaasgrotesktxpro-66mdit.ttf"},
-		{"New Peninim MT", "New Peninim MT", "NewPeninimMT.ttf"},
 		{"New Peninim MT", "New Peninim MT", "NewPeninimMT.ttc"},
-		{"New Peninim MT", "New Peninim MT Bold", "NewPeninimMTBold.ttf"},
+		{"New Peninim MT", "New Peninim MT", "NewPeninimMT.ttf"},
 		{"New Peninim MT", "New Peninim MT Bold", "NewPeninimMT.ttc"},
-		{"New Peninim MT", "New Peninim MT Bold Inclined", "NewPeninimMTBoldInclined.ttf"},
+		{"New Peninim MT", "New Peninim MT Bold", "NewPeninimMTBold.ttf"},
 		{"New Peninim MT", "New Peninim MT Bold Inclined", "NewPeninimMT.ttc"},
-		{"New Peninim MT", "New Peninim MT Inclined", "NewPeninimMTInclined.ttf"},
+		{"New Peninim MT", "New Peninim MT Bold Inclined", "NewPeninimMTBoldInclined.ttf"},
 		{"New Peninim MT", "New Peninim MT Inclined", "NewPeninimMT.ttc"},
+		{"New Peninim MT", "New Peninim MT Inclined", "NewPeninimMTInclined.ttf"},
 		{"News Gothic MT", "News Gothic MT", "nwgthc.ttf"},
 		{"News Gothic MT", "News Gothic MT Bold", "nwgthcb.ttf"},
 		{"News Gothic MT", "News Gothic MT Italic", "nwgthci.ttf"},
@@ -1994,17 +1997,17 @@ var fontRegistry = &registry{
 		{"Nimbus Mono L", "Nimbus Mono L", "n022004l.pfb"},
 		{"Nimbus Mono L", "Nimbus Mono L", "n022023l.pfb"},
 		{"Nimbus Mono L", "Nimbus Mono L", "n022024l.pfb"},
-		{"Nimbus Mono PS", "Nimbus Mono PS", "NimbusMonoPS-BoldItalic.t1"},
 		{"Nimbus Mono PS", "Nimbus Mono PS", "NimbusMonoPS-Bold.t1"},
-		{"Nimbus Mono PS", "Nimbus Mono PS", "NimbusMonoPS-Regular.t1"},
+		{"Nimbus Mono PS", "Nimbus Mono PS", "NimbusMonoPS-BoldItalic.t1"},
 		{"Nimbus Mono PS", "Nimbus Mono PS", "NimbusMonoPS-Italic.t1"},
+		{"Nimbus Mono PS", "Nimbus Mono PS", "NimbusMonoPS-Regular.t1"},
 		{"Nimbus Mono PS", "NimbusMonoPS-Bold", "NimbusMonoPS-Bold.otf"},
 		{"Nimbus Mono PS", "NimbusMonoPS-BoldItalic", "NimbusMonoPS-BoldItalic.otf"},
 		{"Nimbus Mono PS", "NimbusMonoPS-Italic", "NimbusMonoPS-Italic.otf"},
 		{"Nimbus Mono PS", "NimbusMonoPS-Regular", "NimbusMonoPS-Regular.otf"},
-		{"Nimbus Roman", "Nimbus Roman", "NimbusRoman-Italic.t1"},
 		{"Nimbus Roman", "Nimbus Roman", "NimbusRoman-Bold.t1"},
 		{"Nimbus Roman", "Nimbus Roman", "NimbusRoman-BoldItalic.t1"},
+		{"Nimbus Roman", "Nimbus Roman", "NimbusRoman-Italic.t1"},
 		{"Nimbus Roman", "Nimbus Roman", "NimbusRoman-Regular.t1"},
 		{"Nimbus Roman", "NimbusRoman-Bold", "NimbusRoman-Bold.otf"},
 		{"Nimbus Roman", "NimbusRoman-BoldItalic", "NimbusRoman-BoldItalic.otf"},
@@ -2012,29 +2015,29 @@ var fontRegistry = &registry{
 		{"Nimbus Roman", "NimbusRoman-Regular", "NimbusRoman-Regular.otf"},
 		{"Nimbus Roman No9 L", "Nimbus Roman No9 L", "n021003l.pfb"},
 		{"Nimbus Roman No9 L", "Nimbus Roman No9 L", "n021004l.pfb"},
-		{"Nimbus Roman No9 L", "Nimbus Roman No9 L", "n021024l.pfb"},
 		{"Nimbus Roman No9 L", "Nimbus Roman No9 L", "n021023l.pfb"},
-		{"Nimbus Sans", "Nimbus Sans", "NimbusSans-BoldItalic.t1"},
-		{"Nimbus Sans", "Nimbus Sans", "NimbusSans-Regular.t1"},
+		{"Nimbus Roman No9 L", "Nimbus Roman No9 L", "n021024l.pfb"},
 		{"Nimbus Sans", "Nimbus Sans", "NimbusSans-Bold.t1"},
+		{"Nimbus Sans", "Nimbus Sans", "NimbusSans-BoldItalic.t1"},
 		{"Nimbus Sans", "Nimbus Sans", "NimbusSans-Italic.t1"},
+		{"Nimbus Sans", "Nimbus Sans", "NimbusSans-Regular.t1"},
 		{"Nimbus Sans", "NimbusSans-Bold", "NimbusSans-Bold.otf"},
 		{"Nimbus Sans", "NimbusSans-BoldItalic", "NimbusSans-BoldItalic.otf"},
 		{"Nimbus Sans", "NimbusSans-Italic", "NimbusSans-Italic.otf"},
 		{"Nimbus Sans", "NimbusSans-Regular", "NimbusSans-Regular.otf"},
-		{"Nimbus Sans L", "Nimbus Sans L", "n019063l.pfb"},
-		{"Nimbus Sans L", "Nimbus Sans L", "n019064l.pfb"},
-		{"Nimbus Sans L", "Nimbus Sans L", "n019043l.pfb"},
-		{"Nimbus Sans L", "Nimbus Sans L", "n019044l.pfb"},
-		{"Nimbus Sans L", "Nimbus Sans L", "n019004l.pfb"},
 		{"Nimbus Sans L", "Nimbus Sans L", "n019003l.pfb"},
+		{"Nimbus Sans L", "Nimbus Sans L", "n019004l.pfb"},
 		{"Nimbus Sans L", "Nimbus Sans L", "n019023l.pfb"},
 		{"Nimbus Sans L", "Nimbus Sans L", "n019024l.pfb"},
+		{"Nimbus Sans L", "Nimbus Sans L", "n019043l.pfb"},
+		{"Nimbus Sans L", "Nimbus Sans L", "n019044l.pfb"},
+		{"Nimbus Sans L", "Nimbus Sans L", "n019063l.pfb"},
+		{"Nimbus Sans L", "Nimbus Sans L", "n019064l.pfb"},
+		{"Nimbus Sans Narrow", "Nimbus Sans Narrow", "NimbusSansNarrow-BdOblique.t1"},
 		{"Nimbus Sans Narrow", "Nimbus Sans Narrow", "NimbusSansNarrow-Bold.t1"},
+		{"Nimbus Sans Narrow", "Nimbus Sans Narrow", "NimbusSansNarrow-BoldOblique.t1"},
 		{"Nimbus Sans Narrow", "Nimbus Sans Narrow", "NimbusSansNarrow-Oblique.t1"},
 		{"Nimbus Sans Narrow", "Nimbus Sans Narrow", "NimbusSansNarrow-Regular.t1"},
-		{"Nimbus Sans Narrow", "Nimbus Sans Narrow", "NimbusSansNarrow-BdOblique.t1"},
-		{"Nimbus Sans Narrow", "Nimbus Sans Narrow", "NimbusSansNarrow-BoldOblique.t1"},
 		{"Nimbus Sans Narrow", "NimbusSansNarrow-Bold", "NimbusSansNarrow-Bold.otf"},
 		{"Nimbus Sans Narrow", "NimbusSansNarrow-BoldOblique", "NimbusSansNarrow-BoldOblique.otf"},
 		{"Nimbus Sans Narrow", "NimbusSansNarrow-Oblique", "NimbusSansNarrow-Oblique.otf"},
@@ -2214,10 +2217,10 @@ var fontRegistry = &registry{
 		{"Noto Sans Arabic UI", "Noto Sans Arabic UI SemiCondensed Thin", "NotoSansArabicUI-SemiCondensedThin.ttf"},
 		{"Noto Sans Arabic UI", "Noto Sans Arabic UI Thin", "NotoSansArabicUI-Thin.ttf"},
 		{"Noto Sans Armenian", "Noto Sans Armenian", "NotoSansArmenian-Regular.ttf"},
-		{"Noto Sans Armenian", "Noto Sans Armenian Black", "NotoSansArmenian.ttc"},
 		{"Noto Sans Armenian", "Noto Sans Armenian Black", "NotoSansArmenian-Black.ttf"},
-		{"Noto Sans Armenian", "Noto Sans Armenian Bold", "NotoSansArmenian.ttc"},
+		{"Noto Sans Armenian", "Noto Sans Armenian Black", "NotoSansArmenian.ttc"},
 		{"Noto Sans Armenian", "Noto Sans Armenian Bold", "NotoSansArmenian-Bold.ttf"},
+		{"Noto Sans Armenian", "Noto Sans Armenian Bold", "NotoSansArmenian.ttc"},
 		{"Noto Sans Armenian", "Noto Sans Armenian Condensed", "NotoSansArmenian-Condensed.ttf"},
 		{"Noto Sans Armenian", "Noto Sans Armenian Condensed Black", "NotoSansArmenian-CondensedBlack.ttf"},
 		{"Noto Sans Armenian", "Noto Sans Armenian Condensed Bold", "NotoSansArmenian-CondensedBold.ttf"},
@@ -2227,8 +2230,8 @@ var fontRegistry = &registry{
 		{"Noto Sans Armenian", "Noto Sans Armenian Condensed Medium", "NotoSansArmenian-CondensedMedium.ttf"},
 		{"Noto Sans Armenian", "Noto Sans Armenian Condensed SemiBold", "NotoSansArmenian-CondensedSemiBold.ttf"},
 		{"Noto Sans Armenian", "Noto Sans Armenian Condensed Thin", "NotoSansArmenian-CondensedThin.ttf"},
-		{"Noto Sans Armenian", "Noto Sans Armenian ExtraBold", "NotoSansArmenian.ttc"},
 		{"Noto Sans Armenian", "Noto Sans Armenian ExtraBold", "NotoSansArmenian-ExtraBold.ttf"},
+		{"Noto Sans Armenian", "Noto Sans Armenian ExtraBold", "NotoSansArmenian.ttc"},
 		{"Noto Sans Armenian", "Noto Sans Armenian ExtraCondensed", "NotoSansArmenian-ExtraCondensed.ttf"},
 		{"Noto Sans Armenian", "Noto Sans Armenian ExtraCondensed Black", "NotoSansArmenian-ExtraCondensedBlack.ttf"},
 		{"Noto Sans Armenian", "Noto Sans Armenian ExtraCondensed Bold", "NotoSansArmenian-ExtraCondensedBold.ttf"},
@@ -2238,16 +2241,16 @@ var fontRegistry = &registry{
 		{"Noto Sans Armenian", "Noto Sans Armenian ExtraCondensed Medium", "NotoSansArmenian-ExtraCondensedMedium.ttf"},
 		{"Noto Sans Armenian", "Noto Sans Armenian ExtraCondensed SemiBold", "NotoSansArmenian-ExtraCondensedSemiBold.ttf"},
 		{"Noto Sans Armenian", "Noto Sans Armenian ExtraCondensed Thin", "NotoSansArmenian-ExtraCondensedThin.ttf"},
-		{"Noto Sans Armenian", "Noto Sans Armenian ExtraLight", "NotoSansArmenian.ttc"},
 		{"Noto Sans Armenian", "Noto Sans Armenian ExtraLight", "NotoSansArmenian-ExtraLight.ttf"},
-		{"Noto Sans Armenian", "Noto Sans Armenian Light", "NotoSansArmenian.ttc"},
+		{"Noto Sans Armenian", "Noto Sans Armenian ExtraLight", "NotoSansArmenian.ttc"},
 		{"Noto Sans Armenian", "Noto Sans Armenian Light", "NotoSansArmenian-Light.ttf"},
-		{"Noto Sans Armenian", "Noto Sans Armenian Medium", "NotoSansArmenian.ttc"},
+		{"Noto Sans Armenian", "Noto Sans Armenian Light", "NotoSansArmenian.ttc"},
 		{"Noto Sans Armenian", "Noto Sans Armenian Medium", "NotoSansArmenian-Medium.ttf"},
-		{"Noto Sans Armenian", "Noto Sans Armenian Regular", "NotoSansArmenian.ttc"},
+		{"Noto Sans Armenian", "Noto Sans Armenian Medium", "NotoSansArmenian.ttc"},
 		{"Noto Sans Armenian", "Noto Sans Armenian Regular", "NotoSansArmenian-Regular.ttf"},
-		{"Noto Sans Armenian", "Noto Sans Armenian SemiBold", "NotoSansArmenian.ttc"},
+		{"Noto Sans Armenian", "Noto Sans Armenian Regular", "NotoSansArmenian.ttc"},
 		{"Noto Sans Armenian", "Noto Sans Armenian SemiBold", "NotoSansArmenian-SemiBold.ttf"},
+		{"Noto Sans Armenian", "Noto Sans Armenian SemiBold", "NotoSansArmenian.ttc"},
 		{"Noto Sans Armenian", "Noto Sans Armenian SemiCondensed", "NotoSansArmenian-SemiCondensed.ttf"},
 		{"Noto Sans Armenian", "Noto Sans Armenian SemiCondensed Black", "NotoSansArmenian-SemiCondensedBlack.ttf"},
 		{"Noto Sans Armenian", "Noto Sans Armenian SemiCondensed Bold", "NotoSansArmenian-SemiCondensedBold.ttf"},
@@ -2257,8 +2260,8 @@ var fontRegistry = &registry{
 		{"Noto Sans Armenian", "Noto Sans Armenian SemiCondensed Medium", "NotoSansArmenian-SemiCondensedMedium.ttf"},
 		{"Noto Sans Armenian", "Noto Sans Armenian SemiCondensed SemiBold", "NotoSansArmenian-SemiCondensedSemiBold.ttf"},
 		{"Noto Sans Armenian", "Noto Sans Armenian SemiCondensed Thin", "NotoSansArmenian-SemiCondensedThin.ttf"},
-		{"Noto Sans Armenian", "Noto Sans Armenian Thin", "NotoSansArmenian.ttc"},
 		{"Noto Sans Armenian", "Noto Sans Armenian Thin", "NotoSansArmenian-Thin.ttf"},
+		{"Noto Sans Armenian", "Noto Sans Armenian Thin", "NotoSansArmenian.ttc"},
 		{"Noto Sans Avestan", "Noto Sans Avestan", "NotoSansAvestan-Regular.ttf"},
 		{"Noto Sans Avestan", "Noto Sans Avestan Regular", "NotoSansAvestan-Regular.ttf"},
 		{"Noto Sans Balinese", "Noto Sans Balinese", "NotoSansBalinese-Regular.ttf"},
@@ -2670,10 +2673,10 @@ var fontRegistry = &registry{
 		{"Noto Sans Kaithi", "Noto Sans Kaithi", "NotoSansKaithi-Regular.ttf"},
 		{"Noto Sans Kaithi", "Noto Sans Kaithi Regular", "NotoSansKaithi-Regular.ttf"},
 		{"Noto Sans Kannada", "Noto Sans Kannada", "NotoSansKannada-Regular.ttf"},
-		{"Noto Sans Kannada", "Noto Sans Kannada Black", "NotoSansKannada.ttc"},
 		{"Noto Sans Kannada", "Noto Sans Kannada Black", "NotoSansKannada-Black.ttf"},
-		{"Noto Sans Kannada", "Noto Sans Kannada Bold", "NotoSansKannada.ttc"},
+		{"Noto Sans Kannada", "Noto Sans Kannada Black", "NotoSansKannada.ttc"},
 		{"Noto Sans Kannada", "Noto Sans Kannada Bold", "NotoSansKannada-Bold.ttf"},
+		{"Noto Sans Kannada", "Noto Sans Kannada Bold", "NotoSansKannada.ttc"},
 		{"Noto Sans Kannada", "Noto Sans Kannada Condensed", "NotoSansKannada-Condensed.ttf"},
 		{"Noto Sans Kannada", "Noto Sans Kannada Condensed Black", "NotoSansKannada-CondensedBlack.ttf"},
 		{"Noto Sans Kannada", "Noto Sans Kannada Condensed Bold", "NotoSansKannada-CondensedBold.ttf"},
@@ -2683,8 +2686,8 @@ var fontRegistry = &registry{
 		{"Noto Sans Kannada", "Noto Sans Kannada Condensed Medium", "NotoSansKannada-CondensedMedium.ttf"},
 		{"Noto Sans Kannada", "Noto Sans Kannada Condensed SemiBold", "NotoSansKannada-CondensedSemiBold.ttf"},
 		{"Noto Sans Kannada", "Noto Sans Kannada Condensed Thin", "NotoSansKannada-CondensedThin.ttf"},
-		{"Noto Sans Kannada", "Noto Sans Kannada ExtraBold", "NotoSansKannada.ttc"},
 		{"Noto Sans Kannada", "Noto Sans Kannada ExtraBold", "NotoSansKannada-ExtraBold.ttf"},
+		{"Noto Sans Kannada", "Noto Sans Kannada ExtraBold", "NotoSansKannada.ttc"},
 		{"Noto Sans Kannada", "Noto Sans Kannada ExtraCondensed", "NotoSansKannada-ExtraCondensed.ttf"},
 		{"Noto Sans Kannada", "Noto Sans Kannada ExtraCondensed Black", "NotoSansKannada-ExtraCondensedBlack.ttf"},
 		{"Noto Sans Kannada", "Noto Sans Kannada ExtraCondensed Bold", "NotoSansKannada-ExtraCondensedBold.ttf"},
@@ -2694,16 +2697,16 @@ var fontRegistry = &registry{
 		{"Noto Sans Kannada", "Noto Sans Kannada ExtraCondensed Medium", "NotoSansKannada-ExtraCondensedMedium.ttf"},
 		{"Noto Sans Kannada", "Noto Sans Kannada ExtraCondensed SemiBold", "NotoSansKannada-ExtraCondensedSemiBold.ttf"},
 		{"Noto Sans Kannada", "Noto Sans Kannada ExtraCondensed Thin", "NotoSansKannada-ExtraCondensedThin.ttf"},
-		{"Noto Sans Kannada", "Noto Sans Kannada ExtraLight", "NotoSansKannada.ttc"},
 		{"Noto Sans Kannada", "Noto Sans Kannada ExtraLight", "NotoSansKannada-ExtraLight.ttf"},
-		{"Noto Sans Kannada", "Noto Sans Kannada Light", "NotoSansKannada.ttc"},
+		{"Noto Sans Kannada", "Noto Sans Kannada ExtraLight", "NotoSansKannada.ttc"},
 		{"Noto Sans Kannada", "Noto Sans Kannada Light", "NotoSansKannada-Light.ttf"},
-		{"Noto Sans Kannada", "Noto Sans Kannada Medium", "NotoSansKannada.ttc"},
+		{"Noto Sans Kannada", "Noto Sans Kannada Light", "NotoSansKannada.ttc"},
 		{"Noto Sans Kannada", "Noto Sans Kannada Medium", "NotoSansKannada-Medium.ttf"},
-		{"Noto Sans Kannada", "Noto Sans Kannada Regular", "NotoSansKannada.ttc"},
+		{"Noto Sans Kannada", "Noto Sans Kannada Medium", "NotoSansKannada.ttc"},
 		{"Noto Sans Kannada", "Noto Sans Kannada Regular", "NotoSansKannada-Regular.ttf"},
-		{"Noto Sans Kannada", "Noto Sans Kannada SemiBold", "NotoSansKannada.ttc"},
+		{"Noto Sans Kannada", "Noto Sans Kannada Regular", "NotoSansKannada.ttc"},
 		{"Noto Sans Kannada", "Noto Sans Kannada SemiBold", "NotoSansKannada-SemiBold.ttf"},
+		{"Noto Sans Kannada", "Noto Sans Kannada SemiBold", "NotoSansKannada.ttc"},
 		{"Noto Sans Kannada", "Noto Sans Kannada SemiCondensed", "NotoSansKannada-SemiCondensed.ttf"},
 		{"Noto Sans Kannada", "Noto Sans Kannada SemiCondensed Black", "NotoSansKannada-SemiCondensedBlack.ttf"},
 		{"Noto Sans Kannada", "Noto Sans Kannada SemiCondensed Bold", "NotoSansKannada-SemiCondensedBold.ttf"},
@@ -2713,8 +2716,8 @@ var fontRegistry = &registry{
 		{"Noto Sans Kannada", "Noto Sans Kannada SemiCondensed Medium", "NotoSansKannada-SemiCondensedMedium.ttf"},
 		{"Noto Sans Kannada", "Noto Sans Kannada SemiCondensed SemiBold", "NotoSansKannada-SemiCondensedSemiBold.ttf"},
 		{"Noto Sans Kannada", "Noto Sans Kannada SemiCondensed Thin", "NotoSansKannada-SemiCondensedThin.ttf"},
-		{"Noto Sans Kannada", "Noto Sans Kannada Thin", "NotoSansKannada.ttc"},
 		{"Noto Sans Kannada", "Noto Sans Kannada Thin", "NotoSansKannada-Thin.ttf"},
+		{"Noto Sans Kannada", "Noto Sans Kannada Thin", "NotoSansKannada.ttc"},
 		{"Noto Sans Kannada UI", "Noto Sans Kannada UI", "NotoSansKannadaUI-Regular.ttf"},
 		{"Noto Sans Kannada UI", "Noto Sans Kannada UI Black", "NotoSansKannadaUI-Black.ttf"},
 		{"Noto Sans Kannada UI", "Noto Sans Kannada UI Bold", "NotoSansKannadaUI-Bold.ttf"},
@@ -3060,10 +3063,10 @@ var fontRegistry = &registry{
 		{"Noto Sans Mro", "Noto Sans Mro Regular", "NotoSansMro-Regular.ttf"},
 		{"Noto Sans Multani", "Noto Sans Multani Regular", "NotoSansMultani-Regular.ttf"},
 		{"Noto Sans Myanmar", "Noto Sans Myanmar", "NotoSansMyanmar-Regular.ttf"},
-		{"Noto Sans Myanmar", "Noto Sans Myanmar Black", "NotoSansMyanmar.ttc"},
 		{"Noto Sans Myanmar", "Noto Sans Myanmar Black", "NotoSansMyanmar-Black.ttf"},
-		{"Noto Sans Myanmar", "Noto Sans Myanmar Bold", "NotoSansMyanmar.ttc"},
+		{"Noto Sans Myanmar", "Noto Sans Myanmar Black", "NotoSansMyanmar.ttc"},
 		{"Noto Sans Myanmar", "Noto Sans Myanmar Bold", "NotoSansMyanmar-Bold.ttf"},
+		{"Noto Sans Myanmar", "Noto Sans Myanmar Bold", "NotoSansMyanmar.ttc"},
 		{"Noto Sans Myanmar", "Noto Sans Myanmar Condensed", "NotoSansMyanmar-Condensed.ttf"},
 		{"Noto Sans Myanmar", "Noto Sans Myanmar Condensed Black", "NotoSansMyanmar-CondensedBlack.ttf"},
 		{"Noto Sans Myanmar", "Noto Sans Myanmar Condensed Bold", "NotoSansMyanmar-CondensedBold.ttf"},
@@ -3073,8 +3076,8 @@ var fontRegistry = &registry{
 		{"Noto Sans Myanmar", "Noto Sans Myanmar Condensed Medium", "NotoSansMyanmar-CondensedMedium.ttf"},
 		{"Noto Sans Myanmar", "Noto Sans Myanmar Condensed SemiBold", "NotoSansMyanmar-CondensedSemiBold.ttf"},
 		{"Noto Sans Myanmar", "Noto Sans Myanmar Condensed Thin", "NotoSansMyanmar-CondensedThin.ttf"},
-		{"Noto Sans Myanmar", "Noto Sans Myanmar ExtraBold", "NotoSansMyanmar.ttc"},
 		{"Noto Sans Myanmar", "Noto Sans Myanmar ExtraBold", "NotoSansMyanmar-ExtraBold.ttf"},
+		{"Noto Sans Myanmar", "Noto Sans Myanmar ExtraBold", "NotoSansMyanmar.ttc"},
 		{"Noto Sans Myanmar", "Noto Sans Myanmar ExtraCondensed", "NotoSansMyanmar-ExtraCondensed.ttf"},
 		{"Noto Sans Myanmar", "Noto Sans Myanmar ExtraCondensed Black", "NotoSansMyanmar-ExtraCondensedBlack.ttf"},
 		{"Noto Sans Myanmar", "Noto Sans Myanmar ExtraCondensed Bold", "NotoSansMyanmar-ExtraCondensedBold.ttf"},
@@ -3084,16 +3087,16 @@ var fontRegistry = &registry{
 		{"Noto Sans Myanmar", "Noto Sans Myanmar ExtraCondensed Medium", "NotoSansMyanmar-ExtraCondensedMedium.ttf"},
 		{"Noto Sans Myanmar", "Noto Sans Myanmar ExtraCondensed SemiBold", "NotoSansMyanmar-ExtraCondensedSemiBold.ttf"},
 		{"Noto Sans Myanmar", "Noto Sans Myanmar ExtraCondensed Thin", "NotoSansMyanmar-ExtraCondensedThin.ttf"},
-		{"Noto Sans Myanmar", "Noto Sans Myanmar ExtraLight", "NotoSansMyanmar.ttc"},
 		{"Noto Sans Myanmar", "Noto Sans Myanmar ExtraLight", "NotoSansMyanmar-ExtraLight.ttf"},
-		{"Noto Sans Myanmar", "Noto Sans Myanmar Light", "NotoSansMyanmar.ttc"},
+		{"Noto Sans Myanmar", "Noto Sans Myanmar ExtraLight", "NotoSansMyanmar.ttc"},
 		{"Noto Sans Myanmar", "Noto Sans Myanmar Light", "NotoSansMyanmar-Light.ttf"},
-		{"Noto Sans Myanmar", "Noto Sans Myanmar Medium", "NotoSansMyanmar.ttc"},
+		{"Noto Sans Myanmar", "Noto Sans Myanmar Light", "NotoSansMyanmar.ttc"},
 		{"Noto Sans Myanmar", "Noto Sans Myanmar Medium", "NotoSansMyanmar-Medium.ttf"},
-		{"Noto Sans Myanmar", "Noto Sans Myanmar Regular", "NotoSansMyanmar.ttc"},
+		{"Noto Sans Myanmar", "Noto Sans Myanmar Medium", "NotoSansMyanmar.ttc"},
 		{"Noto Sans Myanmar", "Noto Sans Myanmar Regular", "NotoSansMyanmar-Regular.ttf"},
-		{"Noto Sans Myanmar", "Noto Sans Myanmar SemiBold", "NotoSansMyanmar.ttc"},
+		{"Noto Sans Myanmar", "Noto Sans Myanmar Regular", "NotoSansMyanmar.ttc"},
 		{"Noto Sans Myanmar", "Noto Sans Myanmar SemiBold", "NotoSansMyanmar-SemiBold.ttf"},
+		{"Noto Sans Myanmar", "Noto Sans Myanmar SemiBold", "NotoSansMyanmar.ttc"},
 		{"Noto Sans Myanmar", "Noto Sans Myanmar SemiCondensed", "NotoSansMyanmar-SemiCondensed.ttf"},
 		{"Noto Sans Myanmar", "Noto Sans Myanmar SemiCondensed Black", "NotoSansMyanmar-SemiCondensedBlack.ttf"},
 		{"Noto Sans Myanmar", "Noto Sans Myanmar SemiCondensed Bold", "NotoSansMyanmar-SemiCondensedBold.ttf"},
@@ -3103,8 +3106,8 @@ var fontRegistry = &registry{
 		{"Noto Sans Myanmar", "Noto Sans Myanmar SemiCondensed Medium", "NotoSansMyanmar-SemiCondensedMedium.ttf"},
 		{"Noto Sans Myanmar", "Noto Sans Myanmar SemiCondensed SemiBold", "NotoSansMyanmar-SemiCondensedSemiBold.ttf"},
 		{"Noto Sans Myanmar", "Noto Sans Myanmar SemiCondensed Thin", "NotoSansMyanmar-SemiCondensedThin.ttf"},
-		{"Noto Sans Myanmar", "Noto Sans Myanmar Thin", "NotoSansMyanmar.ttc"},
 		{"Noto Sans Myanmar", "Noto Sans Myanmar Thin", "NotoSansMyanmar-Thin.ttf"},
+		{"Noto Sans Myanmar", "Noto Sans Myanmar Thin", "NotoSansMyanmar.ttc"},
 		{"Noto Sans Myanmar UI", "Noto Sans Myanmar UI", "NotoSansMyanmarUI-Regular.ttf"},
 		{"Noto Sans Myanmar UI", "Noto Sans Myanmar UI Black", "NotoSansMyanmarUI-Black.ttf"},
 		{"Noto Sans Myanmar UI", "Noto Sans Myanmar UI Bold", "NotoSansMyanmarUI-Bold.ttf"},
@@ -3163,10 +3166,10 @@ var fontRegistry = &registry{
 		{"Noto Sans Old South Arabian", "Noto Sans Old South Arabian Regular", "NotoSansOldSouthArabian-Regular.ttf"},
 		{"Noto Sans Old Turkic", "Noto Sans Old Turkic", "NotoSansOldTurkic-Regular.ttf"},
 		{"Noto Sans Old Turkic", "Noto Sans Old Turkic Regular", "NotoSansOldTurkic-Regular.ttf"},
-		{"Noto Sans Oriya", "Noto Sans Oriya", "NotoSansOriya.ttc"},
 		{"Noto Sans Oriya", "Noto Sans Oriya", "NotoSansOriya-Regular.ttf"},
-		{"Noto Sans Oriya", "Noto Sans Oriya Bold", "NotoSansOriya.ttc"},
+		{"Noto Sans Oriya", "Noto Sans Oriya", "NotoSansOriya.ttc"},
 		{"Noto Sans Oriya", "Noto Sans Oriya Bold", "NotoSansOriya-Bold.ttf"},
+		{"Noto Sans Oriya", "Noto Sans Oriya Bold", "NotoSansOriya.ttc"},
 		{"Noto Sans Oriya UI", "Noto Sans Oriya UI", "NotoSansOriyaUI-Regular.ttf"},
 		{"Noto Sans Oriya UI", "Noto Sans Oriya UI Bold", "NotoSansOriyaUI-Bold.ttf"},
 		{"Noto Sans Osage", "Noto Sans Osage Regular", "NotoSansOsage-Regular.ttf"},
@@ -3284,8 +3287,8 @@ var fontRegistry = &registry{
 		{"Noto Sans Symbols", "Noto Sans Symbols Thin", "NotoSansSymbols-Thin.ttf"},
 		{"Noto Sans Symbols2", "Noto Sans Symbols2 Regular", "NotoSansSymbols2-Regular.ttf"},
 		{"Noto Sans Syriac", "Noto Sans Syriac Black", "NotoSansSyriac-Black.ttf"},
-		{"Noto Sans Syriac", "Noto Sans Syriac Regular", "NotoSansSyriacEastern-Regular.ttf"},
 		{"Noto Sans Syriac", "Noto Sans Syriac Regular", "NotoSansSyriac-Regular.ttf"},
+		{"Noto Sans Syriac", "Noto Sans Syriac Regular", "NotoSansSyriacEastern-Regular.ttf"},
 		{"Noto Sans Syriac", "Noto Sans Syriac Thin", "NotoSansSyriac-Thin.ttf"},
 		{"Noto Sans Syriac Eastern", "Noto Sans Syriac Eastern", "NotoSansSyriacEastern-Regular.ttf"},
 		{"Noto Sans Syriac Estrangela", "Noto Sans Syriac Estrangela", "NotoSansSyriacEstrangela-Regular.ttf"},
@@ -3902,10 +3905,10 @@ var fontRegistry = &registry{
 		{"Noto Serif Lao", "Noto Serif Lao Thin", "NotoSerifLao-Thin.ttf"},
 		{"Noto Serif Malayalam", "Noto Serif Malayalam", "NotoSerifMalayalam-Regular.ttf"},
 		{"Noto Serif Malayalam", "Noto Serif Malayalam Bold", "NotoSerifMalayalam-Bold.ttf"},
-		{"Noto Serif Myanmar", "Noto Serif Myanmar Black", "NotoSerifMyanmar.ttc"},
 		{"Noto Serif Myanmar", "Noto Serif Myanmar Black", "NotoSerifMyanmar-Black.ttf"},
-		{"Noto Serif Myanmar", "Noto Serif Myanmar Bold", "NotoSerifMyanmar.ttc"},
+		{"Noto Serif Myanmar", "Noto Serif Myanmar Black", "NotoSerifMyanmar.ttc"},
 		{"Noto Serif Myanmar", "Noto Serif Myanmar Bold", "NotoSerifMyanmar-Bold.ttf"},
+		{"Noto Serif Myanmar", "Noto Serif Myanmar Bold", "NotoSerifMyanmar.ttc"},
 		{"Noto Serif Myanmar", "Noto Serif Myanmar Condensed", "NotoSerifMyanmar-Condensed.ttf"},
 		{"Noto Serif Myanmar", "Noto Serif Myanmar Condensed Black", "NotoSerifMyanmar-CondensedBlack.ttf"},
 		{"Noto Serif Myanmar", "Noto Serif Myanmar Condensed Bold", "NotoSerifMyanmar-CondensedBold.ttf"},
@@ -3915,8 +3918,8 @@ var fontRegistry = &registry{
 		{"Noto Serif Myanmar", "Noto Serif Myanmar Condensed Medium", "NotoSerifMyanmar-CondensedMedium.ttf"},
 		{"Noto Serif Myanmar", "Noto Serif Myanmar Condensed SemiBold", "NotoSerifMyanmar-CondensedSemiBold.ttf"},
 		{"Noto Serif Myanmar", "Noto Serif Myanmar Condensed Thin", "NotoSerifMyanmar-CondensedThin.ttf"},
-		{"Noto Serif Myanmar", "Noto Serif Myanmar ExtraBold", "NotoSerifMyanmar.ttc"},
 		{"Noto Serif Myanmar", "Noto Serif Myanmar ExtraBold", "NotoSerifMyanmar-ExtraBold.ttf"},
+		{"Noto Serif Myanmar", "Noto Serif Myanmar ExtraBold", "NotoSerifMyanmar.ttc"},
 		{"Noto Serif Myanmar", "Noto Serif Myanmar ExtraCondensed", "NotoSerifMyanmar-ExtraCondensed.ttf"},
 		{"Noto Serif Myanmar", "Noto Serif Myanmar ExtraCondensed Black", "NotoSerifMyanmar-ExtraCondensedBlack.ttf"},
 		{"Noto Serif Myanmar", "Noto Serif Myanmar ExtraCondensed Bold", "NotoSerifMyanmar-ExtraCondensedBold.ttf"},
@@ -3926,16 +3929,16 @@ var fontRegistry = &registry{
 		{"Noto Serif Myanmar", "Noto Serif Myanmar ExtraCondensed Medium", "NotoSerifMyanmar-ExtraCondensedMedium.ttf"},
 		{"Noto Serif Myanmar", "Noto Serif Myanmar ExtraCondensed SemiBold", "NotoSerifMyanmar-ExtraCondensedSemiBold.ttf"},
 		{"Noto Serif Myanmar", "Noto Serif Myanmar ExtraCondensed Thin", "NotoSerifMyanmar-ExtraCondensedThin.ttf"},
-		{"Noto Serif Myanmar", "Noto Serif Myanmar ExtraLight", "NotoSerifMyanmar.ttc"},
 		{"Noto Serif Myanmar", "Noto Serif Myanmar ExtraLight", "NotoSerifMyanmar-ExtraLight.ttf"},
-		{"Noto Serif Myanmar", "Noto Serif Myanmar Light", "NotoSerifMyanmar.ttc"},
+		{"Noto Serif Myanmar", "Noto Serif Myanmar ExtraLight", "NotoSerifMyanmar.ttc"},
 		{"Noto Serif Myanmar", "Noto Serif Myanmar Light", "NotoSerifMyanmar-Light.ttf"},
-		{"Noto Serif Myanmar", "Noto Serif Myanmar Medium", "NotoSerifMyanmar.ttc"},
+		{"Noto Serif Myanmar", "Noto Serif Myanmar Light", "NotoSerifMyanmar.ttc"},
 		{"Noto Serif Myanmar", "Noto Serif Myanmar Medium", "NotoSerifMyanmar-Medium.ttf"},
-		{"Noto Serif Myanmar", "Noto Serif Myanmar Regular", "NotoSerifMyanmar.ttc"},
+		{"Noto Serif Myanmar", "Noto Serif Myanmar Medium", "NotoSerifMyanmar.ttc"},
 		{"Noto Serif Myanmar", "Noto Serif Myanmar Regular", "NotoSerifMyanmar-Regular.ttf"},
-		{"Noto Serif Myanmar", "Noto Serif Myanmar SemiBold", "NotoSerifMyanmar.ttc"},
+		{"Noto Serif Myanmar", "Noto Serif Myanmar Regular", "NotoSerifMyanmar.ttc"},
 		{"Noto Serif Myanmar", "Noto Serif Myanmar SemiBold", "NotoSerifMyanmar-SemiBold.ttf"},
+		{"Noto Serif Myanmar", "Noto Serif Myanmar SemiBold", "NotoSerifMyanmar.ttc"},
 		{"Noto Serif Myanmar", "Noto Serif Myanmar SemiCondensed", "NotoSerifMyanmar-SemiCondensed.ttf"},
 		{"Noto Serif Myanmar", "Noto Serif Myanmar SemiCondensed Black", "NotoSerifMyanmar-SemiCondensedBlack.ttf"},
 		{"Noto Serif Myanmar", "Noto Serif Myanmar SemiCondensed Bold", "NotoSerifMyanmar-SemiCondensedBold.ttf"},
@@ -3945,8 +3948,8 @@ var fontRegistry = &registry{
 		{"Noto Serif Myanmar", "Noto Serif Myanmar SemiCondensed Medium", "NotoSerifMyanmar-SemiCondensedMedium.ttf"},
 		{"Noto Serif Myanmar", "Noto Serif Myanmar SemiCondensed SemiBold", "NotoSerifMyanmar-SemiCondensedSemiBold.ttf"},
 		{"Noto Serif Myanmar", "Noto Serif Myanmar SemiCondensed Thin", "NotoSerifMyanmar-SemiCondensedThin.ttf"},
-		{"Noto Serif Myanmar", "Noto Serif Myanmar Thin", "NotoSerifMyanmar.ttc"},
 		{"Noto Serif Myanmar", "Noto Serif Myanmar Thin", "NotoSerifMyanmar-Thin.ttf"},
+		{"Noto Serif Myanmar", "Noto Serif Myanmar Thin", "NotoSerifMyanmar.ttc"},
 		{"Noto Serif Sinhala", "Noto Serif Sinhala Black", "NotoSerifSinhala-Black.ttf"},
 		{"Noto Serif Sinhala", "Noto Serif Sinhala Bold", "NotoSerifSinhala-Bold.ttf"},
 		{"Noto Serif Sinhala", "Noto Serif Sinhala Condensed", "NotoSerifSinhala-Condensed.ttf"},
@@ -4131,10 +4134,10 @@ var fontRegistry = &registry{
 		{"Ouhod", "Ouhod Bold", "ae_Ouhod-Bold.ttf"},
 		{"Overpass", "Overpass-Bold", "Overpass_Bold.ttf"},
 		{"Overpass", "Overpass-Reg", "Overpass_Regular.ttf"},
+		{"P052", "P052", "P052-Bold.t1"},
 		{"P052", "P052", "P052-BoldItalic.t1"},
 		{"P052", "P052", "P052-Italic.t1"},
 		{"P052", "P052", "P052-Roman.t1"},
-		{"P052", "P052", "P052-Bold.t1"},
 		{"P052", "P052-Bold", "P052-Bold.otf"},
 		{"P052", "P052-BoldItalic", "P052-BoldItalic.otf"},
 		{"P052", "P052-Italic", "P052-Italic.otf"},
@@ -4151,20 +4154,20 @@ var fontRegistry = &registry{
 		{"PSL Ornanong Pro", "PSL Ornanong Pro Light Italic", "Ornanong.ttc"},
 		{"PT Mono", "PT Mono", "PTMono.ttc"},
 		{"PT Mono", "PT Mono Bold", "PTMono.ttc"},
-		{"PT Sans", "PT Sans", "PTSans.ttc"},
 		{"PT Sans", "PT Sans", "PTS55F.ttf"},
-		{"PT Sans", "PT Sans Bold", "PTSans.ttc"},
+		{"PT Sans", "PT Sans", "PTSans.ttc"},
 		{"PT Sans", "PT Sans Bold", "PTS75F.ttf"},
-		{"PT Sans", "PT Sans Bold Italic", "PTSans.ttc"},
+		{"PT Sans", "PT Sans Bold", "PTSans.ttc"},
 		{"PT Sans", "PT Sans Bold Italic", "PTS76F.ttf"},
-		{"PT Sans", "PT Sans Italic", "PTSans.ttc"},
+		{"PT Sans", "PT Sans Bold Italic", "PTSans.ttc"},
 		{"PT Sans", "PT Sans Italic", "PTS56F.ttf"},
+		{"PT Sans", "PT Sans Italic", "PTSans.ttc"},
 		{"PT Sans Caption", "PT Sans Caption", "PTSans.ttc"},
 		{"PT Sans Caption", "PT Sans Caption Bold", "PTSans.ttc"},
-		{"PT Sans Narrow", "PT Sans Narrow", "PTSans.ttc"},
 		{"PT Sans Narrow", "PT Sans Narrow", "PTN57F.ttf"},
-		{"PT Sans Narrow", "PT Sans Narrow Bold", "PTSans.ttc"},
+		{"PT Sans Narrow", "PT Sans Narrow", "PTSans.ttc"},
 		{"PT Sans Narrow", "PT Sans Narrow Bold", "PTN77F.ttf"},
+		{"PT Sans Narrow", "PT Sans Narrow Bold", "PTSans.ttc"},
 		{"PT Serif", "PT Serif", "PTSerif.ttc"},
 		{"PT Serif", "PT Serif Bold", "PTSerif.ttc"},
 		{"PT Serif", "PT Serif Bold Italic", "PTSerif.ttc"},
@@ -4219,8 +4222,8 @@ var fontRegistry = &registry{
 		{"PingFang TC", "PingFang TC Semibold", "PingFang.ttc"},
 		{"PingFang TC", "PingFang TC Thin", "PingFang.ttc"},
 		{"PingFang TC", "PingFang TC Ultralight", "PingFang.ttc"},
-		{"Plantagenet Cherokee", "Plantagenet Cherokee", "plantc.ttf"},
 		{"Plantagenet Cherokee", "Plantagenet Cherokee", "PlantagenetCherokee.ttf"},
+		{"Plantagenet Cherokee", "Plantagenet Cherokee", "plantc.ttf"},
 		{"Pothana2000", "Pothana2000", "Pothana2000.ttf"},
 		{"Purisa", "Purisa", "Purisa.ttf"},
 		{"Purisa", "Purisa Bold", "Purisa-Bold.ttf"},
@@ -4230,16 +4233,16 @@ var fontRegistry = &registry{
 		{"Quicksand", "Quicksand Regular", "Quicksand-Regular.ttf"},
 		{"Quicksand Light", "Quicksand Light", "Quicksand-Light.ttf"},
 		{"Quicksand Medium", "Quicksand Medium", "Quicksand-Medium.ttf"},
-		{"Raanana", "Raanana", "Raanana.ttf"},
 		{"Raanana", "Raanana", "Raanana.ttc"},
-		{"Raanana", "Raanana Bold", "RaananaBold.ttf"},
+		{"Raanana", "Raanana", "Raanana.ttf"},
 		{"Raanana", "Raanana Bold", "Raanana.ttc"},
+		{"Raanana", "Raanana Bold", "RaananaBold.ttf"},
 		{"Raavi", "Raavi", "raavi.ttf"},
 		{"Raavi", "Raavi Bold", "raavib.ttf"},
-		{"Rachana", "Rachana", "Rachana-Regular.ttf"},
 		{"Rachana", "Rachana", "Rachana-Bold.ttf"},
-		{"Rachana", "Rachana", "Rachana_04.ttf"},
+		{"Rachana", "Rachana", "Rachana-Regular.ttf"},
 		{"Rachana", "Rachana", "Rachana.ttf"},
+		{"Rachana", "Rachana", "Rachana_04.ttf"},
 		{"RaghuMalayalam", "RaghuMalayalam", "RaghuMalayalamSans.ttf"},
 		{"RaghuMalayalam", "RaghuMalayalam Regular", "RaghuMalayalamSans-Regular.ttf"},
 		{"RaghuMalayalamSans", "RaghuMalayalamSans Regular", "RaghuMalayalamSans-Regular.ttf"},
@@ -4271,13 +4274,13 @@ var fontRegistry = &registry{
 		{"Rockwell Nova Extra Bold", "Rockwell Nova Extra Bold Italic", "rockwellnova-extrabolditalic.ttf"},
 		{"Rod", "Rod", "rod.ttf"},
 		{"Rod", "Rod Transparent", "rodtr.ttf"},
-		{"STFangSong", "STFangSong", "华文仿宋.ttf"},
 		{"STFangSong", "STFangSong", "STFANGSO.ttf"},
+		{"STFangSong", "STFangSong", "华文仿宋.ttf"},
 		{"STHeiti", "STHeiti Light", "华文细黑.ttf"},
-		{"STHeiti", "STHeiti Regular", "华文黑体.ttf"},
 		{"STHeiti", "STHeiti Regular", "STHEITI.ttf"},
-		{"STHeiti", "STXihei", "华文细黑.ttf"},
+		{"STHeiti", "STHeiti Regular", "华文黑体.ttf"},
 		{"STHeiti", "STXihei", "STXIHEI.ttf"},
+		{"STHeiti", "STXihei", "华文细黑.ttf"},
 		{"STIX", "STIX-Bold", "STIX-Bold.otf"},
 		{"STIX", "STIX-BoldItalic", "STIX-BoldItalic.otf"},
 		{"STIX", "STIX-Italic", "STIX-Italic.otf"},
@@ -4292,12 +4295,12 @@ var fontRegistry = &registry{
 		{"STIXGeneral", "STIXGeneral Bold", "STIXGeneralBol.otf"},
 		{"STIXGeneral", "STIXGeneral Bold Italic", "STIXGeneralBolIta.otf"},
 		{"STIXGeneral", "STIXGeneral Italic", "STIXGeneralItalic.otf"},
-		{"STIXGeneral", "STIXGeneral-Bold", "STIXGeneralBol.otf"},
 		{"STIXGeneral", "STIXGeneral-Bold", "STIXGeneral-Bold.otf"},
-		{"STIXGeneral", "STIXGeneral-BoldItalic", "STIXGeneralBolIta.otf"},
+		{"STIXGeneral", "STIXGeneral-Bold", "STIXGeneralBol.otf"},
 		{"STIXGeneral", "STIXGeneral-BoldItalic", "STIXGeneral-BoldItalic.otf"},
-		{"STIXGeneral", "STIXGeneral-Italic", "STIXGeneralItalic.otf"},
+		{"STIXGeneral", "STIXGeneral-BoldItalic", "STIXGeneralBolIta.otf"},
 		{"STIXGeneral", "STIXGeneral-Italic", "STIXGeneral-Italic.otf"},
+		{"STIXGeneral", "STIXGeneral-Italic", "STIXGeneralItalic.otf"},
 		{"STIXGeneral", "STIXGeneral-Regular", "STIXGeneral-Regular.otf"},
 		{"STIXIntegralsD", "STIXIntegralsD-Bold", "STIXIntDBol.otf"},
 		{"STIXIntegralsD", "STIXIntegralsD-Bold", "STIXIntegralsD-Bold.otf"},
@@ -4349,12 +4352,12 @@ var fontRegistry = &registry{
 		{"STIXVariants", "STIXVariants-Bold", "STIXVariants-Bold.otf"},
 		{"STIXVariants", "STIXVariants-Regular", "STIXVar.otf"},
 		{"STIXVariants", "STIXVariants-Regular", "STIXVariants-Regular.otf"},
+		{"STKaiti", "STKaiti", "Kaiti.ttc"},
 		{"STKaiti", "STKaiti", "华文楷体.ttf"},
 		{"STKaiti", "STKaiti", "楷体.ttc"},
-		{"STKaiti", "STKaiti", "Kaiti.ttc"},
+		{"STSong", "STSong", "Songti.ttc"},
 		{"STSong", "STSong", "华文宋体.ttf"},
 		{"STSong", "STSong", "宋体.ttc"},
-		{"STSong", "STSong", "Songti.ttc"},
 		{"Saab", "Saab", "Saab.ttf"},
 		{"Sahadeva", "Sahadeva", "sahadeva.ttf"},
 		{"Sakkal Majalla", "Sakkal Majalla", "majalla.ttf"},
@@ -4421,8 +4424,8 @@ var fontRegistry = &registry{
 		{"Shree Devanagari 714", "Shree Devanagari 714 Italic", "Shree714.ttc"},
 		{"Shruti", "Shruti", "shruti.ttf"},
 		{"Shruti", "Shruti Bold", "shrutib.ttf"},
-		{"SignPainter", "SignPainter-HouseScript", "SignPainter.ttc"},
 		{"SignPainter", "SignPainter-HouseScript", "SignPainter.otf"},
+		{"SignPainter", "SignPainter-HouseScript", "SignPainter.ttc"},
 		{"SignPainter", "SignPainter-HouseScript Semibold", "SignPainter.ttc"},
 		{"Silom", "Silom", "Silom.ttf"},
 		{"SimHei", "SimHei", "simhei.ttf"},
@@ -4562,20 +4565,20 @@ var fontRegistry = &registry{
 		{"Superclarendon", "Superclarendon Regular", "SuperClarendon.ttc"},
 		{"Suruma", "Suruma", "Suruma.ttf"},
 		{"Sylfaen", "Sylfaen", "sylfaen.ttf"},
-		{"Symbol", "Symbol", "symbol.ttf"},
 		{"Symbol", "Symbol", "Symbol.dfont"},
-		{"Symbol", "Symbol", "Symbol.ttf"},
 		{"Symbol", "Symbol", "Symbol.pfb"},
+		{"Symbol", "Symbol", "Symbol.ttf"},
+		{"Symbol", "Symbol", "symbol.ttf"},
 		{"Symbola", "Symbola", "Symbola.ttf"},
-		{"Symbola", "Symbola", "Symbola_hint.ttf"},
 		{"Symbola", "Symbola", "Symbola717.ttf"},
-		{"Tahoma", "Tahoma", "tahoma.ttf"},
+		{"Symbola", "Symbola", "Symbola_hint.ttf"},
 		{"Tahoma", "Tahoma", "Tahoma.ttf"},
-		{"Tahoma", "Tahoma Bold", "tahomabd.ttf"},
+		{"Tahoma", "Tahoma", "tahoma.ttf"},
 		{"Tahoma", "Tahoma Bold", "Tahoma Bold.ttf"},
+		{"Tahoma", "Tahoma Bold", "tahomabd.ttf"},
 		{"TakaoPGothic", "TakaoPGothic", "TakaoPGothic.ttf"},
-		{"TakaoPGothic", "TakaoPGothic", "ttf-japanese-gothic.ttf"},
 		{"TakaoPGothic", "TakaoPGothic", "fonts-japanese-gothic.ttf"},
+		{"TakaoPGothic", "TakaoPGothic", "ttf-japanese-gothic.ttf"},
 		{"Tamil MN", "Tamil MN", "Tamil MN.ttc"},
 		{"Tamil MN", "Tamil MN Bold", "Tamil MN.ttc"},
 		{"Tamil Sangam MN", "Tamil Sangam MN", "Tamil Sangam MN.ttc"},
@@ -4583,8 +4586,8 @@ var fontRegistry = &registry{
 		{"Tamil Sangam MN", "Tamil Sangam MN Bold", "Tamil Sangam MN.ttc"},
 		{"Tarablus", "Tarablus", "ae_Tarablus.ttf"},
 		{"Teams", "Teams", "teams.pfb"},
-		{"Teams", "Teams", "teamsbi.pfb"},
 		{"Teams", "Teams", "teamsb.pfb"},
+		{"Teams", "Teams", "teamsbi.pfb"},
 		{"Teams", "Teams", "teamsi.pfb"},
 		{"Telugu MN", "Telugu MN", "Telugu MN.ttc"},
 		{"Telugu MN", "Telugu MN Bold", "Telugu MN.ttc"},
@@ -4593,13 +4596,13 @@ var fontRegistry = &registry{
 		{"Telugu Sangam MN", "Telugu Sangam MN Bold", "Telugu Sangam MN.ttc"},
 		{"Thabit", "Thabit", "Thabit-Oblique.ttf"},
 		{"Thabit", "Thabit", "Thabit.ttf"},
-		{"Thabit", "Thabit Bold", "Thabit-Bold.ttf"},
 		{"Thabit", "Thabit Bold", "Thabit-Bold-Oblique.ttf"},
+		{"Thabit", "Thabit Bold", "Thabit-Bold.ttf"},
 		{"Tholoth", "Tholoth", "ae_Tholoth.ttf"},
-		{"Thonburi", "Thonburi", "Thonburi.ttf"},
 		{"Thonburi", "Thonburi", "Thonburi.ttc"},
-		{"Thonburi", "Thonburi Bold", "ThonburiBold.ttf"},
+		{"Thonburi", "Thonburi", "Thonburi.ttf"},
 		{"Thonburi", "Thonburi Bold", "Thonburi.ttc"},
+		{"Thonburi", "Thonburi Bold", "ThonburiBold.ttf"},
 		{"Thonburi", "Thonburi Light", "Thonburi.ttc"},
 		{"Tibetan Machine Uni", "Tibetan_Machine_Uni", "TibMachUni-1.901b.ttf"},
 		{"Tibetan Machine Uni", "Tibetan_Machine_Uni", "TibetanMachineUni.ttf"},
@@ -4617,19 +4620,19 @@ var fontRegistry = &registry{
 		{"Times CY", "Times CY Bold Italic", "TimesCY.dfont"},
 		{"Times CY", "Times CY Italic", "TimesCY.dfont"},
 		{"Times CY", "Times CY Roman", "TimesCY.dfont"},
-		{"Times New Roman", "Times New Roman", "times.ttf"},
-		{"Times New Roman", "Times New Roman", "Times New Roman.ttf"},
 		{"Times New Roman", "Times New Roman", "Times New Roman"},
+		{"Times New Roman", "Times New Roman", "Times New Roman.ttf"},
 		{"Times New Roman", "Times New Roman", "Times_New_Roman.ttf"},
-		{"Times New Roman", "Times New Roman Bold", "timesbd.ttf"},
+		{"Times New Roman", "Times New Roman", "times.ttf"},
 		{"Times New Roman", "Times New Roman Bold", "Times New Roman Bold.ttf"},
 		{"Times New Roman", "Times New Roman Bold", "Times_New_Roman_Bold.ttf"},
-		{"Times New Roman", "Times New Roman Bold Italic", "timesbi.ttf"},
+		{"Times New Roman", "Times New Roman Bold", "timesbd.ttf"},
 		{"Times New Roman", "Times New Roman Bold Italic", "Times New Roman Bold Italic.ttf"},
 		{"Times New Roman", "Times New Roman Bold Italic", "Times_New_Roman_Bold_Italic.ttf"},
-		{"Times New Roman", "Times New Roman Italic", "timesi.ttf"},
+		{"Times New Roman", "Times New Roman Bold Italic", "timesbi.ttf"},
 		{"Times New Roman", "Times New Roman Italic", "Times New Roman Italic.ttf"},
 		{"Times New Roman", "Times New Roman Italic", "Times_New_Roman_Italic.ttf"},
+		{"Times New Roman", "Times New Roman Italic", "timesi.ttf"},
 		{"Titr", "Titr Bold", "titr.ttf"},
 		{"Tlwg Mono", "Tlwg Mono", "TlwgMono.ttf"},
 		{"Tlwg Mono", "Tlwg Mono Bold", "TlwgMono-Bold.ttf"},
@@ -4663,19 +4666,19 @@ var fontRegistry = &registry{
 		{"Traditional Arabic", "Traditional Arabic", "trado.ttf"},
 		{"Traditional Arabic", "Traditional Arabic Bold", "tradbdo.ttf"},
 		{"Trattatello", "Trattatello", "Trattatello.ttf"},
-		{"Trebuchet MS", "Trebuchet MS", "trebuc.ttf"},
-		{"Trebuchet MS", "Trebuchet MS", "Trebuchet MS.ttf"},
 		{"Trebuchet MS", "Trebuchet MS", "Trebuchet MS"},
+		{"Trebuchet MS", "Trebuchet MS", "Trebuchet MS.ttf"},
 		{"Trebuchet MS", "Trebuchet MS", "Trebuchet_MS.ttf"},
-		{"Trebuchet MS", "Trebuchet MS Bold", "trebucbd.ttf"},
+		{"Trebuchet MS", "Trebuchet MS", "trebuc.ttf"},
 		{"Trebuchet MS", "Trebuchet MS Bold", "Trebuchet MS Bold.ttf"},
 		{"Trebuchet MS", "Trebuchet MS Bold", "Trebuchet_MS_Bold.ttf"},
-		{"Trebuchet MS", "Trebuchet MS Bold Italic", "trebucbi.ttf"},
+		{"Trebuchet MS", "Trebuchet MS Bold", "trebucbd.ttf"},
 		{"Trebuchet MS", "Trebuchet MS Bold Italic", "Trebuchet MS Bold Italic.ttf"},
 		{"Trebuchet MS", "Trebuchet MS Bold Italic", "Trebuchet_MS_Bold_Italic.ttf"},
-		{"Trebuchet MS", "Trebuchet MS Italic", "trebucit.ttf"},
+		{"Trebuchet MS", "Trebuchet MS Bold Italic", "trebucbi.ttf"},
 		{"Trebuchet MS", "Trebuchet MS Italic", "Trebuchet MS Italic.ttf"},
 		{"Trebuchet MS", "Trebuchet MS Italic", "Trebuchet_MS_Italic.ttf"},
+		{"Trebuchet MS", "Trebuchet MS Italic", "trebucit.ttf"},
 		{"Tsukushi A Round Gothic", "Tsukushi A Round Gothic Bold", "TsukushiAMaruGothic.ttc"},
 		{"Tsukushi A Round Gothic", "Tsukushi A Round Gothic Regular", "TsukushiAMaruGothic.ttc"},
 		{"Tsukushi B Round Gothic", "Tsukushi B Round Gothic Bold", "TsukushiBMaruGothic.ttc"},
@@ -4780,35 +4783,35 @@ var fontRegistry = &registry{
 		{"UKIJ_Mac Ekran", "UKIJ_Mac Ekran", "UKIJ_MacEkran.ttf"},
 		{"UKIJ_Mac Ekran", "UKIJ_Mac Ekran", "UKIJ_MacEkranBold.ttf"},
 		{"UKIJ_Mac Ekran", "UKIJ_Mac Ekran Bold", "UKIJ_MacEkranBold.ttf"},
+		{"URW Bookman", "URW Bookman", "URWBookman-Demi.t1"},
+		{"URW Bookman", "URW Bookman", "URWBookman-DemiItalic.t1"},
 		{"URW Bookman", "URW Bookman", "URWBookman-Light.t1"},
 		{"URW Bookman", "URW Bookman", "URWBookman-LightItalic.t1"},
-		{"URW Bookman", "URW Bookman", "URWBookman-DemiItalic.t1"},
-		{"URW Bookman", "URW Bookman", "URWBookman-Demi.t1"},
 		{"URW Bookman", "URWBookman-Demi", "URWBookman-Demi.otf"},
 		{"URW Bookman", "URWBookman-DemiItalic", "URWBookman-DemiItalic.otf"},
 		{"URW Bookman", "URWBookman-Light", "URWBookman-Light.otf"},
 		{"URW Bookman", "URWBookman-LightItalic", "URWBookman-LightItalic.otf"},
-		{"URW Bookman L", "URW Bookman L", "b018035l.pfb"},
-		{"URW Bookman L", "URW Bookman L", "b018032l.pfb"},
-		{"URW Bookman L", "URW Bookman L", "b018015l.pfb"},
 		{"URW Bookman L", "URW Bookman L", "b018012l.pfb"},
+		{"URW Bookman L", "URW Bookman L", "b018015l.pfb"},
+		{"URW Bookman L", "URW Bookman L", "b018032l.pfb"},
+		{"URW Bookman L", "URW Bookman L", "b018035l.pfb"},
 		{"URW Chancery L", "URW Chancery L", "z003034l.pfb"},
 		{"URW Gothic", "URW Gothic", "URWGothic-Book.t1"},
+		{"URW Gothic", "URW Gothic", "URWGothic-BookOblique.t1"},
 		{"URW Gothic", "URW Gothic", "URWGothic-Demi.t1"},
 		{"URW Gothic", "URW Gothic", "URWGothic-DemiOblique.t1"},
-		{"URW Gothic", "URW Gothic", "URWGothic-BookOblique.t1"},
 		{"URW Gothic", "URWGothic-Book", "URWGothic-Book.otf"},
 		{"URW Gothic", "URWGothic-BookOblique", "URWGothic-BookOblique.otf"},
 		{"URW Gothic", "URWGothic-Demi", "URWGothic-Demi.otf"},
 		{"URW Gothic", "URWGothic-DemiOblique", "URWGothic-DemiOblique.otf"},
-		{"URW Gothic L", "URW Gothic L", "a010033l.pfb"},
-		{"URW Gothic L", "URW Gothic L", "a010035l.pfb"},
 		{"URW Gothic L", "URW Gothic L", "a010013l.pfb"},
 		{"URW Gothic L", "URW Gothic L", "a010015l.pfb"},
-		{"URW Palladio L", "URW Palladio L", "p052024l.pfb"},
-		{"URW Palladio L", "URW Palladio L", "p052023l.pfb"},
-		{"URW Palladio L", "URW Palladio L", "p052004l.pfb"},
+		{"URW Gothic L", "URW Gothic L", "a010033l.pfb"},
+		{"URW Gothic L", "URW Gothic L", "a010035l.pfb"},
 		{"URW Palladio L", "URW Palladio L", "p052003l.pfb"},
+		{"URW Palladio L", "URW Palladio L", "p052004l.pfb"},
+		{"URW Palladio L", "URW Palladio L", "p052023l.pfb"},
+		{"URW Palladio L", "URW Palladio L", "p052024l.pfb"},
 		{"Ubuntu", "Ubuntu", "Ubuntu-R.ttf"},
 		{"Ubuntu", "Ubuntu Bold", "Ubuntu-B.ttf"},
 		{"Ubuntu", "Ubuntu Bold Italic", "Ubuntu-BI.ttf"},
@@ -4840,14 +4843,14 @@ var fontRegistry = &registry{
 		{"Urdu Typesetting", "Urdu Typesetting Bold", "urdtypeb.ttf"},
 		{"Uroob", "Uroob", "Uroob.ttf"},
 		{"Uroob", "Uroob Regular", "Uroob-Regular.ttf"},
-		{"Utopia", "Utopia", "putr.pfa"},
+		{"Utopia", "Utopia", "UTBI____.pfa"},
+		{"Utopia", "Utopia", "UTB_____.pfa"},
+		{"Utopia", "Utopia", "UTI_____.pfa"},
+		{"Utopia", "Utopia", "UTRG____.pfa"},
 		{"Utopia", "Utopia", "putb.pfa"},
 		{"Utopia", "Utopia", "putbi.pfa"},
+		{"Utopia", "Utopia", "putr.pfa"},
 		{"Utopia", "Utopia", "putri.pfa"},
-		{"Utopia", "Utopia", "UTI_____.pfa"},
-		{"Utopia", "Utopia", "UTRG____.pfa"},
-		{"Utopia", "Utopia", "UTBI____.pfa"},
-		{"Utopia", "Utopia", "UTB_____.pfa"},
 		{"Utsaah", "Utsaah", "utsaah.ttf"},
 		{"Utsaah", "Utsaah Bold", "utsaahb.ttf"},
 		{"Utsaah", "Utsaah Bold Italic", "utsaahbi.ttf"},
@@ -4856,20 +4859,20 @@ var fontRegistry = &registry{
 		{"VL PGothic", "VL PGothic Regular", "VL-PGothic-Regular.ttf"},
 		{"Vani", "Vani", "vani.ttf"},
 		{"Vani", "Vani Bold", "vanib.ttf"},
-		{"Vemana2000", "Vemana2000", "vemana2000.ttf"},
 		{"Vemana2000", "Vemana2000", "Vemana.ttf"},
-		{"Verdana", "Verdana", "verdana.ttf"},
-		{"Verdana", "Verdana", "Verdana.ttf"},
+		{"Vemana2000", "Vemana2000", "vemana2000.ttf"},
 		{"Verdana", "Verdana", "Verdana"},
-		{"Verdana", "Verdana Bold", "verdanab.ttf"},
+		{"Verdana", "Verdana", "Verdana.ttf"},
+		{"Verdana", "Verdana", "verdana.ttf"},
 		{"Verdana", "Verdana Bold", "Verdana Bold.ttf"},
 		{"Verdana", "Verdana Bold", "Verdana_Bold.ttf"},
-		{"Verdana", "Verdana Bold Italic", "verdanaz.ttf"},
+		{"Verdana", "Verdana Bold", "verdanab.ttf"},
 		{"Verdana", "Verdana Bold Italic", "Verdana Bold Italic.ttf"},
 		{"Verdana", "Verdana Bold Italic", "Verdana_Bold_Italic.ttf"},
-		{"Verdana", "Verdana Italic", "verdanai.ttf"},
+		{"Verdana", "Verdana Bold Italic", "verdanaz.ttf"},
 		{"Verdana", "Verdana Italic", "Verdana Italic.ttf"},
 		{"Verdana", "Verdana Italic", "Verdana_Italic.ttf"},
+		{"Verdana", "Verdana Italic", "verdanai.ttf"},
 		{"Verdana Pro", "Verdana Pro", "verdanapro-regular.ttf"},
 		{"Verdana Pro", "Verdana Pro Black", "verdanapro-black.ttf"},
 		{"Verdana Pro", "Verdana Pro Black Italic", "verdanapro-blackitalic.ttf"},
@@ -4902,9 +4905,9 @@ var fontRegistry = &registry{
 		{"Waseem", "Waseem Regular", "Waseem.ttc"},
 		{"Wawati SC", "Wawati SC Regular", "WawaSC-Regular.otf"},
 		{"Wawati TC", "Wawati TC Regular", "WawaTC-Regular.otf"},
-		{"Webdings", "Webdings", "webdings.ttf"},
-		{"Webdings", "Webdings", "Webdings.ttf"},
 		{"Webdings", "Webdings", "Webdings"},
+		{"Webdings", "Webdings", "Webdings.ttf"},
+		{"Webdings", "Webdings", "webdings.ttf"},
 		{"Weibei SC", "Weibei SC Bold", "WeibeiSC-Bold.otf"},
 		{"Weibei TC", "Weibei TC Bold", "WeibeiTC-Bold.otf"},
 		{"WenQuanYi Micro Hei", "WenQuanYi Micro Hei", "wqy-microhei.ttc"},
@@ -4913,8 +4916,8 @@ var fontRegistry = &registry{
 		{"WenQuanYi Zen Hei Mono", "WenQuanYi Zen Hei Mono", "wqy-zenhei.ttc"},
 		{"WenQuanYi Zen Hei Sharp", "WenQuanYi Zen Hei Sharp", "wqy-zenhei.ttc"},
 		{"Westminster", "Westminster", "westm.ttf"},
-		{"Wingdings", "Wingdings", "wingding.ttf"},
 		{"Wingdings", "Wingdings", "Wingdings.ttf"},
+		{"Wingdings", "Wingdings", "wingding.ttf"},
 		{"Wingdings", "Wingdings 2", "Wingdings 2.ttf"},
 		{"Wingdings", "Wingdings 3", "Wingdings 3.ttf"},
 		{"Xingkai SC", "Xingkai SC Bold", "Xingkai.ttc"},
@@ -4930,11 +4933,11 @@ var fontRegistry = &registry{
 		{"Yrsa", "Yrsa SemiBold", "Yrsa-SemiBold.ttf"},
 		{"Yu Gothic", "Yu Gothic Bold", "yugothb.ttc"},
 		{"Yu Gothic", "Yu Gothic Bold", "yugothib.ttf"},
-		{"Yu Gothic", "Yu Gothic Light", "yugothl.ttc"},
 		{"Yu Gothic", "Yu Gothic Light", "yugothil.ttf"},
+		{"Yu Gothic", "Yu Gothic Light", "yugothl.ttc"},
 		{"Yu Gothic", "Yu Gothic Medium", "yugothm.ttc"},
-		{"Yu Gothic", "Yu Gothic Regular", "yugothr.ttc"},
 		{"Yu Gothic", "Yu Gothic Regular", "yugothic.ttf"},
+		{"Yu Gothic", "Yu Gothic Regular", "yugothr.ttc"},
 		{"Yu Gothic", "Yu Gothic UI Bold", "yugothb.ttc"},
 		{"Yu Gothic", "Yu Gothic UI Light", "yugothl.ttc"},
 		{"Yu Gothic", "Yu Gothic UI Regular", "yugothm.ttc"},
@@ -4951,11 +4954,11 @@ var fontRegistry = &registry{
 		{"YuKyokasho", "YuKyokasho Medium", "Kyokasho.ttc"},
 		{"YuKyokasho Yoko", "YuKyokasho Yoko Bold", "Kyokasho.ttc"},
 		{"YuKyokasho Yoko", "YuKyokasho Yoko Medium", "Kyokasho.ttc"},
-		{"YuMincho", "YuMincho Demibold", "YuMincho.ttc"},
 		{"YuMincho", "YuMincho Demibold", "Yu Mincho Demibold.otf"},
+		{"YuMincho", "YuMincho Demibold", "YuMincho.ttc"},
 		{"YuMincho", "YuMincho Extrabold", "YuMincho.ttc"},
-		{"YuMincho", "YuMincho Medium", "YuMincho.ttc"},
 		{"YuMincho", "YuMincho Medium", "Yu Mincho Medium.otf"},
+		{"YuMincho", "YuMincho Medium", "YuMincho.ttc"},
 		{"YuMincho +36p Kana", "YuMincho +36p Kana Demibold", "YuMincho.ttc"},
 		{"YuMincho +36p Kana", "YuMincho +36p Kana Extrabold", "YuMincho.ttc"},
 		{"YuMincho +36p Kana", "YuMincho +36p Kana Medium", "YuMincho.ttc"},
@@ -4965,10 +4968,10 @@ var fontRegistry = &registry{
 		{"Yuanti TC", "Yuanti TC Bold", "Yuanti.ttc"},
 		{"Yuanti TC", "Yuanti TC Light", "Yuanti.ttc"},
 		{"Yuanti TC", "Yuanti TC Regular", "Yuanti.ttc"},
-		{"Yuppy SC", "Yuppy SC Regular", "雅痞-简.otf"},
 		{"Yuppy SC", "Yuppy SC Regular", "YuppySC-Regular.otf"},
-		{"Yuppy TC", "Yuppy TC Regular", "雅痞-繁.otf"},
+		{"Yuppy SC", "Yuppy SC Regular", "雅痞-简.otf"},
 		{"Yuppy TC", "Yuppy TC Regular", "YuppyTC-Regular.otf"},
+		{"Yuppy TC", "Yuppy TC Regular", "雅痞-繁.otf"},
 		{"Z003", "Z003", "Z003-MediumItalic.t1"},
 		{"Z003", "Z003-MediumItalic", "Z003-MediumItalic.otf"},
 		{"Zapf Dingbats", "Zapf Dingbats", "ZapfDingbats.dfont"},
@@ -5114,6 +5117,136 @@ var fontRegistry = &registry{
 			"Wingdings",
 			"Zapf Dingbats",
 		},
+		// Generic font families, resolved to the families of their group,
+		// in order of preference.
+		{
+			FamilySerif,
+			"Times New Roman",
+			"Times",
+			"Liberation Serif",
+			"DejaVu Serif",
+			"Nimbus Roman",
+			"Nimbus Roman No9 L",
+			"FreeSerif",
+			"Noto Serif",
+			"Georgia",
+			"Cambria",
+			"Constantia",
+			"Hoefler Text",
+			"Palatino Linotype",
+			"Palatino",
+			"Book Antiqua",
+			"Baskerville",
+			"Bitstream Vera Serif",
+			"Lucida Bright",
+			"Century Schoolbook L",
+			"Caladea",
+			"Utopia",
+			"Garamond",
+		},
+		{
+			FamilySansSerif,
+			"Arial",
+			"Helvetica",
+			"Helvetica Neue",
+			"Liberation Sans",
+			"DejaVu Sans",
+			"Nimbus Sans",
+			"Nimbus Sans L",
+			"FreeSans",
+			"Noto Sans",
+			"Verdana",
+			"Tahoma",
+			"Segoe UI",
+			"Calibri",
+			"Lucida Grande",
+			"Lucida Sans Unicode",
+			"Bitstream Vera Sans",
+			"Trebuchet MS",
+			"Geneva",
+			"Gill Sans",
+			"Ubuntu",
+			"PT Sans",
+			"Frutiger",
+			"Univers",
+			"Myriad Pro",
+			"Corbel",
+			"Candara",
+		},
+		{
+			FamilyMonospace,
+			"Courier New",
+			"Courier",
+			"DejaVu Sans Mono",
+			"Liberation Mono",
+			"Consolas",
+			"Menlo",
+			"Monaco",
+			"Noto Sans Mono",
+			"Lucida Console",
+			"Andale Mono",
+			"Bitstream Vera Sans Mono",
+			"Nimbus Mono",
+			"Nimbus Mono L",
+			"FreeMono",
+			"Lucida Sans Typewriter",
+		},
+		{
+			FamilyCursive,
+			"Comic Sans MS",
+			"Apple Chancery",
+			"Brush Script MT",
+			"Segoe Script",
+			"Lucida Handwriting",
+			"URW Chancery L",
+			"Free Chancery",
+		},
+		{
+			FamilyFantasy,
+			"Impact",
+			"Papyrus",
+			"Chalkduster",
+			"Luminari",
+			"Haettenschweiler",
+			"Franklin Gothic Bold",
+			"Charcoal",
+			"Arial Black",
+		},
+		{
+			FamilySystemUI,
+			".SF NS",
+			".SF NS Text",
+			"Segoe UI",
+			"Ubuntu",
+			"Cantarell",
+			"Noto Sans UI",
+			"Noto Sans",
+			"DejaVu Sans",
+			"Helvetica Neue",
+			"Lucida Grande",
+			"Tahoma",
+		},
+		{
+			FamilyEmoji,
+			"Apple Color Emoji",
+			"Segoe UI Emoji",
+			"Noto Color Emoji",
+			"Android Emoji",
+			"Noto Emoji",
+			"Segoe UI Symbol",
+			"Symbola",
+		},
+		{
+			FamilyMath,
+			"Cambria Math",
+			"STIX Two Math",
+			"STIX Math",
+			"STIXGeneral",
+			"Noto Sans Math",
+			"DejaVu Math TeX Gyre",
+			"Latin Modern Math",
+			"Symbol",
+		},
 	},
 	defaults: []string{
 		"Arial",
@@ -5143,52 +5276,8 @@ var fontRegistry = &registry{
 	},
 }
 
-var fontStyles = []string{
-	"extrabold",
-	"demibold",
-	"semibold",
-	"demi bold",
-	"semi bold",
-	"bold",
-	"extralight",
-	"ultralight",
-	"ultra light",
-	"light",
-	"ultrathin",
-	"thin",
-	"regular",
-	"italic",
-	"regularoblique",
-	"boldoblique",
-	"oblique",
-	"heavy",
-	"medium",
-}
-
 func init() {
-	families := map[string][]*Font{}
-	filenames := map[string][]*Font{}
-
-	for _, font := range fontRegistry.fonts {
-		// Generate fonts by family map entry.
-		family := font.Family
-
-		fonts, ok := families[family]
-		if !ok {
-			fonts = []*Font{}
-		}
-		families[family] = append(fonts, font)
-
-		// Generate fonts by filename map entry.
-		filename := strings.ToLower(font.Filename)
-
-		fonts, ok = filenames[filename]
-		if !ok {
-			fonts = []*Font{}
-		}
-		filenames[filename] = append(fonts, font)
+	for _, regFont := range fontRegistry.fonts {
+		fontRegistry.index(regFont)
 	}
-
-	fontRegistry.families = families
-	fontRegistry.filenames = filenames
 }
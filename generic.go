@@ -0,0 +1,112 @@
+package sysfont
+
+import "strings"
+
+// Generic font families. Generic families can be used in font queries in
+// place of actual font families. They are resolved to the installed fonts of
+// the appropriate category.
+const (
+	FamilySerif     = "serif"
+	FamilySansSerif = "sans-serif"
+	FamilyMonospace = "monospace"
+	FamilyCursive   = "cursive"
+	FamilyFantasy   = "fantasy"
+	FamilySystemUI  = "system-ui"
+	FamilyEmoji     = "emoji"
+	FamilyMath      = "math"
+)
+
+// genericFamilies maps cleaned generic family names and their common
+// variants to generic font families.
+var genericFamilies = map[string]string{
+	"serif":      FamilySerif,
+	"sans serif": FamilySansSerif,
+	"sans":       FamilySansSerif,
+	"monospace":  FamilyMonospace,
+	"mono":       FamilyMonospace,
+	"cursive":    FamilyCursive,
+	"fantasy":    FamilyFantasy,
+	"system ui":  FamilySystemUI,
+	"emoji":      FamilyEmoji,
+	"math":       FamilyMath,
+}
+
+// genericFamily returns the generic font family identified by the specified
+// family name, if any.
+func genericFamily(family string) (string, bool) {
+	generic, ok := genericFamilies[cleanQuery(family)]
+	return generic, ok
+}
+
+// expandFamilies replaces the generic families in the specified list with
+// their ordered preference lists. For each returned family, the preference
+// list it originates from is also returned. Families which are not the result
+// of an expansion have a nil preference list.
+func (r *Registry) expandFamilies(families []string) ([]string, [][]string) {
+	var expanded []string
+	var groups [][]string
+	seen := map[string]bool{}
+
+	var group []string
+	add := func(family string) {
+		if key := strings.ToLower(family); !seen[key] {
+			seen[key] = true
+			expanded = append(expanded, family)
+			groups = append(groups, group)
+		}
+	}
+
+	for _, family := range families {
+		generic, ok := genericFamily(family)
+		if !ok {
+			group = nil
+			add(family)
+			continue
+		}
+		group = r.genericFamilies(generic)
+
+		for _, member := range group {
+			add(member)
+		}
+	}
+
+	return expanded, groups
+}
+
+// genericFamilies returns the ordered preference list of the specified
+// generic family, built from the alternative groups which contain the generic
+// family or one of its variants. The groups are used in the order of the
+// alternatives, so custom groups (e.g. fontconfig aliases) take priority
+// over the built-in ones.
+func (r *Registry) genericFamilies(generic string) []string {
+	var families []string
+	seen := map[string]bool{}
+
+	add := func(family string) {
+		if key := strings.ToLower(family); !seen[key] {
+			seen[key] = true
+			families = append(families, family)
+		}
+	}
+
+	for _, group := range r.alternatives {
+		var found bool
+		for _, member := range group {
+			if g, ok := genericFamily(member); ok && g == generic {
+				found = true
+				break
+			}
+		}
+		if !found {
+			continue
+		}
+
+		for _, member := range group {
+			if _, ok := genericFamily(member); !ok {
+				add(member)
+			}
+		}
+	}
+
+	return families
+}
@@ -0,0 +1,163 @@
+package sysfont
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGenericFamily(t *testing.T) {
+	tests := []struct {
+		family  string
+		generic string
+	}{
+		{"serif", FamilySerif},
+		{"Sans-Serif", FamilySansSerif},
+		{"sans serif", FamilySansSerif},
+		{"Sans", FamilySansSerif},
+		{"monospace", FamilyMonospace},
+		{"Mono", FamilyMonospace},
+		{"System-UI", FamilySystemUI},
+		{"emoji", FamilyEmoji},
+		{"Arial", ""},
+		{"DejaVu Sans", ""},
+	}
+
+	for _, test := range tests {
+		if generic, ok := genericFamily(test.family); generic != test.generic || ok != (test.generic != "") {
+			t.Errorf("%s: got generic family %q (%t), want %q", test.family, generic, ok, test.generic)
+		}
+	}
+}
+
+func TestGenericFamilies(t *testing.T) {
+	reg := NewRegistry()
+	reg.AddAlternatives("Arial", "Helvetica")
+	reg.AddAlternatives("sans", "Verdana", "DejaVu Sans")
+	reg.AddAlternatives("Courier New", "monospace", "Courier")
+	reg.AddAlternatives(FamilySansSerif, "Arial", "DejaVu Sans", "Liberation Sans")
+
+	// Groups added later (e.g. fontconfig aliases) take priority.
+	config := reg.withAlternatives([][]string{{"Mono", "Hack", "courier"}})
+
+	tests := []struct {
+		name     string
+		reg      *Registry
+		generic  string
+		families []string
+	}{
+		{
+			name:     "group order",
+			reg:      reg,
+			generic:  FamilySansSerif,
+			families: []string{"Arial", "DejaVu Sans", "Liberation Sans", "Verdana"},
+		},
+		{
+			name:     "monospace variant",
+			reg:      reg,
+			generic:  FamilyMonospace,
+			families: []string{"Courier New", "Courier"},
+		},
+		{
+			name:     "fontconfig group priority",
+			reg:      config,
+			generic:  FamilyMonospace,
+			families: []string{"Hack", "courier", "Courier New"},
+		},
+		{
+			name:    "no groups",
+			reg:     reg,
+			generic: FamilyEmoji,
+		},
+	}
+
+	for _, test := range tests {
+		if families := test.reg.genericFamilies(test.generic); !reflect.DeepEqual(families, test.families) {
+			t.Errorf("%s: got families %q, want %q", test.name, families, test.families)
+		}
+	}
+
+	// The generic families of the built-in registry are resolved using its
+	// alternative groups.
+	for _, generic := range []string{
+		FamilySerif, FamilySansSerif, FamilyMonospace, FamilyCursive,
+		FamilyFantasy, FamilySystemUI, FamilyEmoji, FamilyMath,
+	} {
+		families := fontRegistry.genericFamilies(generic)
+		if len(families) == 0 {
+			t.Errorf("%s: no built-in families", generic)
+		}
+		for _, family := range families {
+			if _, ok := genericFamily(family); ok {
+				t.Errorf("%s: got generic family %q in preference list", generic, family)
+			}
+		}
+	}
+	if families := fontRegistry.genericFamilies(FamilyMonospace); families[0] != "Courier New" {
+		t.Errorf("monospace: got preferred family %q", families[0])
+	}
+}
+
+func TestExpandFamilies(t *testing.T) {
+	reg := NewRegistry()
+	reg.AddAlternatives(FamilySerif, "Times", "Georgia")
+	reg.AddAlternatives(FamilyMonospace, "Courier", "Menlo")
+
+	config := reg.withAlternatives([][]string{{"monospace", "Hack"}})
+	serif := []string{"Times", "Georgia"}
+	mono := []string{"Courier", "Menlo"}
+
+	tests := []struct {
+		name     string
+		reg      *Registry
+		families []string
+		expanded []string
+		groups   [][]string
+	}{
+		{
+			name:     "no generic families",
+			reg:      reg,
+			families: []string{"Arial", "Helvetica"},
+			expanded: []string{"Arial", "Helvetica"},
+			groups:   [][]string{nil, nil},
+		},
+		{
+			name:     "generic families",
+			reg:      reg,
+			families: []string{"Arial", "serif", "Mono"},
+			expanded: []string{"Arial", "Times", "Georgia", "Courier", "Menlo"},
+			groups:   [][]string{nil, serif, serif, mono, mono},
+		},
+		{
+			name:     "duplicate families",
+			reg:      reg,
+			families: []string{"georgia", "serif", "monospace", "mono"},
+			expanded: []string{"georgia", "Times", "Courier", "Menlo"},
+			groups:   [][]string{nil, serif, mono, mono},
+		},
+		{
+			name:     "generic family without groups",
+			reg:      reg,
+			families: []string{"sans-serif", "Arial"},
+			expanded: []string{"Arial"},
+			groups:   [][]string{nil},
+		},
+		{
+			name:     "fontconfig group priority",
+			reg:      config,
+			families: []string{"monospace"},
+			expanded: []string{"Hack", "Courier", "Menlo"},
+			groups: [][]string{
+				{"Hack", "Courier", "Menlo"},
+				{"Hack", "Courier", "Menlo"},
+				{"Hack", "Courier", "Menlo"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		expanded, groups := test.reg.expandFamilies(test.families)
+		if !reflect.DeepEqual(expanded, test.expanded) || !reflect.DeepEqual(groups, test.groups) {
+			t.Errorf("%s: got families %q with groups %q, want %q with groups %q", test.name, expanded, groups, test.expanded, test.groups)
+		}
+	}
+}
@@ -1,8 +1,9 @@
 module github.com/adrg/sysfont
 
-go 1.14
+go 1.16
 
 require (
 	github.com/adrg/strutil v0.3.1
 	github.com/adrg/xdg v0.4.0
+	github.com/andybalholm/brotli v1.0.6
 )
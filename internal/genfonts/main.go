@@ -0,0 +1,310 @@
+// Command genfonts regenerates the font definitions of the built-in font
+// registry (the fonts field of fontRegistry in fonts.go). The fonts found in
+// the specified directories and manifest files are identified by reading
+// their name tables and are merged with the existing definitions, unless
+// the -replace flag is used. The alternative and default font families of
+// the registry are preserved. The generated definitions are deduplicated and
+// sorted by family, name and filename.
+//
+// Usage:
+//
+//	go run ./internal/genfonts [-o fonts.go] [-dir dir]... [-manifest file]... [-system] [-replace]
+//
+// At least one font source must be specified. The -system flag scans the
+// font directories of the current system, so its output depends on the
+// machine it runs on. The go:generate directive of the registry only uses
+// the checked-in fonts.json manifest, which keeps the output reproducible.
+//
+// Manifest files use the JSON registry format accepted by Registry.Load:
+//
+//	{"fonts": [{"family": "Arial", "name": "Arial Bold", "filename": "Arial Bold.ttf"}]}
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/adrg/strutil"
+	"github.com/adrg/sysfont"
+	"github.com/adrg/xdg"
+)
+
+// fontExtensions contains the extensions of the scanned font files.
+var fontExtensions = []string{".ttf", ".ttc", ".otf", ".otc"}
+
+// entry represents a font definition of the registry.
+type entry struct {
+	Family   string `json:"family"`
+	Name     string `json:"name"`
+	Filename string `json:"filename"`
+}
+
+// stringList is a flag value which can be specified multiple times.
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+func main() {
+	var output string
+	var dirs, manifests stringList
+	var system, replace bool
+
+	flag.StringVar(&output, "o", "fonts.go", "the registry source `file` to update")
+	flag.Var(&dirs, "dir", "scan the specified font `directory` (repeatable)")
+	flag.Var(&manifests, "manifest", "read font definitions from the specified JSON `file` (repeatable)")
+	flag.BoolVar(&system, "system", false, "scan the font directories of the current system")
+	flag.BoolVar(&replace, "replace", false, "discard the existing font definitions")
+	flag.Parse()
+
+	if len(dirs) == 0 && len(manifests) == 0 && !system {
+		fmt.Fprintln(os.Stderr, "genfonts: no font source specified (use -dir, -manifest or -system)")
+		flag.Usage()
+		os.Exit(2)
+	}
+	if system {
+		dirs = append(dirs, xdg.FontDirs...)
+	}
+
+	if err := run(output, dirs, manifests, replace); err != nil {
+		fmt.Fprintln(os.Stderr, "genfonts:", err)
+		os.Exit(1)
+	}
+}
+
+func run(output string, dirs, manifests []string, replace bool) error {
+	src, err := ioutil.ReadFile(output)
+	if err != nil {
+		return err
+	}
+
+	// Locate the font definitions of the registry.
+	lit, err := findFontsLiteral(output, src)
+	if err != nil {
+		return err
+	}
+
+	var entries []*entry
+	if !replace {
+		if entries, err = parseEntries(lit); err != nil {
+			return err
+		}
+	}
+
+	// Add font definitions.
+	for _, manifest := range manifests {
+		manifestEntries, err := readManifest(manifest)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, manifestEntries...)
+	}
+	for _, dir := range dirs {
+		entries = append(entries, scanDir(dir)...)
+	}
+	entries = sortEntries(entries)
+
+	// Generate the font definitions and update the registry source.
+	var buf bytes.Buffer
+	buf.Write(src[:lit.Pos()-1])
+	buf.WriteString("[]*registryFont{\n")
+	for _, e := range entries {
+		fmt.Fprintf(&buf, "{%s, %s, %s},\n", strconv.Quote(e.Family), strconv.Quote(e.Name), strconv.Quote(e.Filename))
+	}
+	buf.WriteString("}")
+	buf.Write(src[lit.End()-1:])
+
+	formatted, err := format.Source(buf.Bytes())
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(output, formatted, 0644)
+}
+
+// findFontsLiteral returns the composite literal assigned to the fonts field
+// of the fontRegistry variable.
+func findFontsLiteral(filename string, src []byte) (*ast.CompositeLit, error) {
+	file, err := parser.ParseFile(token.NewFileSet(), filename, src, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var lit *ast.CompositeLit
+	ast.Inspect(file, func(node ast.Node) bool {
+		spec, ok := node.(*ast.ValueSpec)
+		if !ok || lit != nil {
+			return lit == nil
+		}
+		if len(spec.Names) != 1 || spec.Names[0].Name != "fontRegistry" || len(spec.Values) != 1 {
+			return false
+		}
+
+		// Identify the fonts field of the registry literal.
+		value := spec.Values[0]
+		if unary, ok := value.(*ast.UnaryExpr); ok {
+			value = unary.X
+		}
+		registry, ok := value.(*ast.CompositeLit)
+		if !ok {
+			return false
+		}
+
+		for _, elt := range registry.Elts {
+			kv, ok := elt.(*ast.KeyValueExpr)
+			if !ok {
+				continue
+			}
+			if key, ok := kv.Key.(*ast.Ident); ok && key.Name == "fonts" {
+				lit, _ = kv.Value.(*ast.CompositeLit)
+				break
+			}
+		}
+
+		return false
+	})
+	if lit == nil {
+		return nil, errors.New("fonts field of fontRegistry not found in " + filename)
+	}
+
+	return lit, nil
+}
+
+// parseEntries returns the font definitions of the specified literal.
+func parseEntries(lit *ast.CompositeLit) ([]*entry, error) {
+	entries := make([]*entry, 0, len(lit.Elts))
+	for _, elt := range lit.Elts {
+		values, ok := elt.(*ast.CompositeLit)
+		if !ok || len(values.Elts) != 3 {
+			return nil, fmt.Errorf("invalid font definition at offset %d", elt.Pos())
+		}
+
+		var fields [3]string
+		for i, value := range values.Elts {
+			basic, ok := value.(*ast.BasicLit)
+			if !ok || basic.Kind != token.STRING {
+				return nil, fmt.Errorf("invalid font definition at offset %d", elt.Pos())
+			}
+
+			field, err := strconv.Unquote(basic.Value)
+			if err != nil {
+				return nil, err
+			}
+			fields[i] = field
+		}
+
+		entries = append(entries, &entry{Family: fields[0], Name: fields[1], Filename: fields[2]})
+	}
+
+	return entries, nil
+}
+
+// readManifest returns the font definitions of the specified manifest file.
+func readManifest(filename string) ([]*entry, error) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	var manifest struct {
+		Fonts []*entry `json:"fonts"`
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, &os.PathError{Op: "parse", Path: filename, Err: err}
+	}
+
+	var entries []*entry
+	for _, e := range manifest.Fonts {
+		if e == nil || e.Family == "" || e.Filename == "" {
+			continue
+		}
+		if e.Name == "" {
+			e.Name = e.Family
+		}
+		e.Filename = filepath.Base(e.Filename)
+
+		entries = append(entries, e)
+	}
+
+	return entries, nil
+}
+
+// scanDir returns the font definitions of the font files found in the
+// specified directory. Font files which cannot be read are skipped.
+func scanDir(dir string) []*entry {
+	var entries []*entry
+	filepath.Walk(dir, func(filename string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return nil
+		}
+		if !strutil.SliceContains(fontExtensions, strings.ToLower(filepath.Ext(filename))) {
+			return nil
+		}
+
+		fonts, err := sysfont.ReadFontFile(filename)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "genfonts: skipping", filename+":", err)
+			return nil
+		}
+
+		for _, font := range fonts {
+			if font.Family == "" || font.Name == "" {
+				continue
+			}
+
+			entries = append(entries, &entry{
+				Family:   font.Family,
+				Name:     font.Name,
+				Filename: filepath.Base(filename),
+			})
+		}
+
+		return nil
+	})
+
+	return entries
+}
+
+// sortEntries returns the specified font definitions, deduplicated and
+// sorted by family, name and filename.
+func sortEntries(entries []*entry) []*entry {
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.Family != b.Family {
+			return a.Family < b.Family
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.Filename < b.Filename
+	})
+
+	unique := entries[:0]
+	for i, e := range entries {
+		if i > 0 && *e == *entries[i-1] {
+			continue
+		}
+		unique = append(unique, e)
+	}
+
+	return unique
+}
@@ -0,0 +1,397 @@
+package sysfont
+
+import (
+	"fmt"
+	"math"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// fcWeights maps fontconfig weights to OpenType weights. Weights between the
+// listed values are interpolated linearly.
+var fcWeights = [][2]float64{
+	{0, 100},
+	{40, 200},
+	{50, 300},
+	{55, 350},
+	{75, 380},
+	{80, 400},
+	{100, 500},
+	{180, 600},
+	{200, 700},
+	{205, 800},
+	{210, 900},
+	{215, 1000},
+}
+
+// Fontconfig slant values.
+const (
+	fcSlantRoman   = 0
+	fcSlantItalic  = 100
+	fcSlantOblique = 110
+)
+
+// ParsePattern parses the specified fontconfig pattern (e.g. "DejaVu Sans,
+// Arial:style=Bold:weight=200") into a query. The pattern contains comma
+// separated families, optionally followed by a point size, and properties
+// separated by colons. Properties are either name=value pairs or constants
+// (e.g. bold, italic, condensed). The family, style, weight, slant and width
+// properties are used, with weights, slants and widths using the fontconfig
+// scales. Other properties are ignored. Special characters can be escaped
+// using backslashes.
+func ParsePattern(pattern string) (Query, error) {
+	elements := splitPattern(pattern, ':')
+
+	// Parse families. The optional point size is ignored.
+	var query Query
+	families := elements[0]
+	if i := lastUnescaped(families, '-'); i >= 0 {
+		if _, err := strconv.ParseFloat(unescapePattern(families[i+1:]), 64); err == nil {
+			families = families[:i]
+		}
+	}
+	for _, family := range splitPattern(families, ',') {
+		if family = strings.TrimSpace(unescapePattern(family)); family != "" {
+			query.Family = append(query.Family, family)
+		}
+	}
+
+	// Parse properties.
+	for _, element := range elements[1:] {
+		name, value := element, ""
+		if i := strings.IndexByte(element, '='); i >= 0 {
+			name, value = element[:i], element[i+1:]
+		}
+		name = strings.ToLower(strings.TrimSpace(name))
+
+		// Only the first value of each property is used.
+		if values := splitPattern(value, ','); len(values) > 0 {
+			value = strings.TrimSpace(unescapePattern(values[0]))
+		}
+
+		if err := query.setPatternProperty(name, value); err != nil {
+			return Query{}, err
+		}
+	}
+
+	return query, nil
+}
+
+func (q *Query) setPatternProperty(name, value string) error {
+	// Handle constants.
+	if value == "" {
+		if weight, ok := fontWeights[name]; ok {
+			q.Weight = weight
+		} else if stretch, ok := fontStretches[name]; ok {
+			q.Stretch = stretch
+		} else if style, ok := fontSlants[name]; ok {
+			q.Style = style
+		}
+		return nil
+	}
+
+	invalid := func() error {
+		return fmt.Errorf("sysfont: invalid pattern value %q for property %s", value, name)
+	}
+
+	constant := strings.ToLower(value)
+	number, err := strconv.ParseFloat(value, 64)
+	numeric := err == nil
+
+	switch name {
+	case "family":
+		q.Family = append(q.Family, value)
+	case "style":
+		weight, stretch, style := parseStyle(value)
+		if weight != WeightNormal {
+			q.Weight = weight
+		}
+		if stretch != StretchNormal {
+			q.Stretch = stretch
+		}
+		if style != StyleNormal {
+			q.Style = style
+		}
+	case "weight":
+		switch weight, ok := fontWeights[constant]; {
+		case ok:
+			q.Weight = weight
+		case numeric:
+			q.Weight = fcWeightToWeight(number)
+		default:
+			return invalid()
+		}
+	case "slant":
+		switch {
+		case constant == "roman" || numeric && number == fcSlantRoman:
+			q.Style = StyleNormal
+		case constant == "italic" || numeric && number == fcSlantItalic:
+			q.Style = StyleItalic
+		case constant == "oblique" || numeric && number == fcSlantOblique:
+			q.Style = StyleOblique
+		default:
+			return invalid()
+		}
+	case "width":
+		switch stretch, ok := fontStretches[constant]; {
+		case ok:
+			q.Stretch = stretch
+		case constant == "normal":
+			q.Stretch = StretchNormal
+		case numeric:
+			q.Stretch = percentToStretch(number)
+		default:
+			return invalid()
+		}
+	}
+
+	return nil
+}
+
+// FormatFont formats the specified font using the fontconfig format syntax
+// (e.g. "%{family}: %{style}\n"). Elements have the form %{name}, where name
+// is one of: family, fullname, style, file, index, weight, width or slant.
+// Weights, widths and slants use the fontconfig scales. Elements can specify
+// a default value for empty properties (%{style:-Regular}), a minimum width
+// (%-20{family} or %20{family}, for left and right alignment respectively)
+// and a converter (%{file|basename}). The supported converters are basename,
+// dirname, downcase and upcase. The builtin formats %{=fclist}, %{=fcmatch}
+// and %{=unparse} produce output similar to the fontconfig tools. The \n, \t
+// and \\ escape sequences are supported, and %% produces a percent sign.
+// Unknown elements produce empty output.
+func FormatFont(format string, font *Font) string {
+	var sb strings.Builder
+
+	for i := 0; i < len(format); i++ {
+		switch c := format[i]; c {
+		case '\\':
+			if i+1 >= len(format) {
+				sb.WriteByte(c)
+				continue
+			}
+
+			i++
+			switch format[i] {
+			case 'n':
+				sb.WriteByte('\n')
+			case 't':
+				sb.WriteByte('\t')
+			default:
+				sb.WriteByte(format[i])
+			}
+		case '%':
+			if i+1 < len(format) && format[i+1] == '%' {
+				sb.WriteByte('%')
+				i++
+				continue
+			}
+
+			// Parse element width.
+			j := i + 1
+			for j < len(format) && (format[j] == '-' || format[j] >= '0' && format[j] <= '9') {
+				j++
+			}
+			width, _ := strconv.Atoi(format[i+1 : j])
+
+			// Parse element.
+			end := strings.IndexByte(format[j:], '}')
+			if j >= len(format) || format[j] != '{' || end < 0 {
+				sb.WriteByte(c)
+				continue
+			}
+			value := formatFontElement(format[j+1:j+end], font)
+
+			switch padding := int(math.Abs(float64(width))) - len([]rune(value)); {
+			case padding <= 0:
+				sb.WriteString(value)
+			case width < 0:
+				sb.WriteString(value + strings.Repeat(" ", padding))
+			default:
+				sb.WriteString(strings.Repeat(" ", padding) + value)
+			}
+			i = j + end
+		default:
+			sb.WriteByte(c)
+		}
+	}
+
+	return sb.String()
+}
+
+func formatFontElement(element string, font *Font) string {
+	// Handle builtin formats.
+	switch element {
+	case "=fclist":
+		return FormatFont("%{file}: %{family}:style=%{style}", font)
+	case "=fcmatch":
+		return FormatFont(`%{file|basename}: "%{family}" "%{style}"`, font)
+	case "=unparse":
+		return FormatFont("%{family}:style=%{style}:weight=%{weight}:slant=%{slant}:width=%{width}:file=%{file}:index=%{index}", font)
+	}
+
+	// Parse converter and default value.
+	var converter, defaultValue string
+	if i := strings.IndexByte(element, '|'); i >= 0 {
+		element, converter = element[:i], element[i+1:]
+	}
+	if i := strings.Index(element, ":-"); i >= 0 {
+		element, defaultValue = element[:i], element[i+2:]
+	}
+
+	var value string
+	switch element {
+	case "family":
+		value = font.Family
+	case "fullname":
+		value = font.Name
+	case "style":
+		value = fontStyleName(font)
+	case "file":
+		value = font.Filename
+	case "index":
+		value = strconv.Itoa(font.Index)
+	case "weight":
+		value = strconv.Itoa(weightToFCWeight(font.Weight))
+	case "width":
+		if percent, ok := cssStretches[font.Stretch]; ok {
+			value = strconv.FormatFloat(percent, 'f', -1, 64)
+		}
+	case "slant":
+		switch font.Style {
+		case StyleItalic:
+			value = strconv.Itoa(fcSlantItalic)
+		case StyleOblique:
+			value = strconv.Itoa(fcSlantOblique)
+		default:
+			value = strconv.Itoa(fcSlantRoman)
+		}
+	}
+	if value == "" {
+		value = defaultValue
+	}
+
+	switch converter {
+	case "basename":
+		value = filepath.Base(value)
+	case "dirname":
+		value = filepath.Dir(value)
+	case "downcase":
+		value = strings.ToLower(value)
+	case "upcase":
+		value = strings.ToUpper(value)
+	}
+
+	return value
+}
+
+// fontStyleName returns the style name of the font (e.g. Bold Italic), based
+// on its full name and family. If the full name does not contain additional
+// style information, Regular is returned.
+func fontStyleName(font *Font) string {
+	style := strings.TrimSpace(strings.TrimPrefix(font.Name, font.Family))
+	if style == "" {
+		return "Regular"
+	}
+
+	return style
+}
+
+// fcWeightToWeight converts the specified fontconfig weight to an OpenType
+// weight.
+func fcWeightToWeight(weight float64) int {
+	return int(math.Round(interpolateWeight(weight, 0, 1)))
+}
+
+// weightToFCWeight converts the specified OpenType weight to a fontconfig
+// weight.
+func weightToFCWeight(weight int) int {
+	return int(math.Round(interpolateWeight(float64(weight), 1, 0)))
+}
+
+// interpolateWeight maps the specified weight between the columns of the
+// fontconfig weight table, using linear interpolation.
+func interpolateWeight(weight float64, from, to int) float64 {
+	first, last := fcWeights[0], fcWeights[len(fcWeights)-1]
+	if weight <= first[from] {
+		return first[to]
+	}
+	if weight >= last[from] {
+		return last[to]
+	}
+
+	for i := 1; i < len(fcWeights); i++ {
+		lo, hi := fcWeights[i-1], fcWeights[i]
+		if weight <= hi[from] {
+			return lo[to] + (weight-lo[from])*(hi[to]-lo[to])/(hi[from]-lo[from])
+		}
+	}
+
+	return last[to]
+}
+
+// percentToStretch returns the font stretch closest to the specified width
+// percentage.
+func percentToStretch(percent float64) int {
+	stretch, minDiff := StretchNormal, math.Inf(1)
+	for value := StretchUltraCondensed; value <= StretchUltraExpanded; value++ {
+		if diff := math.Abs(cssStretches[value] - percent); diff < minDiff {
+			stretch, minDiff = value, diff
+		}
+	}
+
+	return stretch
+}
+
+// splitPattern splits the specified pattern by the unescaped occurrences of
+// the separator. Escape sequences are preserved.
+func splitPattern(pattern string, sep byte) []string {
+	var parts []string
+
+	start := 0
+	for i := 0; i < len(pattern); i++ {
+		switch pattern[i] {
+		case '\\':
+			i++
+		case sep:
+			parts = append(parts, pattern[start:i])
+			start = i + 1
+		}
+	}
+
+	return append(parts, pattern[start:])
+}
+
+// lastUnescaped returns the index of the last unescaped occurrence of the
+// specified character in the pattern, or -1 if it is not present.
+func lastUnescaped(pattern string, c byte) int {
+	index := -1
+	for i := 0; i < len(pattern); i++ {
+		switch pattern[i] {
+		case '\\':
+			i++
+		case c:
+			index = i
+		}
+	}
+
+	return index
+}
+
+// unescapePattern removes the backslashes used for escaping characters in
+// the specified pattern.
+func unescapePattern(pattern string) string {
+	if strings.IndexByte(pattern, '\\') < 0 {
+		return pattern
+	}
+
+	var sb strings.Builder
+	for i := 0; i < len(pattern); i++ {
+		if pattern[i] == '\\' && i+1 < len(pattern) {
+			i++
+		}
+		sb.WriteByte(pattern[i])
+	}
+
+	return sb.String()
+}
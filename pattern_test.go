@@ -0,0 +1,175 @@
+package sysfont
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParsePattern(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		query   Query
+		err     bool
+	}{
+		{
+			name:    "families",
+			pattern: "DejaVu Sans, Arial ,,",
+			query:   Query{Family: []string{"DejaVu Sans", "Arial"}},
+		},
+		{
+			name:    "point size",
+			pattern: "DejaVu Sans-12.5",
+			query:   Query{Family: []string{"DejaVu Sans"}},
+		},
+		{
+			name:    "non-numeric suffix",
+			pattern: "Sans-bold",
+			query:   Query{Family: []string{"Sans-bold"}},
+		},
+		{
+			name:    "escaped characters",
+			pattern: `Foo\-Bar\,Baz\:Qux-12,Other`,
+			query:   Query{Family: []string{"Foo-Bar,Baz:Qux-12", "Other"}},
+		},
+		{
+			name:    "style",
+			pattern: "DejaVu Sans:style=Bold Italic",
+			query:   Query{Family: []string{"DejaVu Sans"}, Weight: WeightBold, Style: StyleItalic},
+		},
+		{
+			name:    "constants",
+			pattern: ":bold:italic:condensed:unknown",
+			query:   Query{Weight: WeightBold, Stretch: StretchCondensed, Style: StyleItalic},
+		},
+		{
+			name:    "family property",
+			pattern: "Sans:family=Serif",
+			query:   Query{Family: []string{"Sans", "Serif"}},
+		},
+		{
+			name:    "first value used",
+			pattern: "Sans:weight=bold,light:slant=roman",
+			query:   Query{Family: []string{"Sans"}, Weight: WeightBold},
+		},
+		{
+			name:    "numeric weight",
+			pattern: "Sans:weight=90",
+			query:   Query{Family: []string{"Sans"}, Weight: 450},
+		},
+		{
+			name:    "numeric slant",
+			pattern: "Sans:slant=110",
+			query:   Query{Family: []string{"Sans"}, Style: StyleOblique},
+		},
+		{
+			name:    "numeric width",
+			pattern: "Sans:width=80",
+			query:   Query{Family: []string{"Sans"}, Stretch: StretchCondensed},
+		},
+		{
+			name:    "named width",
+			pattern: "Sans:width=expanded",
+			query:   Query{Family: []string{"Sans"}, Stretch: StretchExpanded},
+		},
+		{
+			name:    "ignored properties",
+			pattern: "Sans:size=12:antialias=true",
+			query:   Query{Family: []string{"Sans"}},
+		},
+		{name: "invalid weight", pattern: "Sans:weight=heavyish", err: true},
+		{name: "invalid slant", pattern: "Sans:slant=5", err: true},
+		{name: "invalid width", pattern: "Sans:width=narrowish", err: true},
+	}
+
+	for _, test := range tests {
+		query, err := ParsePattern(test.pattern)
+		if (err != nil) != test.err {
+			t.Errorf("%s: got error %v, want error %t", test.name, err, test.err)
+			continue
+		}
+		if !reflect.DeepEqual(query, test.query) {
+			t.Errorf("%s: got query %+v, want %+v", test.name, query, test.query)
+		}
+	}
+}
+
+func TestFormatFont(t *testing.T) {
+	font := &Font{
+		Family:   "DejaVu Sans",
+		Name:     "DejaVu Sans Bold Oblique",
+		Filename: "/usr/share/fonts/dejavu/DejaVuSans-BoldOblique.ttf",
+		Weight:   WeightBold,
+		Stretch:  StretchNormal,
+		Style:    StyleOblique,
+	}
+	regular := &Font{
+		Family:   "Fixed",
+		Name:     "Fixed",
+		Filename: "/usr/share/fonts/X11/misc/6x13.pcf.gz",
+		Index:    2,
+		Weight:   WeightNormal,
+	}
+
+	tests := []struct {
+		name   string
+		format string
+		font   *Font
+		want   string
+	}{
+		{"elements", `%{family}: %{style}\n`, font, "DejaVu Sans: Bold Oblique\n"},
+		{"fclist", "%{=fclist}", font, "/usr/share/fonts/dejavu/DejaVuSans-BoldOblique.ttf: DejaVu Sans:style=Bold Oblique"},
+		{"fcmatch", "%{=fcmatch}", font, `DejaVuSans-BoldOblique.ttf: "DejaVu Sans" "Bold Oblique"`},
+		{
+			"unparse", "%{=unparse}", font,
+			"DejaVu Sans:style=Bold Oblique:weight=200:slant=110:width=100:file=/usr/share/fonts/dejavu/DejaVuSans-BoldOblique.ttf:index=0",
+		},
+		{"regular style", "%{fullname}|%{style}|%{slant}|%{weight}", regular, "Fixed|Regular|0|80"},
+		{"default value", "%{width:-normal}|%{foundry:-none}", regular, "normal|none"},
+		{"unknown element", "[%{unknown}]", font, "[]"},
+		{"left alignment", "%-6{index}|", regular, "2     |"},
+		{"right alignment", "%6{index}|", regular, "     2|"},
+		{"short width", "%3{family}", font, "DejaVu Sans"},
+		{"basename", "%{file|basename}", regular, "6x13.pcf.gz"},
+		{"dirname", "%{file|dirname}", regular, "/usr/share/fonts/X11/misc"},
+		{"case converters", "%{family|upcase} %{family|downcase}", font, "DEJAVU SANS dejavu sans"},
+		{"escapes", `100%%\t\\\x`, font, "100%\t\\x"},
+		{"trailing backslash", `a\`, font, `a\`},
+		{"unterminated element", "%{family", font, "%{family"},
+		{"missing element", "50% %d", font, "50% %d"},
+	}
+
+	for _, test := range tests {
+		if got := FormatFont(test.format, test.font); got != test.want {
+			t.Errorf("%s: got %q, want %q", test.name, got, test.want)
+		}
+	}
+}
+
+func TestFontconfigWeights(t *testing.T) {
+	tests := []struct {
+		fcWeight float64
+		weight   int
+	}{
+		{-10, 100},
+		{0, 100},
+		{50, 300},
+		{80, 400},
+		{90, 450},
+		{200, 700},
+		{215, 1000},
+		{300, 1000},
+	}
+
+	for _, test := range tests {
+		if weight := fcWeightToWeight(test.fcWeight); weight != test.weight {
+			t.Errorf("fcWeightToWeight(%v): got %d, want %d", test.fcWeight, weight, test.weight)
+		}
+		if test.fcWeight < 0 || test.fcWeight > 215 {
+			continue
+		}
+		if fcWeight := weightToFCWeight(test.weight); fcWeight != int(test.fcWeight) {
+			t.Errorf("weightToFCWeight(%d): got %d, want %v", test.weight, fcWeight, test.fcWeight)
+		}
+	}
+}
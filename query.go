@@ -0,0 +1,216 @@
+package sysfont
+
+import "strings"
+
+// Query contains the attributes used for matching installed fonts.
+type Query struct {
+	// Family contains the requested font families, in order of preference.
+	// Families containing the * and ? wildcards (e.g. helv*) only match
+	// fonts whose family matches the pattern.
+	Family []string
+
+	// Weight contains the requested font weight, in the 1-1000 range.
+	// If it is 0, fonts with normal weight are preferred.
+	Weight int
+
+	// Style contains the requested font slant.
+	Style Style
+
+	// ObliqueAngle contains the requested oblique angle in degrees, using
+	// the CSS convention (positive values lean to the right). It is only used
+	// by CSS matching, for oblique styles. If it is 0, an angle of 14 degrees
+	// is used.
+	ObliqueAngle float64
+
+	// Stretch contains the requested font stretch, in the 1-9 range.
+	// If it is 0, fonts with normal stretch are preferred.
+	Stretch int
+
+	// PixelSize contains the requested size of bitmap fonts, in pixels.
+	// Bitmap fonts of other sizes are excluded, while scalable fonts match
+	// any size. If it is 0, fonts of any size are matched.
+	PixelSize int
+
+	// Charset contains the requested X11 character set, as registry and
+	// encoding (e.g. iso8859-1). It can contain the * and ? wildcards.
+	// Fonts whose X11 font names specify other character sets are excluded,
+	// while fonts without X11 font names match any character set. If it is
+	// empty, fonts with any character set are matched.
+	Charset string
+}
+
+// Candidate represents an installed font scored against a query.
+type Candidate struct {
+	// Font contains the scored font.
+	Font *Font
+
+	// FamilyScore contains the similarity between the font family and the
+	// query family, in the 0-1 range.
+	FamilyScore float64
+
+	// StyleScore contains the similarity between the style attributes of
+	// the font and the ones of the query, in the 0-1 range.
+	StyleScore float64
+
+	// Score contains the combined score of the font. The style score is
+	// taken into account only if the family score is at least 0.85. Fonts
+	// with a combined score of at least 0.9 are considered matches.
+	Score float64
+
+	// matched specifies whether the font matches one of the query families.
+	matched bool
+
+	// rank contains the index of the query family matched by the font.
+	rank int
+}
+
+// MatchSource specifies how the font returned by a match was found.
+type MatchSource int
+
+// Match sources.
+const (
+	// SourceExact specifies that the font matches one of the query families.
+	SourceExact MatchSource = iota
+
+	// SourceAlias specifies that the font belongs to one of the families
+	// which a generic query family (e.g. FamilySansSerif) resolves to.
+	SourceAlias
+
+	// SourceAlternative specifies that none of the query families are
+	// installed and the font was found in an alternative family group.
+	SourceAlternative
+
+	// SourceDefault specifies that no alternatives were found for the query
+	// families and the font belongs to one of the default families.
+	SourceDefault
+)
+
+// String returns the name of the match source.
+func (s MatchSource) String() string {
+	switch s {
+	case SourceAlias:
+		return "alias"
+	case SourceAlternative:
+		return "alternative"
+	case SourceDefault:
+		return "default"
+	default:
+		return "exact"
+	}
+}
+
+// MatchResult contains a matched font, along with the reason it was selected.
+type MatchResult struct {
+	// Font contains the matched font.
+	Font *Font
+
+	// Source specifies how the font was found.
+	Source MatchSource
+
+	// Group contains the families which were searched for finding the font,
+	// in order of preference. For alias matches, it contains the families
+	// of the generic query family. For alternative and default matches, it
+	// contains the alternative or the default families. It is empty for
+	// exact matches.
+	Group []string
+
+	// Score contains the score of the font. For exact and alias matches, it
+	// contains the combined score of the font, as described by the Candidate
+	// type. Otherwise, it contains the similarity between the style
+	// attributes of the font and the ones of the query, in the 0-1 range.
+	Score float64
+}
+
+// normalize returns a copy of the query with unspecified attributes replaced
+// by their normal values.
+func (q Query) normalize() Query {
+	if q.Weight <= 0 {
+		q.Weight = WeightNormal
+	}
+	if q.Stretch <= 0 {
+		q.Stretch = StretchNormal
+	}
+
+	return q
+}
+
+// filterFonts returns the specified fonts which satisfy the pixel size and
+// the character set of the query.
+func (q Query) filterFonts(fonts []*Font) []*Font {
+	if q.PixelSize <= 0 && q.Charset == "" {
+		return fonts
+	}
+
+	filtered := make([]*Font, 0, len(fonts))
+	for _, font := range fonts {
+		if q.PixelSize > 0 && font.PixelSize > 0 && font.PixelSize != q.PixelSize {
+			continue
+		}
+		if charset := xlfdCharset(font.XLFD); q.Charset != "" && charset != "" &&
+			!matchXLFDPattern(q.Charset, charset) {
+			continue
+		}
+
+		filtered = append(filtered, font)
+	}
+
+	return filtered
+}
+
+// parseQuery extracts the font family and style attributes from the
+// specified string query. X logical font descriptions are parsed into their
+// fields, while queries containing colons are parsed as fontconfig patterns,
+// if possible.
+func parseQuery(query string) Query {
+	if q, ok := parseXLFDQuery(query); ok {
+		return q
+	}
+	if strings.IndexByte(query, ':') >= 0 {
+		if q, err := ParsePattern(query); err == nil {
+			return q
+		}
+	}
+
+	weight, stretch, style := parseStyle(query)
+
+	return Query{
+		Family:  []string{extractFamily(query)},
+		Weight:  weight,
+		Style:   style,
+		Stretch: stretch,
+	}
+}
+
+// preferCandidate moves the candidate of the specified font to the front of
+// the candidate list, keeping its scores. The order of the other candidates
+// is preserved. If the font is not a candidate, the list is not changed.
+func preferCandidate(candidates []*Candidate, font *Font) []*Candidate {
+	for i, candidate := range candidates {
+		if candidate.Font != font {
+			continue
+		}
+
+		copy(candidates[1:i+1], candidates[:i])
+		candidates[0] = candidate
+		break
+	}
+
+	return candidates
+}
+
+// cloneCandidates returns copies of the first n specified candidates. If n
+// is less than or equal to 0, all candidates are returned.
+func cloneCandidates(candidates []*Candidate, n int) []*Candidate {
+	if n > 0 && n < len(candidates) {
+		candidates = candidates[:n]
+	}
+
+	clones := make([]*Candidate, len(candidates))
+	for i, candidate := range candidates {
+		clone := *candidate
+		clone.Font = candidate.Font.clone()
+		clones[i] = &clone
+	}
+
+	return clones
+}
@@ -6,7 +6,13 @@ user queries. The matching process also suggests viable font alternatives.
 package sysfont
 
 import (
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/adrg/strutil"
@@ -21,8 +27,74 @@ type Font struct {
 	// Name contains the full name of the font.
 	Name string
 
-	// Filename contains the path of the font file.
+	// Filename contains the path of the font file. For fonts found in the
+	// file system specified through the FS field of FinderOpts, it contains
+	// the path of the file inside the file system.
 	Filename string
+
+	// MetricsFilename contains the path of the font metrics file (e.g.
+	// .afm, .pfm) associated with the font. It is only set for Type 1 fonts
+	// which have a metrics file next to the font file.
+	MetricsFilename string
+
+	// FS contains the file system in which the font file was found. It is
+	// nil for fonts found in the OS file system.
+	FS fs.FS `json:"-"`
+
+	// Index contains the index of the font inside a font collection file
+	// (e.g. .ttc, .otc). For files containing a single font, it is 0.
+	Index int
+
+	// Container contains the container format of the font file
+	// (e.g. WOFF, WOFF2). It is ContainerNone for regular font files.
+	Container FontContainer
+
+	// Weight contains the visual weight of the font, in the 1-1000 range
+	// (e.g. 400 for normal fonts, 700 for bold fonts).
+	Weight int
+
+	// Stretch contains the relative width of the font, in the 1-9 range
+	// (e.g. 3 for condensed fonts, 5 for normal fonts).
+	Stretch int
+
+	// Style contains the slant of the font (normal, italic or oblique).
+	Style Style
+
+	// ItalicAngle contains the slant angle of the font, in counter-clockwise
+	// degrees from the vertical. Fonts which lean to the right have negative
+	// values. It is 0 for upright fonts or if the angle is unknown.
+	ItalicAngle float64
+
+	// PixelSize contains the size of bitmap fonts (e.g. PCF, BDF), in
+	// pixels. It is 0 for scalable fonts.
+	PixelSize int
+
+	// XLFD contains the X logical font description of the font (e.g.
+	// -misc-fixed-medium-r-normal--13-120-75-75-c-70-iso8859-1). It is set
+	// for bitmap fonts and for fonts listed in fonts.dir files.
+	XLFD string
+
+	// Aliases contains the names assigned to the font by fonts.alias files.
+	Aliases []string
+
+	// coverage contains the characters supported by the font.
+	coverage coverage
+}
+
+// Covers reports whether the font contains a glyph for the specified
+// character. If the font coverage is unknown (e.g. the font file could not
+// be read), false is returned.
+func (f *Font) Covers(r rune) bool {
+	return f.coverage.contains(r)
+}
+
+// Open opens the font file, from the file system in which it was found.
+func (f *Font) Open() (fs.File, error) {
+	if f.FS != nil {
+		return f.FS.Open(f.Filename)
+	}
+
+	return os.Open(f.Filename)
 }
 
 // clone returns a duplicate of the current font instance.
@@ -32,18 +104,238 @@ func (f *Font) clone() *Font {
 	}
 
 	font := *f
+	font.Aliases = append([]string(nil), f.Aliases...)
 	return &font
 }
 
-type registry struct {
-	fonts        []*Font
+// registryFont represents a font definition in the font registry.
+type registryFont struct {
+	Family   string `json:"family"`
+	Name     string `json:"name"`
+	Filename string `json:"filename"`
+}
+
+// font returns a font based on the registry definition. The font style
+// attributes are extracted from the font name.
+func (f *registryFont) font() *Font {
+	weight, stretch, style := parseStyle(f.Name)
+
+	return &Font{
+		Family:   f.Family,
+		Name:     f.Name,
+		Filename: f.Filename,
+		Weight:   weight,
+		Stretch:  stretch,
+		Style:    style,
+	}
+}
+
+// Registry contains the font definitions used for identifying font files
+// which cannot be read, along with the alternative font families and the
+// default font families used for substituting missing fonts. The built-in
+// registry is used by finders, unless a different one is specified through
+// the Registry field of FinderOpts.
+type Registry struct {
+	fonts        []*registryFont
 	families     map[string][]*Font
 	filenames    map[string][]*Font
 	alternatives [][]string
 	defaults     []string
 }
 
-func (r *registry) matchFontsByFilename(filename string) []*Font {
+// registryData represents the contents of a registry definition file.
+type registryData struct {
+	Fonts        []*registryFont `json:"fonts"`
+	Alternatives [][]string      `json:"alternatives"`
+	Defaults     []string        `json:"defaults"`
+}
+
+// NewRegistry returns an empty font registry.
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// DefaultRegistry returns a copy of the built-in font registry. The returned
+// registry can be extended with custom font definitions and alternatives.
+func DefaultRegistry() *Registry {
+	return fontRegistry.clone()
+}
+
+// LoadRegistry returns a copy of the built-in font registry, extended with
+// the definitions read from the specified JSON file. See the Load method for
+// details regarding the file format.
+func LoadRegistry(filename string) (*Registry, error) {
+	reg := DefaultRegistry()
+	if err := reg.Load(filename); err != nil {
+		return nil, err
+	}
+
+	return reg, nil
+}
+
+// AddFont adds a font definition to the registry. The font file is
+// identified by its base name (e.g. Arial.ttf) and the style attributes of
+// the font are extracted from its full name (e.g. Arial Bold Italic).
+func (r *Registry) AddFont(family, name, filename string) {
+	regFont := &registryFont{Family: family, Name: name, Filename: filename}
+
+	r.fonts = append(r.fonts, regFont)
+	r.index(regFont)
+}
+
+// AddAlternatives adds a group of font families which can be used in place
+// of each other, in order of preference. The added group takes priority over
+// the existing groups of the registry.
+func (r *Registry) AddAlternatives(families ...string) {
+	if len(families) == 0 {
+		return
+	}
+
+	r.addAlternatives([][]string{append([]string(nil), families...)})
+}
+
+// SetDefaults sets the font families used for substituting missing fonts
+// which have no alternatives, in order of preference.
+func (r *Registry) SetDefaults(families ...string) {
+	r.defaults = append([]string(nil), families...)
+}
+
+// Load reads registry definitions from the specified JSON file and adds them
+// to the registry. The file contains an object with the following optional
+// fields: fonts, an array of font definitions having the family, name and
+// filename fields; alternatives, an array of alternative family groups,
+// which take priority over the existing ones; defaults, an array of font
+// families which replaces the existing default families.
+//
+//	{
+//	  "fonts": [
+//	    {"family": "Acme Sans", "name": "Acme Sans Bold", "filename": "AcmeSans-Bold.ttf"}
+//	  ],
+//	  "alternatives": [["Acme Sans", "Helvetica", "Arial"]],
+//	  "defaults": ["Acme Sans", "Arial"]
+//	}
+func (r *Registry) Load(filename string) error {
+	f, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err := r.Decode(f); err != nil {
+		return &os.PathError{Op: "parse", Path: filename, Err: err}
+	}
+
+	return nil
+}
+
+// Decode reads JSON registry definitions from the specified reader and adds
+// them to the registry. The expected format is described by the Load method.
+func (r *Registry) Decode(reader io.Reader) error {
+	var data registryData
+	if err := json.NewDecoder(reader).Decode(&data); err != nil {
+		return err
+	}
+
+	// Validate definitions.
+	for _, regFont := range data.Fonts {
+		if regFont == nil || regFont.Family == "" || regFont.Filename == "" {
+			return errors.New("sysfont: font definitions require a family and a filename")
+		}
+	}
+
+	// Add definitions.
+	for _, regFont := range data.Fonts {
+		if regFont.Name == "" {
+			regFont.Name = regFont.Family
+		}
+		r.AddFont(regFont.Family, regFont.Name, regFont.Filename)
+	}
+
+	var groups [][]string
+	for _, group := range data.Alternatives {
+		if len(group) > 0 {
+			groups = append(groups, group)
+		}
+	}
+	r.addAlternatives(groups)
+
+	if len(data.Defaults) > 0 {
+		r.SetDefaults(data.Defaults...)
+	}
+
+	return nil
+}
+
+// index adds the specified font definition to the registry lookup maps.
+func (r *Registry) index(regFont *registryFont) {
+	if r.families == nil {
+		r.families = map[string][]*Font{}
+	}
+	if r.filenames == nil {
+		r.filenames = map[string][]*Font{}
+	}
+
+	font := regFont.font()
+	r.families[font.Family] = append(r.families[font.Family], font)
+
+	filename := strings.ToLower(filepath.Base(font.Filename))
+	r.filenames[filename] = append(r.filenames[filename], font)
+}
+
+func (r *Registry) addAlternatives(groups [][]string) {
+	alternatives := make([][]string, 0, len(groups)+len(r.alternatives))
+	alternatives = append(alternatives, groups...)
+	r.alternatives = append(alternatives, r.alternatives...)
+}
+
+// clone returns a copy of the registry, which does not share any mutable
+// data with the original.
+func (r *Registry) clone() *Registry {
+	reg := &Registry{
+		fonts:        make([]*registryFont, len(r.fonts)),
+		families:     make(map[string][]*Font, len(r.families)),
+		filenames:    make(map[string][]*Font, len(r.filenames)),
+		alternatives: make([][]string, len(r.alternatives)),
+		defaults:     append([]string(nil), r.defaults...),
+	}
+
+	for i, regFont := range r.fonts {
+		f := *regFont
+		reg.fonts[i] = &f
+	}
+	for family, fonts := range r.families {
+		reg.families[family] = cloneFonts(fonts)
+	}
+	for filename, fonts := range r.filenames {
+		reg.filenames[filename] = cloneFonts(fonts)
+	}
+	for i, group := range r.alternatives {
+		reg.alternatives[i] = append([]string(nil), group...)
+	}
+
+	return reg
+}
+
+// cloneFonts returns a copy of the specified fonts.
+func cloneFonts(fonts []*Font) []*Font {
+	clones := make([]*Font, len(fonts))
+	for i, font := range fonts {
+		clones[i] = font.clone()
+	}
+
+	return clones
+}
+
+// withAlternatives returns a copy of the registry which uses the specified
+// groups of alternative font families, with priority over the existing ones.
+func (r *Registry) withAlternatives(groups [][]string) *Registry {
+	reg := *r
+	reg.addAlternatives(groups)
+
+	return &reg
+}
+
+func (r *Registry) matchFontsByFilename(filename string) []*Font {
 	// Attempt to identify font filename in the registry.
 	if fonts := r.fontsByFilename(filename); len(fonts) > 0 {
 		return fonts
@@ -51,7 +343,8 @@ func (r *registry) matchFontsByFilename(filename string) []*Font {
 
 	// Identify font family.
 	basename := filepath.Base(filename)
-	query := cleanQuery(strings.TrimSuffix(basename, filepath.Ext(basename)))
+	name := strings.TrimSuffix(basename, filepath.Ext(basename))
+	query := cleanQuery(name)
 
 	queryFamily, ok := r.matchFamily(query)
 	if !ok {
@@ -59,7 +352,7 @@ func (r *registry) matchFontsByFilename(filename string) []*Font {
 	}
 
 	// Attempt to identify font by filename and the extracted family.
-	match := r.matchFont(query, r.families[queryFamily])
+	match := r.matchFont(name, r.families[queryFamily])
 	if match == nil {
 		return nil
 	}
@@ -80,7 +373,7 @@ func (r *registry) matchFontsByFilename(filename string) []*Font {
 	return fonts
 }
 
-func (r *registry) fontsByFilename(filename string) []*Font {
+func (r *Registry) fontsByFilename(filename string) []*Font {
 	regFonts, ok := r.filenames[strings.ToLower(filepath.Base(filename))]
 	if !ok {
 		return nil
@@ -88,17 +381,14 @@ func (r *registry) fontsByFilename(filename string) []*Font {
 
 	fonts := make([]*Font, len(regFonts))
 	for i, regFont := range regFonts {
-		fonts[i] = &Font{
-			Name:     regFont.Name,
-			Family:   regFont.Family,
-			Filename: filename,
-		}
+		fonts[i] = regFont.clone()
+		fonts[i].Filename = filename
 	}
 
 	return fonts
 }
 
-func (r *registry) matchFamily(query string) (string, bool) {
+func (r *Registry) matchFamily(query string) (string, bool) {
 	// Extract font family from query.
 	queryFamily := extractFamily(query)
 
@@ -120,63 +410,166 @@ func (r *registry) matchFamily(query string) (string, bool) {
 	return queryFamily, false
 }
 
-func (r *registry) matchFont(query string, fonts []*Font) *Font {
-	// Clean input and extract font family.
-	query = cleanQuery(query)
-	queryFamily := extractFamily(query)
+func (r *Registry) matchFont(query string, fonts []*Font) *Font {
+	return r.matchQuery(parseQuery(query).normalize(), fonts)
+}
 
-	// Attempt to match font.
-	var maxScore float64
-	var maxScoreFont *Font
+func (r *Registry) matchQuery(query Query, fonts []*Font) *Font {
+	candidate := r.matchCandidate(query, nil, fonts)
+	if candidate == nil {
+		return nil
+	}
 
-	for _, font := range fonts {
-		score := getFamilyScore(queryFamily, font.Family)
-		if score >= 0.85 {
-			score += getFontStyleScore(query, font.Name)
+	return candidate.Font
+}
+
+// matchCandidate returns the best candidate for the specified query, if it
+// matches one of the query families.
+func (r *Registry) matchCandidate(query Query, groups [][]string, fonts []*Font) *Candidate {
+	candidates := r.scoreQuery(query, groups, fonts, false)
+	if len(candidates) == 0 || !candidates[0].matched {
+		return nil
+	}
+
+	return candidates[0]
+}
+
+// scoreQuery scores the specified fonts based on the query and returns them
+// in order of preference. The families of the query are searched in order.
+// Fonts which closely match an earlier query family are preferred over
+// fonts which match later families. The remaining fonts are sorted by their
+// best score. In strict mode, fonts are considered matches only if their
+// family is the same as one of the query families. Query families which
+// are the result of a generic family expansion, reported by a non-nil
+// preference list in the specified groups, only match fonts of the same
+// family.
+func (r *Registry) scoreQuery(query Query, groups [][]string, fonts []*Font, strict bool) []*Candidate {
+	// Clean input families.
+	var valid bool
+	queryFamilies := make([]string, len(query.Family))
+	for i, family := range query.Family {
+		if isXLFDPattern(family) {
+			queryFamilies[i] = strings.ToLower(strings.TrimSpace(family))
+		} else {
+			queryFamilies[i] = cleanQuery(family)
 		}
-		if score > maxScore {
-			maxScore = score
-			maxScoreFont = font
+		if queryFamilies[i] != "" {
+			valid = true
 		}
 	}
+	if !valid {
+		return nil
+	}
+
+	// Score fonts.
+	candidates := make([]*Candidate, 0, len(fonts))
+	for _, font := range fonts {
+		candidate := &Candidate{Font: font}
+		styleScore := getStyleScore(query, font)
+
+		for i, queryFamily := range queryFamilies {
+			if queryFamily == "" {
+				continue
+			}
+			familyScore := getFamilyScore(queryFamily, font.Family)
+
+			score := familyScore
+			if familyScore >= 0.85 {
+				score += styleScore
+			}
+
+			// Strict matching allows minor family variations (e.g. ArialMT),
+			// but rejects different families with a common prefix (e.g.
+			// Helvetica for Helvetica Neue).
+			matched := score >= 0.9 && (!strict || sameFamily(queryFamily, font.Family))
+			if i < len(groups) && groups[i] != nil {
+				matched = matched && familyScore == 1
+			}
+			if matched || score > candidate.Score {
+				candidate.FamilyScore = familyScore
+				candidate.StyleScore = styleScore
+				candidate.Score = score
+			}
+			if matched {
+				candidate.matched = true
+				candidate.rank = i
+				break
+			}
+		}
 
-	if maxScore >= 0.9 {
-		return maxScoreFont
+		candidates = append(candidates, candidate)
 	}
 
-	return nil
-}
+	// Sort candidates by query family preference and score.
+	sort.SliceStable(candidates, func(i, j int) bool {
+		ci, cj := candidates[i], candidates[j]
+		if ci.matched != cj.matched {
+			return ci.matched
+		}
+		if ci.rank != cj.rank {
+			return ci.rank < cj.rank
+		}
+		return ci.Score > cj.Score
+	})
 
-func (r *registry) getAlternatives(queryFamily string, fonts []*Font) []*Font {
-	// Match font family.
-	queryFamily = strings.ToLower(queryFamily)
+	return candidates
+}
 
-	// Find alternative font families for the extracted family.
+// getAlternatives returns the installed fonts which can be used in place of
+// the query families, along with the alternative families used for finding
+// them. If no alternative families are found for the query families, the
+// default families are used, which is reported by the last return value.
+func (r *Registry) getAlternatives(queryFamilies []string, fonts []*Font) ([]*Font, []string, bool) {
+	// Find alternative font families for the first query family which
+	// has any.
 	var families []string
-	for _, familyGroup := range r.alternatives {
-		for _, family := range familyGroup {
-			if queryFamily == strings.ToLower(family) {
-				families = append(families, familyGroup...)
-				break
+	for _, queryFamily := range queryFamilies {
+		queryFamily = strings.ToLower(queryFamily)
+
+		for _, familyGroup := range r.alternatives {
+			for _, family := range familyGroup {
+				if queryFamily == strings.ToLower(family) {
+					families = append(families, familyGroup...)
+					break
+				}
 			}
 		}
+		if len(families) > 0 {
+			break
+		}
 	}
 
 	// If no alternatives are found, use default families.
+	var defaults bool
 	if len(families) == 0 {
 		families = r.defaults
+		defaults = true
+	}
+
+	// Match alternative fonts by family. If none of the alternative
+	// families are installed, use default families.
+	alternatives := matchFamilyFonts(families, fonts)
+	if len(alternatives) == 0 && !defaults {
+		families = r.defaults
+		defaults = true
+		alternatives = matchFamilyFonts(families, fonts)
 	}
 
-	// Match alternative fonts by family.
-	var alternatives []*Font
+	return alternatives, families, defaults
+}
+
+// matchFamilyFonts returns the fonts which belong to the specified families,
+// in the order of the families.
+func matchFamilyFonts(families []string, fonts []*Font) []*Font {
+	var matches []*Font
 	for _, family := range families {
 		family = strings.ToLower(family)
 		for _, font := range fonts {
 			if family == strings.ToLower(font.Family) {
-				alternatives = append(alternatives, font)
+				matches = append(matches, font)
 			}
 		}
 	}
 
-	return alternatives
+	return matches
 }
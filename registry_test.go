@@ -0,0 +1,169 @@
+package sysfont
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestRegistryDecode(t *testing.T) {
+	tests := []struct {
+		name         string
+		data         string
+		fonts        map[string]string
+		alternatives [][]string
+		defaults     []string
+		err          bool
+	}{
+		{
+			name: "definitions",
+			data: `{
+				"fonts": [
+					{"family": "Acme Sans", "name": "Acme Sans Bold", "filename": "AcmeSans-Bold.ttf"},
+					{"family": "Acme Mono", "filename": "AcmeMono.ttf"}
+				],
+				"alternatives": [["Acme Sans", "Helvetica"], [], ["Acme Mono", "Courier"]],
+				"defaults": ["Acme Sans", "Arial"]
+			}`,
+			fonts: map[string]string{
+				"AcmeSans-Bold.ttf": "Acme Sans Bold",
+				"AcmeMono.ttf":      "Acme Mono",
+			},
+			alternatives: [][]string{
+				{"Acme Sans", "Helvetica"},
+				{"Acme Mono", "Courier"},
+				{"Arial", "Helvetica"},
+			},
+			defaults: []string{"Acme Sans", "Arial"},
+		},
+		{
+			name:         "empty definitions",
+			data:         `{"defaults": []}`,
+			alternatives: [][]string{{"Arial", "Helvetica"}},
+			defaults:     []string{"Arial"},
+		},
+		{name: "missing family", data: `{"fonts": [{"name": "Acme", "filename": "Acme.ttf"}]}`, err: true},
+		{name: "missing filename", data: `{"fonts": [{"family": "Acme"}]}`, err: true},
+		{name: "null font", data: `{"fonts": [null]}`, err: true},
+		{name: "invalid json", data: `{"fonts": [`, err: true},
+		{
+			name: "invalid definition after valid ones",
+			data: `{
+				"fonts": [{"family": "Acme", "filename": "Acme.ttf"}, {"family": "Acme Bold"}],
+				"alternatives": [["Acme", "Arial"]],
+				"defaults": ["Acme"]
+			}`,
+			err: true,
+		},
+	}
+
+	for _, test := range tests {
+		reg := NewRegistry()
+		reg.AddAlternatives("Arial", "Helvetica")
+		reg.SetDefaults("Arial")
+
+		err := reg.Decode(strings.NewReader(test.data))
+		if (err != nil) != test.err {
+			t.Errorf("%s: got error %v, want error %t", test.name, err, test.err)
+			continue
+		}
+		if err != nil {
+			// Rejected definitions leave the registry unchanged.
+			if len(reg.fonts) != 0 || len(reg.alternatives) != 1 || !reflect.DeepEqual(reg.defaults, []string{"Arial"}) {
+				t.Errorf("%s: registry changed by invalid definitions", test.name)
+			}
+			continue
+		}
+
+		for filename, name := range test.fonts {
+			fonts := reg.fontsByFilename(filename)
+			if len(fonts) != 1 || fonts[0].Name != name {
+				t.Errorf("%s: got fonts %v for %q, want %q", test.name, fonts, filename, name)
+			}
+		}
+		if len(reg.fonts) != len(test.fonts) {
+			t.Errorf("%s: got %d fonts, want %d", test.name, len(reg.fonts), len(test.fonts))
+		}
+		if !reflect.DeepEqual(reg.alternatives, test.alternatives) {
+			t.Errorf("%s: got alternatives %q, want %q", test.name, reg.alternatives, test.alternatives)
+		}
+		if !reflect.DeepEqual(reg.defaults, test.defaults) {
+			t.Errorf("%s: got defaults %q, want %q", test.name, reg.defaults, test.defaults)
+		}
+	}
+}
+
+func TestRegistryAddAlternatives(t *testing.T) {
+	fonts := []*Font{
+		{Family: "Helvetica", Name: "Helvetica"},
+		{Family: "Liberation Sans", Name: "Liberation Sans"},
+		{Family: "DejaVu Sans", Name: "DejaVu Sans"},
+	}
+
+	reg := NewRegistry()
+	reg.SetDefaults("DejaVu Sans")
+	reg.AddAlternatives()
+	reg.AddAlternatives("Arial", "Helvetica")
+
+	group := []string{"Arial", "Liberation Sans"}
+	reg.AddAlternatives(group...)
+	group[1] = "Changed"
+
+	// The last added group takes priority.
+	alternatives, families, defaults := reg.getAlternatives([]string{"Arial"}, fonts)
+	if defaults || len(alternatives) == 0 || alternatives[0].Family != "Liberation Sans" {
+		t.Errorf("got alternatives %v, want Liberation Sans first", alternatives)
+	}
+	if want := []string{"Arial", "Liberation Sans", "Arial", "Helvetica"}; !reflect.DeepEqual(families, want) {
+		t.Errorf("got families %q, want %q", families, want)
+	}
+
+	// Queries without alternatives use the default families.
+	alternatives, families, defaults = reg.getAlternatives([]string{"Verdana"}, fonts)
+	if !defaults || len(alternatives) != 1 || alternatives[0].Family != "DejaVu Sans" || !reflect.DeepEqual(families, []string{"DejaVu Sans"}) {
+		t.Errorf("got alternatives %v from families %q, want default DejaVu Sans", alternatives, families)
+	}
+}
+
+func TestRegistryClone(t *testing.T) {
+	reg := NewRegistry()
+	reg.AddFont("Acme", "Acme Bold", "Acme-Bold.ttf")
+	reg.AddAlternatives("Acme", "Arial")
+	reg.SetDefaults("Arial")
+
+	finder := NewFinder(&FinderOpts{FS: fstest.MapFS{}, Registry: reg})
+
+	// Changes made to the registry do not affect the finder.
+	reg.AddFont("Acme", "Acme Italic", "Acme-Italic.ttf")
+	reg.AddAlternatives("Acme", "Helvetica")
+	reg.SetDefaults("Helvetica")
+	reg.alternatives[1][1] = "Changed"
+	reg.fonts[0].Name = "Changed"
+	reg.families["Acme"][0].Name = "Changed"
+
+	clone := finder.registry
+	if len(clone.fonts) != 1 || clone.fonts[0].Name != "Acme Bold" {
+		t.Errorf("got cloned fonts %v", clone.fonts)
+	}
+	if fonts := clone.fontsByFilename("Acme-Bold.ttf"); len(fonts) != 1 || fonts[0].Name != "Acme Bold" {
+		t.Errorf("got cloned fonts %v for Acme-Bold.ttf", fonts)
+	}
+	if fonts := clone.fontsByFilename("Acme-Italic.ttf"); len(fonts) != 0 {
+		t.Errorf("got cloned fonts %v for Acme-Italic.ttf", fonts)
+	}
+	if !reflect.DeepEqual(clone.alternatives, [][]string{{"Acme", "Arial"}}) {
+		t.Errorf("got cloned alternatives %q", clone.alternatives)
+	}
+	if !reflect.DeepEqual(clone.defaults, []string{"Arial"}) {
+		t.Errorf("got cloned defaults %q", clone.defaults)
+	}
+
+	// The built-in registry is not affected by changes to its copies.
+	builtin := DefaultRegistry()
+	builtin.alternatives[0][0] = "Changed"
+	builtin.SetDefaults()
+	if fontRegistry.alternatives[0][0] == "Changed" || len(fontRegistry.defaults) == 0 {
+		t.Error("built-in registry changed by its copy")
+	}
+}
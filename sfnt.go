@@ -0,0 +1,485 @@
+package sysfont
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"strings"
+	"unicode/utf16"
+)
+
+// Font file signatures.
+const (
+	sfntVersionTrueType = 0x00010000 // TrueType outlines.
+	sfntVersionOpenType = 0x4F54544F // 'OTTO': CFF outlines.
+	sfntVersionApple    = 0x74727565 // 'true': Apple TrueType.
+	sfntVersionType1    = 0x74797031 // 'typ1': Apple Type 1 wrapper.
+	sfntCollection      = 0x74746366 // 'ttcf': font collection.
+)
+
+// Name table identifiers.
+const (
+	nameFamily            = 1
+	nameSubfamily         = 2
+	nameFull              = 4
+	namePostScript        = 6
+	nameTypographicFamily = 16
+	nameTypographicSub    = 17
+)
+
+var (
+	errInvalidFont     = errors.New("sysfont: invalid font data")
+	errUnsupportedFont = errors.New("sysfont: unsupported font format")
+	errMissingTable    = errors.New("sysfont: missing font table")
+)
+
+// sfntTable represents an entry of an sfnt table directory.
+type sfntTable struct {
+	offset uint32
+	length uint32
+}
+
+// sfntReader provides access to the tables of an sfnt font. Tables which
+// extend past the size of the font data are rejected.
+type sfntReader struct {
+	r      io.ReaderAt
+	size   int64
+	tables map[string]sfntTable
+}
+
+func newSFNTReader(r io.ReaderAt, offset, size int64) (*sfntReader, error) {
+	// Read offset table.
+	var header [12]byte
+	if _, err := r.ReadAt(header[:], offset); err != nil {
+		return nil, errInvalidFont
+	}
+
+	switch binary.BigEndian.Uint32(header[:]) {
+	case sfntVersionTrueType, sfntVersionOpenType, sfntVersionApple, sfntVersionType1:
+	default:
+		return nil, errUnsupportedFont
+	}
+
+	// Read table directory.
+	numTables := int(binary.BigEndian.Uint16(header[4:]))
+	if offset+12+int64(numTables)*16 > size {
+		return nil, errInvalidFont
+	}
+
+	records := make([]byte, numTables*16)
+	if _, err := r.ReadAt(records, offset+12); err != nil {
+		return nil, errInvalidFont
+	}
+
+	tables := make(map[string]sfntTable, numTables)
+	for i := 0; i < numTables; i++ {
+		record := records[i*16:]
+		tables[string(record[:4])] = sfntTable{
+			offset: binary.BigEndian.Uint32(record[8:]),
+			length: binary.BigEndian.Uint32(record[12:]),
+		}
+	}
+
+	return &sfntReader{r: r, size: size, tables: tables}, nil
+}
+
+func (s *sfntReader) table(tag string) ([]byte, error) {
+	table, ok := s.tables[tag]
+	if !ok {
+		return nil, errMissingTable
+	}
+	if int64(table.offset)+int64(table.length) > s.size {
+		return nil, errInvalidFont
+	}
+
+	data := make([]byte, table.length)
+	if _, err := s.r.ReadAt(data, int64(table.offset)); err != nil {
+		return nil, errInvalidFont
+	}
+
+	return data, nil
+}
+
+// font identifies the font using the data found in its name table.
+func (s *sfntReader) font() (*Font, error) {
+	data, err := s.table("name")
+	if err != nil {
+		return nil, err
+	}
+
+	names, err := parseNameTable(data)
+	if err != nil {
+		return nil, err
+	}
+
+	// Identify font family.
+	family := names[nameTypographicFamily]
+	if family == "" {
+		family = names[nameFamily]
+	}
+
+	// Identify font name.
+	name := names[nameFull]
+	if name == "" {
+		subfamily := names[nameTypographicSub]
+		if subfamily == "" {
+			subfamily = names[nameSubfamily]
+		}
+		name = strings.TrimSpace(family + " " + subfamily)
+	}
+	if name == "" {
+		name = names[namePostScript]
+	}
+
+	if family == "" && name == "" {
+		return nil, errInvalidFont
+	}
+
+	// Identify font style attributes.
+	weight, stretch, style, italicAngle := s.style()
+
+	// Identify supported characters.
+	var cov coverage
+	if data, err := s.table("cmap"); err == nil {
+		cov, _ = parseCmapTable(data)
+	}
+
+	return &Font{
+		Family:      family,
+		Name:        name,
+		Weight:      weight,
+		Stretch:     stretch,
+		Style:       style,
+		ItalicAngle: italicAngle,
+		coverage:    cov,
+	}, nil
+}
+
+// style identifies the weight, stretch, style and italic angle of the font,
+// using the data found in its OS/2, post and head tables. Attributes which
+// cannot be identified have normal values.
+func (s *sfntReader) style() (int, int, Style, float64) {
+	weight, stretch, style := WeightNormal, StretchNormal, StyleNormal
+
+	// Read italic angle from the post table.
+	var italicAngle int32
+	if data, err := s.table("post"); err == nil && len(data) >= 8 {
+		italicAngle = int32(binary.BigEndian.Uint32(data[4:]))
+	}
+
+	// Read style attributes from the OS/2 table.
+	if data, err := s.table("OS/2"); err == nil && len(data) >= 64 {
+		if value := int(binary.BigEndian.Uint16(data[4:])); value > 0 {
+			// Some fonts use the 1-9 range for the weight class.
+			if value < 10 {
+				value *= 100
+			}
+			if value > 1000 {
+				value = 1000
+			}
+			weight = value
+		}
+		if value := int(binary.BigEndian.Uint16(data[6:])); value >= 1 && value <= 9 {
+			stretch = value
+		}
+
+		fsSelection := binary.BigEndian.Uint16(data[62:])
+		switch {
+		case fsSelection&0x0200 != 0:
+			style = StyleOblique
+		case fsSelection&0x0001 != 0:
+			style = StyleItalic
+		case italicAngle != 0:
+			style = StyleOblique
+		}
+
+		return weight, stretch, style, float64(italicAngle) / 65536
+	}
+
+	// Fall back to the style flags of the head table.
+	if data, err := s.table("head"); err == nil && len(data) >= 46 {
+		macStyle := binary.BigEndian.Uint16(data[44:])
+		if macStyle&0x0001 != 0 {
+			weight = WeightBold
+		}
+		if macStyle&0x0002 != 0 {
+			style = StyleItalic
+		}
+	}
+	if style == StyleNormal && italicAngle != 0 {
+		style = StyleOblique
+	}
+
+	return weight, stretch, style, float64(italicAngle) / 65536
+}
+
+// readFontFile identifies the fonts contained in the specified font file.
+// If the file system is nil, the file is read from the OS file system.
+// Font collection files produce a font for each face in the collection.
+// Type 1 font files are identified using their cleartext font dictionary.
+// Bitmap font files (e.g. PCF, BDF) are identified using their properties.
+func readFontFile(fsys fs.FS, filename string) ([]*Font, error) {
+	var f fs.File
+	var err error
+	if fsys == nil {
+		f, err = os.Open(filename)
+	} else {
+		f, err = fsys.Open(filename)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	// Read the whole file if it does not support random access.
+	var size int64
+	r, ok := f.(io.ReaderAt)
+	if ok {
+		info, err := f.Stat()
+		if err != nil {
+			return nil, err
+		}
+		size = info.Size()
+	} else {
+		data, err := io.ReadAll(f)
+		if err != nil {
+			return nil, err
+		}
+		r, size = bytes.NewReader(data), int64(len(data))
+	}
+
+	// Decompress gzip-compressed font files (e.g. .pcf.gz).
+	container := ContainerNone
+	if hasSignature(r, gzipSignature) {
+		data, err := readGzipData(r)
+		if err != nil {
+			return nil, err
+		}
+		r, size = data, data.Size()
+		container = ContainerGzip
+	}
+
+	var fonts []*Font
+	switch {
+	case isType1Font(r):
+		fonts, err = readType1File(fsys, filename, r)
+	case isBitmapFont(r):
+		fonts, err = readBitmapFont(r, size, filename)
+	default:
+		fonts, err = readFontData(r, size, filename)
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, font := range fonts {
+		font.FS = fsys
+		if container != ContainerNone {
+			font.Container = container
+		}
+	}
+
+	return fonts, nil
+}
+
+// readFontData identifies the fonts contained in the specified font data,
+// having the specified size. Web fonts are identified by reading the tables
+// of the wrapped sfnt fonts.
+func readFontData(r io.ReaderAt, size int64, filename string) ([]*Font, error) {
+	var signature [4]byte
+	if _, err := r.ReadAt(signature[:], 0); err != nil {
+		return nil, errInvalidFont
+	}
+
+	var readers []*sfntReader
+	var err error
+
+	container := ContainerNone
+	switch binary.BigEndian.Uint32(signature[:]) {
+	case woffSignature:
+		container = ContainerWOFF
+
+		var sr *sfntReader
+		if sr, err = newWOFFReader(r, size); err == nil {
+			readers = []*sfntReader{sr}
+		}
+	case woff2Signature:
+		container = ContainerWOFF2
+		readers, err = newWOFF2Readers(r, size)
+	default:
+		readers, err = newSFNTReaders(r, size)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var fonts []*Font
+	for i, sr := range readers {
+		font, ferr := sr.font()
+		if ferr != nil {
+			err = ferr
+			continue
+		}
+		font.Filename = filename
+		font.Index = i
+		font.Container = container
+
+		fonts = append(fonts, font)
+	}
+	if len(fonts) == 0 {
+		return nil, err
+	}
+
+	return fonts, nil
+}
+
+// newSFNTReaders returns a reader for each font contained in the specified
+// font data, having the specified size. Font collections contain multiple
+// fonts, while regular font files contain a single font.
+func newSFNTReaders(r io.ReaderAt, size int64) ([]*sfntReader, error) {
+	var header [12]byte
+	if _, err := r.ReadAt(header[:], 0); err != nil {
+		return nil, errInvalidFont
+	}
+
+	// Check for regular font files.
+	if binary.BigEndian.Uint32(header[:]) != sfntCollection {
+		sr, err := newSFNTReader(r, 0, size)
+		if err != nil {
+			return nil, err
+		}
+
+		return []*sfntReader{sr}, nil
+	}
+
+	// Read collection font offsets.
+	numFonts := int(binary.BigEndian.Uint32(header[8:]))
+	if numFonts == 0 || numFonts > 0xFFFF || 12+int64(numFonts)*4 > size {
+		return nil, errInvalidFont
+	}
+
+	offsets := make([]byte, numFonts*4)
+	if _, err := r.ReadAt(offsets, 12); err != nil {
+		return nil, errInvalidFont
+	}
+
+	readers := make([]*sfntReader, 0, numFonts)
+	for i := 0; i < numFonts; i++ {
+		sr, err := newSFNTReader(r, int64(binary.BigEndian.Uint32(offsets[i*4:])), size)
+		if err != nil {
+			return nil, err
+		}
+
+		readers = append(readers, sr)
+	}
+
+	return readers, nil
+}
+
+// parseNameTable extracts the strings of the name table, indexed by name
+// identifier. If a name is available in multiple languages or encodings,
+// the most suitable one is chosen, with a preference for English names.
+func parseNameTable(data []byte) (map[uint16]string, error) {
+	if len(data) < 6 {
+		return nil, errInvalidFont
+	}
+
+	count := int(binary.BigEndian.Uint16(data[2:]))
+	storage := int(binary.BigEndian.Uint16(data[4:]))
+	if len(data) < 6+count*12 {
+		return nil, errInvalidFont
+	}
+
+	names := map[uint16]string{}
+	scores := map[uint16]int{}
+
+	for i := 0; i < count; i++ {
+		record := data[6+i*12:]
+
+		platformID := binary.BigEndian.Uint16(record)
+		encodingID := binary.BigEndian.Uint16(record[2:])
+		languageID := binary.BigEndian.Uint16(record[4:])
+		nameID := binary.BigEndian.Uint16(record[6:])
+		length := int(binary.BigEndian.Uint16(record[8:]))
+		offset := storage + int(binary.BigEndian.Uint16(record[10:]))
+
+		// Skip unsuitable records.
+		score := getNameRecordScore(platformID, encodingID, languageID)
+		if score <= scores[nameID] || offset+length > len(data) {
+			continue
+		}
+
+		// Decode name.
+		var name string
+		if platformID == 1 {
+			name = decodeMacRoman(data[offset : offset+length])
+		} else {
+			name = decodeUTF16(data[offset : offset+length])
+		}
+
+		if name = strings.TrimSpace(name); name != "" {
+			names[nameID] = name
+			scores[nameID] = score
+		}
+	}
+
+	return names, nil
+}
+
+func getNameRecordScore(platformID, encodingID, languageID uint16) int {
+	switch platformID {
+	case 0:
+		// Unicode platform.
+		return 3
+	case 1:
+		// Macintosh platform. Only Roman encoding is supported.
+		if encodingID == 0 && languageID == 0 {
+			return 2
+		}
+	case 3:
+		// Windows platform. Symbol, BMP and full repertoire encodings
+		// are supported.
+		if encodingID != 0 && encodingID != 1 && encodingID != 10 {
+			return 0
+		}
+		if languageID == 0x0409 {
+			return 5
+		}
+		if languageID&0xFF == 0x09 {
+			return 4
+		}
+		return 1
+	}
+
+	return 0
+}
+
+func decodeUTF16(data []byte) string {
+	chars := make([]uint16, len(data)/2)
+	for i := range chars {
+		chars[i] = binary.BigEndian.Uint16(data[i*2:])
+	}
+
+	return string(utf16.Decode(chars))
+}
+
+// macRoman contains the characters of the Mac OS Roman encoding above 0x7F.
+var macRoman = []rune("ÄÅÇÉÑÖÜáàâäãåçéèêëíìîïñóòôöõúùûü" +
+	"†°¢£§•¶ß®©™´¨≠ÆØ∞±≤≥¥µ∂∑∏π∫ªºΩæø" +
+	"¿¡¬√ƒ≈∆«»… ÀÃÕŒœ–—“”‘’÷◊ÿŸ⁄€‹›ﬁﬂ" +
+	"‡·‚„‰ÂÊÁËÈÍÎÏÌÓÔÒÚÛÙıˆ˜¯˘˙˚¸˝˛ˇ")
+
+func decodeMacRoman(data []byte) string {
+	chars := make([]rune, len(data))
+	for i, c := range data {
+		if c < 0x80 {
+			chars[i] = rune(c)
+		} else {
+			chars[i] = macRoman[c-0x80]
+		}
+	}
+
+	return string(chars)
+}
@@ -0,0 +1,555 @@
+package sysfont
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"sort"
+	"testing"
+	"unicode/utf16"
+)
+
+// testNameRecord represents a record of a name table built for testing.
+type testNameRecord struct {
+	platformID uint16
+	encodingID uint16
+	languageID uint16
+	nameID     uint16
+	value      string
+}
+
+// buildNameTable returns a name table containing the specified records.
+// Macintosh names are stored as bytes, while the other names are encoded
+// using UTF-16.
+func buildNameTable(records ...testNameRecord) []byte {
+	var storage []byte
+	data := make([]byte, 6+len(records)*12)
+	binary.BigEndian.PutUint16(data[2:], uint16(len(records)))
+	binary.BigEndian.PutUint16(data[4:], uint16(len(data)))
+
+	for i, record := range records {
+		value := []byte(record.value)
+		if record.platformID != 1 {
+			value = value[:0:0]
+			for _, c := range utf16.Encode([]rune(record.value)) {
+				value = append(value, byte(c>>8), byte(c))
+			}
+		}
+
+		entry := data[6+i*12:]
+		binary.BigEndian.PutUint16(entry, record.platformID)
+		binary.BigEndian.PutUint16(entry[2:], record.encodingID)
+		binary.BigEndian.PutUint16(entry[4:], record.languageID)
+		binary.BigEndian.PutUint16(entry[6:], record.nameID)
+		binary.BigEndian.PutUint16(entry[8:], uint16(len(value)))
+		binary.BigEndian.PutUint16(entry[10:], uint16(len(storage)))
+		storage = append(storage, value...)
+	}
+
+	return append(data, storage...)
+}
+
+// buildSFNT returns the data of an sfnt font having the specified version
+// and tables. The tables are stored in tag order, following the table
+// directory.
+func buildSFNT(version uint32, tables map[string][]byte) []byte {
+	tags := make([]string, 0, len(tables))
+	for tag := range tables {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+
+	data := make([]byte, 12+len(tags)*16)
+	binary.BigEndian.PutUint32(data, version)
+	binary.BigEndian.PutUint16(data[4:], uint16(len(tags)))
+
+	for i, tag := range tags {
+		record := data[12+i*16:]
+		copy(record, tag)
+		binary.BigEndian.PutUint32(record[8:], uint32(len(data)))
+		binary.BigEndian.PutUint32(record[12:], uint32(len(tables[tag])))
+		data = append(data, tables[tag]...)
+	}
+
+	return data
+}
+
+// testNames returns a name table containing the specified Windows English
+// names, indexed by name identifier.
+func testNames(names map[uint16]string) []byte {
+	ids := make([]int, 0, len(names))
+	for id := range names {
+		ids = append(ids, int(id))
+	}
+	sort.Ints(ids)
+
+	records := make([]testNameRecord, len(ids))
+	for i, id := range ids {
+		records[i] = testNameRecord{3, 1, 0x0409, uint16(id), names[uint16(id)]}
+	}
+
+	return buildNameTable(records...)
+}
+
+func readTestFontData(data []byte) ([]*Font, error) {
+	return readFontData(bytes.NewReader(data), int64(len(data)), "test.ttf")
+}
+
+func TestParseNameTable(t *testing.T) {
+	tests := []struct {
+		name  string
+		data  []byte
+		names map[uint16]string
+		err   error
+	}{
+		{
+			name: "windows",
+			data: testNames(map[uint16]string{
+				nameFamily:    "Go",
+				nameSubfamily: "Bold",
+				nameFull:      "Go Bold",
+			}),
+			names: map[uint16]string{nameFamily: "Go", nameSubfamily: "Bold", nameFull: "Go Bold"},
+		},
+		{
+			name: "macintosh",
+			data: buildNameTable(
+				testNameRecord{1, 0, 0, nameFamily, "Caf\x8e"},
+			),
+			names: map[uint16]string{nameFamily: "Café"},
+		},
+		{
+			name: "preferred record",
+			data: buildNameTable(
+				testNameRecord{3, 1, 0x040C, nameFamily, "French"},
+				testNameRecord{3, 1, 0x0409, nameFamily, "English"},
+				testNameRecord{1, 0, 0, nameFamily, "Macintosh"},
+				testNameRecord{3, 1, 0x0809, nameFamily, "British"},
+			),
+			names: map[uint16]string{nameFamily: "English"},
+		},
+		{
+			name: "unsupported encoding",
+			data: buildNameTable(
+				testNameRecord{3, 2, 0x0409, nameFamily, "Chinese"},
+				testNameRecord{1, 1, 0, nameFamily, "Japanese"},
+			),
+			names: map[uint16]string{},
+		},
+		{
+			name: "blank name",
+			data: buildNameTable(
+				testNameRecord{3, 1, 0x0409, nameFamily, "  "},
+				testNameRecord{3, 1, 0x0411, nameFamily, " Name "},
+			),
+			names: map[uint16]string{nameFamily: "Name"},
+		},
+		{
+			name: "name out of bounds",
+			data: func() []byte {
+				data := testNames(map[uint16]string{nameFamily: "Go"})
+				return data[:len(data)-1]
+			}(),
+			names: map[uint16]string{},
+		},
+		{
+			name: "truncated header",
+			data: []byte{0, 0, 0, 1},
+			err:  errInvalidFont,
+		},
+		{
+			name: "truncated records",
+			data: testNames(map[uint16]string{nameFamily: "Go"})[:12],
+			err:  errInvalidFont,
+		},
+	}
+
+	for _, test := range tests {
+		names, err := parseNameTable(test.data)
+		if !errors.Is(err, test.err) {
+			t.Errorf("%s: got error %v, want %v", test.name, err, test.err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+
+		if len(names) != len(test.names) {
+			t.Errorf("%s: got names %q, want %q", test.name, names, test.names)
+			continue
+		}
+		for id, name := range test.names {
+			if names[id] != name {
+				t.Errorf("%s: got name %d %q, want %q", test.name, id, names[id], name)
+			}
+		}
+	}
+}
+
+func TestReadFontData(t *testing.T) {
+	oversized := buildSFNT(sfntVersionTrueType, map[string][]byte{"name": nil})
+	binary.BigEndian.PutUint32(oversized[24:], 0xFFFFFFF0)
+
+	truncated := buildSFNT(sfntVersionTrueType, map[string][]byte{
+		"name": testNames(map[uint16]string{nameFamily: "Go"}),
+	})
+
+	tests := []struct {
+		name   string
+		data   []byte
+		family string
+		full   string
+		err    error
+	}{
+		{
+			name: "full name",
+			data: buildSFNT(sfntVersionTrueType, map[string][]byte{
+				"name": testNames(map[uint16]string{
+					nameFamily: "Go",
+					nameFull:   "Go Regular",
+				}),
+			}),
+			family: "Go",
+			full:   "Go Regular",
+		},
+		{
+			name: "typographic names",
+			data: buildSFNT(sfntVersionOpenType, map[string][]byte{
+				"name": testNames(map[uint16]string{
+					nameFamily:            "Go Medium",
+					nameSubfamily:         "Italic",
+					nameTypographicFamily: "Go",
+					nameTypographicSub:    "Medium Italic",
+				}),
+			}),
+			family: "Go",
+			full:   "Go Medium Italic",
+		},
+		{
+			name: "subfamily",
+			data: buildSFNT(sfntVersionApple, map[string][]byte{
+				"name": testNames(map[uint16]string{
+					nameFamily:    "Go",
+					nameSubfamily: "Bold",
+				}),
+			}),
+			family: "Go",
+			full:   "Go Bold",
+		},
+		{
+			name: "postscript name",
+			data: buildSFNT(sfntVersionTrueType, map[string][]byte{
+				"name": testNames(map[uint16]string{namePostScript: "Go-Bold"}),
+			}),
+			full: "Go-Bold",
+		},
+		{
+			name: "no names",
+			data: buildSFNT(sfntVersionTrueType, map[string][]byte{
+				"name": testNames(nil),
+			}),
+			err: errInvalidFont,
+		},
+		{
+			name: "missing name table",
+			data: buildSFNT(sfntVersionTrueType, map[string][]byte{"post": make([]byte, 32)}),
+			err:  errMissingTable,
+		},
+		{
+			name: "unsupported version",
+			data: buildSFNT(0x00020000, nil),
+			err:  errUnsupportedFont,
+		},
+		{
+			name: "truncated header",
+			data: []byte{0, 1, 0, 0, 0},
+			err:  errInvalidFont,
+		},
+		{
+			name: "truncated directory",
+			data: truncated[:20],
+			err:  errInvalidFont,
+		},
+		{
+			name: "truncated table",
+			data: truncated[:len(truncated)-1],
+			err:  errInvalidFont,
+		},
+		{
+			name: "oversized table",
+			data: oversized,
+			err:  errInvalidFont,
+		},
+	}
+
+	for _, test := range tests {
+		fonts, err := readTestFontData(test.data)
+		if !errors.Is(err, test.err) {
+			t.Errorf("%s: got error %v, want %v", test.name, err, test.err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+
+		if len(fonts) != 1 {
+			t.Errorf("%s: got %d fonts, want 1", test.name, len(fonts))
+			continue
+		}
+		if font := fonts[0]; font.Family != test.family || font.Name != test.full {
+			t.Errorf("%s: got font %q (%q), want %q (%q)",
+				test.name, font.Name, font.Family, test.full, test.family)
+		}
+	}
+}
+
+// buildCollection returns the data of a font collection containing the
+// specified fonts. Table offsets are adjusted to the position of the fonts
+// in the collection.
+func buildCollection(fonts ...[]byte) []byte {
+	data := make([]byte, 12+len(fonts)*4)
+	binary.BigEndian.PutUint32(data, sfntCollection)
+	binary.BigEndian.PutUint32(data[4:], 0x00010000)
+	binary.BigEndian.PutUint32(data[8:], uint32(len(fonts)))
+
+	for i, font := range fonts {
+		offset := uint32(len(data))
+		binary.BigEndian.PutUint32(data[12+i*4:], offset)
+
+		font = append([]byte(nil), font...)
+		numTables := int(binary.BigEndian.Uint16(font[4:]))
+		for j := 0; j < numTables; j++ {
+			record := font[12+j*16:]
+			binary.BigEndian.PutUint32(record[8:], binary.BigEndian.Uint32(record[8:])+offset)
+		}
+		data = append(data, font...)
+	}
+
+	return data
+}
+
+func TestReadFontDataCollection(t *testing.T) {
+	regular := buildSFNT(sfntVersionTrueType, map[string][]byte{
+		"name": testNames(map[uint16]string{nameFamily: "Go", nameFull: "Go Regular"}),
+	})
+	bold := buildSFNT(sfntVersionTrueType, map[string][]byte{
+		"name": testNames(map[uint16]string{nameFamily: "Go", nameFull: "Go Bold"}),
+	})
+	invalid := buildSFNT(sfntVersionTrueType, map[string][]byte{"post": make([]byte, 32)})
+
+	collection := buildCollection(regular, bold)
+
+	tooMany := append([]byte(nil), collection...)
+	binary.BigEndian.PutUint32(tooMany[8:], 0xFFFF)
+
+	noFonts := append([]byte(nil), collection...)
+	binary.BigEndian.PutUint32(noFonts[8:], 0)
+
+	badOffset := append([]byte(nil), collection...)
+	binary.BigEndian.PutUint32(badOffset[16:], uint32(len(collection)))
+
+	tests := []struct {
+		name  string
+		data  []byte
+		names []string
+		err   error
+	}{
+		{
+			name:  "collection",
+			data:  collection,
+			names: []string{"Go Regular", "Go Bold"},
+		},
+		{
+			name:  "invalid face",
+			data:  buildCollection(invalid, bold),
+			names: []string{"Go Bold"},
+		},
+		{
+			name: "no valid faces",
+			data: buildCollection(invalid),
+			err:  errMissingTable,
+		},
+		{
+			name: "no fonts",
+			data: noFonts,
+			err:  errInvalidFont,
+		},
+		{
+			name: "font count exceeds data",
+			data: tooMany,
+			err:  errInvalidFont,
+		},
+		{
+			name: "font offset out of bounds",
+			data: badOffset,
+			err:  errInvalidFont,
+		},
+		{
+			name: "truncated offsets",
+			data: collection[:14],
+			err:  errInvalidFont,
+		},
+	}
+
+	for _, test := range tests {
+		fonts, err := readTestFontData(test.data)
+		if !errors.Is(err, test.err) {
+			t.Errorf("%s: got error %v, want %v", test.name, err, test.err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+
+		if len(fonts) != len(test.names) {
+			t.Errorf("%s: got %d fonts, want %d", test.name, len(fonts), len(test.names))
+			continue
+		}
+		for i, font := range fonts {
+			if font.Name != test.names[i] {
+				t.Errorf("%s: got font %d %q, want %q", test.name, i, font.Name, test.names[i])
+			}
+		}
+	}
+}
+
+// testOS2 returns an OS/2 table having the specified weight class, width
+// class and selection flags.
+func testOS2(weight, width, selection uint16) []byte {
+	data := make([]byte, 78)
+	binary.BigEndian.PutUint16(data[4:], weight)
+	binary.BigEndian.PutUint16(data[6:], width)
+	binary.BigEndian.PutUint16(data[62:], selection)
+
+	return data
+}
+
+// testPost returns a post table having the specified italic angle.
+func testPost(italicAngle float64) []byte {
+	data := make([]byte, 32)
+	binary.BigEndian.PutUint32(data[4:], uint32(int32(italicAngle*65536)))
+
+	return data
+}
+
+// testHead returns a head table having the specified style flags.
+func testHead(macStyle uint16) []byte {
+	data := make([]byte, 54)
+	binary.BigEndian.PutUint16(data[44:], macStyle)
+
+	return data
+}
+
+func TestSFNTStyle(t *testing.T) {
+	tests := []struct {
+		name        string
+		tables      map[string][]byte
+		weight      int
+		stretch     int
+		style       Style
+		italicAngle float64
+	}{
+		{
+			name:    "no style tables",
+			tables:  map[string][]byte{"name": testNames(nil)},
+			weight:  WeightNormal,
+			stretch: StretchNormal,
+			style:   StyleNormal,
+		},
+		{
+			name:    "os2 bold condensed",
+			tables:  map[string][]byte{"OS/2": testOS2(700, 3, 0)},
+			weight:  WeightBold,
+			stretch: StretchCondensed,
+			style:   StyleNormal,
+		},
+		{
+			name:    "os2 weight scale",
+			tables:  map[string][]byte{"OS/2": testOS2(3, 0, 0)},
+			weight:  WeightLight,
+			stretch: StretchNormal,
+			style:   StyleNormal,
+		},
+		{
+			name:    "os2 weight out of range",
+			tables:  map[string][]byte{"OS/2": testOS2(1500, 12, 0)},
+			weight:  1000,
+			stretch: StretchNormal,
+			style:   StyleNormal,
+		},
+		{
+			name:    "os2 italic",
+			tables:  map[string][]byte{"OS/2": testOS2(400, 5, 0x0001)},
+			weight:  WeightNormal,
+			stretch: StretchNormal,
+			style:   StyleItalic,
+		},
+		{
+			name:    "os2 oblique",
+			tables:  map[string][]byte{"OS/2": testOS2(400, 5, 0x0201)},
+			weight:  WeightNormal,
+			stretch: StretchNormal,
+			style:   StyleOblique,
+		},
+		{
+			name: "italic angle",
+			tables: map[string][]byte{
+				"OS/2": testOS2(400, 5, 0),
+				"post": testPost(-12.5),
+			},
+			weight:      WeightNormal,
+			stretch:     StretchNormal,
+			style:       StyleOblique,
+			italicAngle: -12.5,
+		},
+		{
+			name: "head fallback",
+			tables: map[string][]byte{
+				"OS/2": testOS2(400, 5, 0)[:40],
+				"head": testHead(0x0003),
+			},
+			weight:  WeightBold,
+			stretch: StretchNormal,
+			style:   StyleItalic,
+		},
+		{
+			name: "head italic angle",
+			tables: map[string][]byte{
+				"head": testHead(0x0001),
+				"post": testPost(-10),
+			},
+			weight:      WeightBold,
+			stretch:     StretchNormal,
+			style:       StyleOblique,
+			italicAngle: -10,
+		},
+		{
+			name: "truncated tables",
+			tables: map[string][]byte{
+				"head": testHead(0x0003)[:45],
+				"post": testPost(-10)[:6],
+			},
+			weight:  WeightNormal,
+			stretch: StretchNormal,
+			style:   StyleNormal,
+		},
+	}
+
+	for _, test := range tests {
+		data := buildSFNT(sfntVersionTrueType, test.tables)
+
+		sr, err := newSFNTReader(bytes.NewReader(data), 0, int64(len(data)))
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+
+		weight, stretch, style, italicAngle := sr.style()
+		if weight != test.weight || stretch != test.stretch ||
+			style != test.style || italicAngle != test.italicAngle {
+			t.Errorf("%s: got %d, %d, %s, %g, want %d, %d, %s, %g", test.name,
+				weight, stretch, style, italicAngle,
+				test.weight, test.stretch, test.style, test.italicAngle)
+		}
+	}
+}
@@ -0,0 +1,218 @@
+package sysfont
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Style represents the slant of a font.
+type Style int
+
+// Font slant styles.
+const (
+	StyleNormal Style = iota
+	StyleItalic
+	StyleOblique
+)
+
+// String returns the name of the style.
+func (s Style) String() string {
+	switch s {
+	case StyleItalic:
+		return "italic"
+	case StyleOblique:
+		return "oblique"
+	default:
+		return "normal"
+	}
+}
+
+// Font weights, as defined by the usWeightClass field of the OS/2 table.
+const (
+	WeightThin       = 100
+	WeightExtraLight = 200
+	WeightLight      = 300
+	WeightNormal     = 400
+	WeightMedium     = 500
+	WeightSemiBold   = 600
+	WeightBold       = 700
+	WeightExtraBold  = 800
+	WeightBlack      = 900
+)
+
+// Font stretches, as defined by the usWidthClass field of the OS/2 table.
+const (
+	StretchUltraCondensed = 1 + iota
+	StretchExtraCondensed
+	StretchCondensed
+	StretchSemiCondensed
+	StretchNormal
+	StretchSemiExpanded
+	StretchExpanded
+	StretchExtraExpanded
+	StretchUltraExpanded
+)
+
+// fontWeights maps weight keywords to font weights.
+var fontWeights = map[string]int{
+	"thin":       WeightThin,
+	"hairline":   WeightThin,
+	"extralight": WeightExtraLight,
+	"ultralight": WeightExtraLight,
+	"light":      WeightLight,
+	"book":       WeightNormal,
+	"normal":     WeightNormal,
+	"regular":    WeightNormal,
+	"roman":      WeightNormal,
+	"plain":      WeightNormal,
+	"medium":     WeightMedium,
+	"demi":       WeightSemiBold,
+	"demibold":   WeightSemiBold,
+	"semibold":   WeightSemiBold,
+	"bold":       WeightBold,
+	"extrabold":  WeightExtraBold,
+	"ultrabold":  WeightExtraBold,
+	"black":      WeightBlack,
+	"heavy":      WeightBlack,
+}
+
+// fontStretches maps stretch keywords to font stretches.
+var fontStretches = map[string]int{
+	"ultracondensed": StretchUltraCondensed,
+	"extracondensed": StretchExtraCondensed,
+	"condensed":      StretchCondensed,
+	"narrow":         StretchCondensed,
+	"semicondensed":  StretchSemiCondensed,
+	"semiexpanded":   StretchSemiExpanded,
+	"expanded":       StretchExpanded,
+	"wide":           StretchExpanded,
+	"extraexpanded":  StretchExtraExpanded,
+	"ultraexpanded":  StretchUltraExpanded,
+}
+
+// fontSlants maps slant keywords to font styles.
+var fontSlants = map[string]Style{
+	"italic":  StyleItalic,
+	"oblique": StyleOblique,
+}
+
+// fontSuffixes contains vendor suffixes which are commonly appended to style
+// names (e.g. Arial-BoldItalicMT).
+var fontSuffixes = []string{"mt", "ps"}
+
+// parseStyle extracts the weight, stretch and style of a font from the
+// specified font name or query. Attributes which cannot be identified have
+// normal values.
+func parseStyle(name string) (int, int, Style) {
+	weight, stretch, style := WeightNormal, StretchNormal, StyleNormal
+
+	for _, word := range splitStyleWords(name) {
+		for _, keyword := range splitStyleKeywords(word) {
+			if value, ok := fontWeights[keyword]; ok {
+				weight = value
+			} else if value, ok := fontStretches[keyword]; ok {
+				stretch = value
+			} else if value, ok := fontSlants[keyword]; ok {
+				style = value
+			}
+		}
+	}
+
+	return weight, stretch, style
+}
+
+// splitStyleWords splits the specified name into lowercase words. Camel case
+// words are split (e.g. BoldItalic) and style prefixes are joined with the
+// words that follow them (e.g. Semi Bold).
+func splitStyleWords(name string) []string {
+	var words []string
+	var word []rune
+	var prev rune
+
+	flush := func() {
+		if len(word) > 0 {
+			words = append(words, strings.ToLower(string(word)))
+			word = word[:0]
+		}
+	}
+
+	for _, c := range name {
+		switch {
+		case !unicode.IsLetter(c) && !unicode.IsNumber(c):
+			flush()
+		case unicode.IsUpper(c) && unicode.IsLower(prev):
+			flush()
+			word = append(word, c)
+		default:
+			word = append(word, c)
+		}
+		prev = c
+	}
+	flush()
+
+	// Join style prefixes with the following words.
+	joined := make([]string, 0, len(words))
+	for i := 0; i < len(words); i++ {
+		if isStylePrefix(words[i]) && i+1 < len(words) {
+			joined = append(joined, words[i]+words[i+1])
+			i++
+			continue
+		}
+
+		joined = append(joined, words[i])
+	}
+
+	return joined
+}
+
+// splitStyleKeywords splits the specified word into style keywords (e.g.
+// bolditalic is split into bold and italic). If the word cannot be fully
+// split into style keywords, nil is returned.
+func splitStyleKeywords(word string) []string {
+	if word == "" {
+		return nil
+	}
+	for _, suffix := range fontSuffixes {
+		if word != suffix && strings.HasSuffix(word, suffix) {
+			if keywords := splitStyleKeywords(strings.TrimSuffix(word, suffix)); keywords != nil {
+				return keywords
+			}
+		}
+	}
+	if isStyleKeyword(word) {
+		return []string{word}
+	}
+
+	for i := len(word) - 1; i > 0; i-- {
+		if !isStyleKeyword(word[:i]) {
+			continue
+		}
+		if keywords := splitStyleKeywords(word[i:]); keywords != nil {
+			return append([]string{word[:i]}, keywords...)
+		}
+	}
+
+	return nil
+}
+
+// isStylePrefix returns true if the specified lowercase word is a prefix of
+// style keywords (e.g. semi in semi bold).
+func isStylePrefix(word string) bool {
+	switch word {
+	case "extra", "ultra", "semi", "demi":
+		return true
+	}
+
+	return false
+}
+
+func isStyleKeyword(word string) bool {
+	if _, ok := fontWeights[word]; ok {
+		return true
+	}
+	if _, ok := fontStretches[word]; ok {
+		return true
+	}
+	_, ok := fontSlants[word]
+	return ok
+}
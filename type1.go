@@ -0,0 +1,350 @@
+package sysfont
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// type1HeaderSize is the maximum size of the cleartext portion of Type 1
+// font files which is read in order to identify fonts.
+const type1HeaderSize = 1 << 18
+
+// type1Signatures contains the prefixes of Type 1 font programs.
+var type1Signatures = []string{"%!PS-AdobeFont", "%!FontType1"}
+
+// type1Keys contains the font dictionary entries used for identifying
+// Type 1 fonts. AFM files use the same keys.
+var type1Keys = []string{"FontName", "FullName", "FamilyName", "Weight", "ItalicAngle"}
+
+// type1MetricsExtensions contains the extensions of the metrics files which
+// are associated with Type 1 fonts, in order of preference.
+var type1MetricsExtensions = []string{".afm", ".AFM", ".pfm", ".PFM"}
+
+// isType1Font returns true if the specified data contains a Type 1 font
+// program, either in binary (PFB) or in ASCII (PFA) format.
+func isType1Font(r io.ReaderAt) bool {
+	var header [32]byte
+	n, _ := r.ReadAt(header[:], 0)
+	if n < 2 {
+		return false
+	}
+	if header[0] == 0x80 && header[1] == 1 {
+		return true
+	}
+
+	for _, signature := range type1Signatures {
+		if bytes.HasPrefix(header[:n], []byte(signature)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// readType1File identifies the Type 1 font contained in the specified font
+// data. The font is identified using the entries of its cleartext font
+// dictionary. Missing entries are read from the AFM file which has the same
+// name as the font file. AFM and PFM files found next to the font file are
+// associated with the font as metrics files.
+func readType1File(fsys fs.FS, filename string, r io.ReaderAt) ([]*Font, error) {
+	data, err := readType1Header(r)
+	if err != nil {
+		return nil, err
+	}
+	info := parseType1Header(data)
+
+	// Read the entries missing from the font dictionary from the metrics
+	// file, if available.
+	metrics := findMetricsFile(fsys, filename)
+	if strings.EqualFold(filepath.Ext(metrics), ".afm") {
+		if afmInfo, err := readAFMFile(fsys, metrics); err == nil {
+			for key, value := range afmInfo {
+				if info[key] == "" {
+					info[key] = value
+				}
+			}
+		}
+	}
+
+	font, err := newType1Font(info)
+	if err != nil {
+		return nil, err
+	}
+	font.Filename = filename
+	font.MetricsFilename = metrics
+
+	return []*Font{font}, nil
+}
+
+// readType1Header returns the cleartext portion of the specified Type 1
+// font data. For PFB files, it is contained in the first segment of the
+// file. For PFA files, it ends where the encrypted portion begins.
+func readType1Header(r io.ReaderAt) ([]byte, error) {
+	var segment [6]byte
+	if _, err := r.ReadAt(segment[:], 0); err != nil {
+		return nil, errInvalidFont
+	}
+
+	var offset int64
+	size := type1HeaderSize
+	if segment[0] == 0x80 {
+		offset = int64(len(segment))
+		if length := int(binary.LittleEndian.Uint32(segment[2:])); length < size {
+			size = length
+		}
+	}
+
+	data := make([]byte, size)
+	n, err := r.ReadAt(data, offset)
+	if n == 0 && err != nil {
+		return nil, errInvalidFont
+	}
+	data = data[:n]
+
+	if i := bytes.Index(data, []byte("eexec")); i >= 0 {
+		data = data[:i]
+	}
+
+	return data, nil
+}
+
+// parseType1Header extracts the identification entries of the font
+// dictionary contained in the specified cleartext font data.
+func parseType1Header(data []byte) map[string]string {
+	info := map[string]string{}
+	for i := 0; i < len(data); i++ {
+		if data[i] != '/' {
+			continue
+		}
+
+		key, end := readPostScriptToken(data, i+1)
+		if i = end - 1; !isType1Key(key) || info[key] != "" {
+			continue
+		}
+		i = end
+
+		// Skip whitespace preceding the value.
+		for i < len(data) && isPostScriptSpace(data[i]) {
+			i++
+		}
+		if i >= len(data) {
+			break
+		}
+
+		var value string
+		switch data[i] {
+		case '(':
+			value, i = readPostScriptString(data, i+1)
+		case '/':
+			value, i = readPostScriptToken(data, i+1)
+		default:
+			value, i = readPostScriptToken(data, i)
+		}
+		info[key] = strings.TrimSpace(value)
+
+		// Position on the last character of the value.
+		i--
+	}
+
+	return info
+}
+
+// readAFMFile extracts the identification entries of the global font
+// information section of the specified AFM file.
+func readAFMFile(fsys fs.FS, filename string) (map[string]string, error) {
+	var f io.ReadCloser
+	var err error
+	if fsys == nil {
+		f, err = os.Open(filename)
+	} else {
+		f, err = fsys.Open(filename)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	info := map[string]string{}
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "StartCharMetrics") {
+			break
+		}
+
+		fields := strings.SplitN(line, " ", 2)
+		if len(fields) == 2 && isType1Key(fields[0]) {
+			info[fields[0]] = strings.TrimSpace(fields[1])
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return info, nil
+}
+
+// findMetricsFile returns the path of the metrics file associated with the
+// specified Type 1 font file. Metrics files have the same name as the font
+// file and the .afm or .pfm extension. If no metrics file is found, an empty
+// string is returned.
+func findMetricsFile(fsys fs.FS, filename string) string {
+	base := strings.TrimSuffix(filename, filepath.Ext(filename))
+	for _, ext := range type1MetricsExtensions {
+		var info fs.FileInfo
+		var err error
+		if fsys == nil {
+			info, err = os.Stat(base + ext)
+		} else {
+			info, err = fs.Stat(fsys, base+ext)
+		}
+		if err == nil && info.Mode().IsRegular() {
+			return base + ext
+		}
+	}
+
+	return ""
+}
+
+// newType1Font returns a font based on the specified identification
+// entries. The style attributes are extracted from the name and the weight
+// of the font.
+func newType1Font(info map[string]string) (*Font, error) {
+	psName := info["FontName"]
+
+	// Identify font family and name.
+	family := info["FamilyName"]
+	if family == "" {
+		family = strings.SplitN(psName, "-", 2)[0]
+	}
+	name := info["FullName"]
+	if name == "" {
+		name = psName
+	}
+	if family == "" && name == "" {
+		return nil, errInvalidFont
+	}
+
+	// Identify font style attributes.
+	weight, stretch, style := parseStyle(psName + " " + name)
+	for _, word := range splitStyleWords(info["Weight"]) {
+		if value, ok := fontWeights[word]; ok {
+			weight = value
+		}
+	}
+
+	italicAngle, _ := strconv.ParseFloat(info["ItalicAngle"], 64)
+	if style == StyleNormal && italicAngle != 0 {
+		style = StyleItalic
+	}
+
+	return &Font{
+		Family:      family,
+		Name:        name,
+		Weight:      weight,
+		Stretch:     stretch,
+		Style:       style,
+		ItalicAngle: italicAngle,
+	}, nil
+}
+
+func isType1Key(key string) bool {
+	for _, k := range type1Keys {
+		if k == key {
+			return true
+		}
+	}
+
+	return false
+}
+
+// readPostScriptToken reads the name or number starting at the specified
+// offset. It returns the token and the offset following it.
+func readPostScriptToken(data []byte, offset int) (string, int) {
+	end := offset
+	for end < len(data) && !isPostScriptSpace(data[end]) && !isPostScriptDelimiter(data[end]) {
+		end++
+	}
+
+	return string(data[offset:end]), end
+}
+
+// readPostScriptString reads the string literal starting at the specified
+// offset, following its opening parenthesis. It returns the unescaped string
+// and the offset following its closing parenthesis.
+func readPostScriptString(data []byte, offset int) (string, int) {
+	var buf []byte
+	depth := 1
+
+	i := offset
+	for ; i < len(data); i++ {
+		c := data[i]
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			if depth--; depth == 0 {
+				return string(buf), i + 1
+			}
+		case '\\':
+			if i+1 >= len(data) {
+				continue
+			}
+			i++
+
+			switch c = data[i]; c {
+			case 'n':
+				c = '\n'
+			case 'r':
+				c = '\r'
+			case 't':
+				c = '\t'
+			case 'b':
+				c = '\b'
+			case 'f':
+				c = '\f'
+			case '\n':
+				// Line continuation.
+				continue
+			case '0', '1', '2', '3', '4', '5', '6', '7':
+				// Read octal character code of up to 3 digits.
+				code := int(c - '0')
+				for j := 0; j < 2 && i+1 < len(data) && data[i+1] >= '0' && data[i+1] <= '7'; j++ {
+					i++
+					code = code*8 + int(data[i]-'0')
+				}
+				c = byte(code)
+			}
+		}
+
+		buf = append(buf, c)
+	}
+
+	return string(buf), i
+}
+
+func isPostScriptSpace(c byte) bool {
+	switch c {
+	case ' ', '\t', '\r', '\n', '\f', 0:
+		return true
+	}
+
+	return false
+}
+
+func isPostScriptDelimiter(c byte) bool {
+	switch c {
+	case '(', ')', '<', '>', '[', ']', '{', '}', '/', '%':
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,375 @@
+package sysfont
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+// buildPFB returns a PFB font containing the specified cleartext and binary
+// segments, followed by the end of file marker.
+func buildPFB(cleartext string, encrypted []byte) []byte {
+	var data []byte
+	data = append(data, pfbSegmentHeader(1, len(cleartext))...)
+	data = append(data, cleartext...)
+	data = append(data, pfbSegmentHeader(2, len(encrypted))...)
+	data = append(data, encrypted...)
+
+	return append(data, 0x80, 3)
+}
+
+func pfbSegmentHeader(kind byte, length int) []byte {
+	header := []byte{0x80, kind, 0, 0, 0, 0}
+	binary.LittleEndian.PutUint32(header[2:], uint32(length))
+
+	return header
+}
+
+const testType1Header = `%!PS-AdobeFont-1.0: Go-BoldItalic 1.0
+%%CreationDate: Thu Jan 1 00:00:00 1970
+/FontInfo 8 dict dup begin
+/version (1.0) readonly def
+/Notice (Copyright \(c\) The Go Authors) readonly def
+/FullName (Go Bold Italic) readonly def
+/FamilyName (Go) readonly def
+/Weight (Bold) readonly def
+/ItalicAngle -12.5 def
+end readonly def
+/FontName /Go-BoldItalic def
+/FullName (Ignored) def
+currentfile eexec
+`
+
+func TestParseType1Header(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		info map[string]string
+	}{
+		{
+			name: "font dictionary",
+			data: testType1Header,
+			info: map[string]string{
+				"FontName":    "Go-BoldItalic",
+				"FullName":    "Go Bold Italic",
+				"FamilyName":  "Go",
+				"Weight":      "Bold",
+				"ItalicAngle": "-12.5",
+			},
+		},
+		{
+			name: "adjacent entries",
+			data: "/FontName/Go-Regular def/FullName(Go)def/ItalicAngle 0def",
+			info: map[string]string{"FontName": "Go-Regular", "FullName": "Go", "ItalicAngle": "0def"},
+		},
+		{
+			name: "string value",
+			data: `/FullName ( Go \(Test\) (Nested) \107\157 ) def`,
+			info: map[string]string{"FullName": "Go (Test) (Nested) Go"},
+		},
+		{
+			name: "unknown keys",
+			data: "/Notice (Go) /version (1.0) /FontType 1 def",
+			info: map[string]string{},
+		},
+		{
+			name: "missing value",
+			data: "/FullName (Go) def /FontName  \n",
+			info: map[string]string{"FullName": "Go"},
+		},
+		{
+			name: "unterminated string",
+			data: "/FullName (Go Bold",
+			info: map[string]string{"FullName": "Go Bold"},
+		},
+		{
+			name: "truncated key",
+			data: "/FontNa",
+			info: map[string]string{},
+		},
+	}
+
+	for _, test := range tests {
+		if info := parseType1Header([]byte(test.data)); !reflect.DeepEqual(info, test.info) {
+			t.Errorf("%s: got %q, want %q", test.name, info, test.info)
+		}
+	}
+}
+
+func TestReadPostScriptString(t *testing.T) {
+	tests := []struct {
+		data string
+		want string
+		end  int
+	}{
+		{"Go) def", "Go", 3},
+		{"a(b)c) def", "a(b)c", 6},
+		{`\n\r\t\b\f\\\(\))`, "\n\r\t\b\f\\()", 17},
+		{`\101\60\0618)`, "A018", 13},
+		{"line\\\ncontinued)", "linecontinued", 16},
+		{`\q)`, "q", 3},
+		{"unterminated", "unterminated", 12},
+		{`trailing\`, "trailing", 9},
+	}
+
+	for _, test := range tests {
+		got, end := readPostScriptString([]byte(test.data), 0)
+		if got != test.want || end != test.end {
+			t.Errorf("%q: got %q ending at %d, want %q ending at %d", test.data, got, end, test.want, test.end)
+		}
+	}
+}
+
+func TestReadType1Header(t *testing.T) {
+	cleartext := "%!FontType1-1.0: Go\n/FontName /Go def\ncurrentfile eexec\n"
+	want := "%!FontType1-1.0: Go\n/FontName /Go def\ncurrentfile "
+	pfb := buildPFB(cleartext, []byte{0xDE, 0xAD, 0xBE, 0xEF})
+
+	tests := []struct {
+		name string
+		data []byte
+		want string
+		err  error
+	}{
+		{
+			name: "pfa",
+			data: []byte(cleartext + "D9D66F633B846A98"),
+			want: want,
+		},
+		{
+			name: "pfa without encrypted portion",
+			data: []byte("%!PS-AdobeFont-1.0: Go\n/FontName /Go def\n"),
+			want: "%!PS-AdobeFont-1.0: Go\n/FontName /Go def\n",
+		},
+		{
+			name: "pfb",
+			data: pfb,
+			want: want,
+		},
+		{
+			name: "pfb segment length",
+			data: append(pfbSegmentHeader(1, 11), "%!FontType1 eexec"...),
+			want: "%!FontType1",
+		},
+		{
+			name: "oversized pfb segment length",
+			data: append(pfbSegmentHeader(1, 0xFFFFFFFF), "%!FontType1 /FontName /Go def"...),
+			want: "%!FontType1 /FontName /Go def",
+		},
+		{
+			name: "truncated pfb segment header",
+			data: pfb[:4],
+			err:  errInvalidFont,
+		},
+		{
+			name: "truncated pfb segment",
+			data: pfb[:6],
+			err:  errInvalidFont,
+		},
+		{
+			name: "empty",
+			data: nil,
+			err:  errInvalidFont,
+		},
+	}
+
+	for _, test := range tests {
+		data, err := readType1Header(bytes.NewReader(test.data))
+		if !errors.Is(err, test.err) {
+			t.Errorf("%s: got error %v, want %v", test.name, err, test.err)
+			continue
+		}
+		if err == nil && string(data) != test.want {
+			t.Errorf("%s: got %q, want %q", test.name, data, test.want)
+		}
+	}
+}
+
+func TestIsType1Font(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		want bool
+	}{
+		{"pfb", buildPFB("%!PS-AdobeFont-1.0", nil), true},
+		{"pfa", []byte("%!PS-AdobeFont-1.0: Go 1.0\n"), true},
+		{"pfa font type", []byte("%!FontType1-1.1: Go\n"), true},
+		{"postscript", []byte("%!PS-Adobe-3.0\n"), false},
+		{"sfnt", buildSFNT(sfntVersionTrueType, map[string][]byte{"name": nil}), false},
+		{"short", []byte{0x80}, false},
+	}
+
+	for _, test := range tests {
+		if got := isType1Font(bytes.NewReader(test.data)); got != test.want {
+			t.Errorf("%s: got %t, want %t", test.name, got, test.want)
+		}
+	}
+}
+
+func TestReadType1File(t *testing.T) {
+	fsys := fstest.MapFS{
+		"type1/go.pfb": {Data: buildPFB("%!PS-AdobeFont-1.0\n/FontName /Go-Bold def\ncurrentfile eexec", nil)},
+		"type1/go.afm": {Data: []byte(`StartFontMetrics 4.1
+FontName Go-Bold
+FullName Go Sans Bold
+FamilyName Go Sans
+Weight Bold
+ItalicAngle 0
+StartCharMetrics 1
+FamilyName Ignored
+C 32 ; WX 250 ; N space ; B 0 0 0 0 ;
+EndCharMetrics
+EndFontMetrics
+`)},
+		"type1/mono.pfa":    {Data: []byte("%!PS-AdobeFont-1.0\n/FontName /GoMono-Italic def\n/ItalicAngle -10 def")},
+		"type1/mono.PFM":    {Data: []byte{0, 1}},
+		"type1/mono.afm/x":  {Data: nil},
+		"type1/invalid.pfa": {Data: []byte("%!PS-AdobeFont-1.0\n/Notice (Go) def")},
+		"type1/missing.pfa": {Data: []byte("%!PS-AdobeFont-1.0\n/FontName /Missing def")},
+	}
+
+	tests := []struct {
+		name    string
+		file    string
+		family  string
+		full    string
+		weight  int
+		style   Style
+		metrics string
+		err     error
+	}{
+		{
+			name:    "afm entries",
+			file:    "type1/go.pfb",
+			family:  "Go Sans",
+			full:    "Go Sans Bold",
+			weight:  WeightBold,
+			style:   StyleNormal,
+			metrics: "type1/go.afm",
+		},
+		{
+			name:    "pfm file",
+			file:    "type1/mono.pfa",
+			family:  "GoMono",
+			full:    "GoMono-Italic",
+			weight:  WeightNormal,
+			style:   StyleItalic,
+			metrics: "type1/mono.PFM",
+		},
+		{
+			name:   "no metrics file",
+			file:   "type1/missing.pfa",
+			family: "Missing",
+			full:   "Missing",
+			weight: WeightNormal,
+			style:  StyleNormal,
+		},
+		{
+			name: "missing names",
+			file: "type1/invalid.pfa",
+			err:  errInvalidFont,
+		},
+	}
+
+	for _, test := range tests {
+		fonts, err := readType1File(fsys, test.file, bytes.NewReader(fsys[test.file].Data))
+		if !errors.Is(err, test.err) {
+			t.Errorf("%s: got error %v, want %v", test.name, err, test.err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+
+		font := fonts[0]
+		if font.Family != test.family || font.Name != test.full || font.Weight != test.weight || font.Style != test.style {
+			t.Errorf("%s: got font %q %q with weight %d and style %v", test.name, font.Family, font.Name, font.Weight, font.Style)
+		}
+		if font.Filename != test.file || font.MetricsFilename != test.metrics {
+			t.Errorf("%s: got files %q and %q, want %q and %q", test.name, font.Filename, font.MetricsFilename, test.file, test.metrics)
+		}
+	}
+
+	if _, err := readAFMFile(fsys, "type1/missing.afm"); err == nil {
+		t.Error("missing afm file: got no error")
+	}
+}
+
+func TestNewType1Font(t *testing.T) {
+	tests := []struct {
+		name        string
+		info        map[string]string
+		family      string
+		full        string
+		weight      int
+		stretch     int
+		style       Style
+		italicAngle float64
+		err         error
+	}{
+		{
+			name:    "postscript name",
+			info:    map[string]string{"FontName": "Go-BoldItalic"},
+			family:  "Go",
+			full:    "Go-BoldItalic",
+			weight:  WeightBold,
+			stretch: StretchNormal,
+			style:   StyleItalic,
+		},
+		{
+			name:    "weight entry",
+			info:    map[string]string{"FontName": "Go-Demi", "FamilyName": "Go", "FullName": "Go Demi", "Weight": "Semibold"},
+			family:  "Go",
+			full:    "Go Demi",
+			weight:  WeightSemiBold,
+			stretch: StretchNormal,
+			style:   StyleNormal,
+		},
+		{
+			name:        "italic angle",
+			info:        map[string]string{"FullName": "Go Condensed", "ItalicAngle": "-11"},
+			family:      "",
+			full:        "Go Condensed",
+			weight:      WeightNormal,
+			stretch:     StretchCondensed,
+			style:       StyleItalic,
+			italicAngle: -11,
+		},
+		{
+			name:        "oblique",
+			info:        map[string]string{"FontName": "Go-Oblique", "ItalicAngle": "-8"},
+			family:      "Go",
+			full:        "Go-Oblique",
+			weight:      WeightNormal,
+			stretch:     StretchNormal,
+			style:       StyleOblique,
+			italicAngle: -8,
+		},
+		{
+			name: "no names",
+			info: map[string]string{"Weight": "Bold"},
+			err:  errInvalidFont,
+		},
+	}
+
+	for _, test := range tests {
+		font, err := newType1Font(test.info)
+		if !errors.Is(err, test.err) {
+			t.Errorf("%s: got error %v, want %v", test.name, err, test.err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+
+		if font.Family != test.family || font.Name != test.full {
+			t.Errorf("%s: got font %q %q, want %q %q", test.name, font.Family, font.Name, test.family, test.full)
+		}
+		if font.Weight != test.weight || font.Stretch != test.stretch || font.Style != test.style || font.ItalicAngle != test.italicAngle {
+			t.Errorf("%s: got weight %d, stretch %d, style %v and italic angle %v", test.name, font.Weight, font.Stretch, font.Style, font.ItalicAngle)
+		}
+	}
+}
@@ -1,6 +1,8 @@
 package sysfont
 
 import (
+	"context"
+	"math"
 	"strings"
 	"unicode"
 
@@ -14,51 +16,167 @@ func cleanQuery(query string) string {
 	}), " ")
 }
 
+// extractFamily returns the family of the specified query, in the same form
+// as cleanQuery. The words of the query which parseStyle identifies as style
+// attributes (e.g. Bold, Book, SemiCondensed) are removed.
 func extractFamily(query string) string {
-	family := cleanQuery(query)
-	for _, fontStyle := range fontStyles {
-		family = strings.Replace(family, fontStyle, "", -1)
+	tokens := strings.FieldsFunc(query, func(c rune) bool {
+		return !unicode.IsLetter(c) && !unicode.IsNumber(c)
+	})
+
+	var family []string
+	for i := 0; i < len(tokens); i++ {
+		// Style prefixes are joined with the tokens that follow them, if
+		// they form style attributes (e.g. Semi Bold).
+		if i+1 < len(tokens) && isStylePrefix(strings.ToLower(tokens[i])) &&
+			isStyleWord(strings.ToLower(tokens[i]+tokens[i+1])) {
+			i++
+			continue
+		}
+
+		// Remove the style words at the end of the token (e.g. BoldItalicMT
+		// in ArialBoldItalicMT).
+		words := splitStyleWords(tokens[i])
+		for j := range words {
+			if isStyleWord(strings.Join(words[j:], "")) {
+				words = words[:j]
+				break
+			}
+		}
+		if len(words) > 0 {
+			family = append(family, strings.Join(words, ""))
+		}
 	}
 
-	return cleanQuery(family)
+	return strings.Join(family, " ")
 }
 
-func extractStyles(query string) string {
-	query = cleanQuery(query)
+// isStyleWord returns true if the specified lowercase word consists of style
+// keywords (e.g. bolditalic).
+func isStyleWord(word string) bool {
+	return splitStyleKeywords(word) != nil
+}
 
-	var matched []string
-	for _, fontStyle := range fontStyles {
-		if strings.Contains(query, fontStyle) {
-			matched = append(matched, fontStyle)
+func getFamilyScore(query, family string) float64 {
+	// Family patterns only match the families they describe.
+	if isXLFDPattern(query) {
+		if matchXLFDPattern(query, family) {
+			return 1
 		}
+		return 0
 	}
 
-	return strings.Join(matched, " ")
+	return strutil.Similarity(query, cleanQuery(family), metrics.NewJaroWinkler())
 }
 
-func getFamilyScore(query, family string) float64 {
-	return strutil.Similarity(query, cleanQuery(family), metrics.NewJaroWinkler())
+// sameFamily returns true if the specified cleaned query family and the
+// font family are the same, ignoring spacing and vendor suffixes (e.g.
+// ArialMT, Arial PS).
+func sameFamily(query, family string) bool {
+	if isXLFDPattern(query) {
+		return matchXLFDPattern(query, family)
+	}
+
+	return trimVendorSuffix(query) == trimVendorSuffix(cleanQuery(family))
 }
 
-func getFontStyleScore(query, font string) float64 {
-	// Extract font styles.
-	fontStyles := extractStyles(font)
-	queryStyles := extractStyles(query)
+// trimVendorSuffix removes the spaces and the vendor suffixes (e.g. MT) of
+// the specified cleaned family name.
+func trimVendorSuffix(family string) string {
+	family = strings.ReplaceAll(family, " ", "")
+	for _, suffix := range fontSuffixes {
+		if len(family) > len(suffix) {
+			family = strings.TrimSuffix(family, suffix)
+		}
+	}
 
-	// Calculate style similarity.
-	sd := metrics.NewSorensenDice()
-	sd.NgramSize = 4
+	return family
+}
+
+// getStyleScore returns the similarity between the style attributes of the
+// query and the style attributes of the font.
+func getStyleScore(query Query, font *Font) float64 {
+	// Calculate weight and stretch similarity.
+	weightScore := 1 - math.Abs(float64(query.Weight-font.Weight))/1000
+	stretchScore := 1 - math.Abs(float64(query.Stretch-font.Stretch))/8
+
+	// Calculate style similarity. Italic and oblique fonts are considered
+	// similar, as they are commonly used interchangeably.
+	var styleScore float64
+	switch {
+	case query.Style == font.Style:
+		styleScore = 1
+	case query.Style != StyleNormal && font.Style != StyleNormal:
+		styleScore = 0.75
+	}
+
+	return (2*weightScore + 2*styleScore + stretchScore) / 5
+}
+
+// parallelize calls fn for each index in the [0, n) range, using at most the
+// specified number of concurrent goroutines, and returns the values produced
+// by the calls, indexed accordingly. If the context is canceled, the pending
+// calls are skipped and the values of the calls which have not completed are
+// nil. In that case, the function returns without waiting for the running
+// calls to complete.
+func parallelize(ctx context.Context, n, workers int, fn func(i int) interface{}) []interface{} {
+	values := make([]interface{}, n)
+	if n == 0 {
+		return values
+	}
+	if workers > n {
+		workers = n
+	}
+	if workers < 1 {
+		workers = 1
+	}
 
-	var similarity float64
-	if queryStyles != "" && fontStyles != "" {
-		similarity = strutil.Similarity(queryStyles, fontStyles, sd)
+	type result struct {
+		index int
+		value interface{}
 	}
 
-	if similarity == 0 {
-		if len(fontStyles) == 0 || strings.Contains(fontStyles, "regular") {
-			similarity += 0.1
+	// Start workers. The results channel is buffered in order to allow
+	// abandoned workers to finish.
+	indices := make(chan int)
+	results := make(chan result, n)
+	for w := 0; w < workers; w++ {
+		go func() {
+			for i := range indices {
+				results <- result{index: i, value: fn(i)}
+			}
+		}()
+	}
+
+	// Dispatch indices until done or canceled.
+	go func() {
+		defer close(indices)
+		for i := 0; i < n; i++ {
+			select {
+			case indices <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	// Collect results.
+	for received := 0; received < n; received++ {
+		select {
+		case r := <-results:
+			values[r.index] = r.value
+		case <-ctx.Done():
+			// Collect the results which are already available.
+			for {
+				select {
+				case r := <-results:
+					values[r.index] = r.value
+				default:
+					return values
+				}
+			}
 		}
 	}
 
-	return similarity
+	return values
 }
@@ -0,0 +1,57 @@
+package sysfont
+
+import "testing"
+
+func TestExtractFamily(t *testing.T) {
+	tests := []struct {
+		query  string
+		family string
+	}{
+		{"DejaVu Sans", "dejavu sans"},
+		{"DejaVu Sans Book", "dejavu sans"},
+		{"DejaVu Sans Mono Bold Oblique", "dejavu sans mono"},
+		{"DejaVu Serif Condensed", "dejavu serif"},
+		{"DejaVu Sans SemiCondensed", "dejavu sans"},
+		{"Liberation Sans Narrow", "liberation sans"},
+		{"Times New Roman", "times new"},
+		{"Noto Sans Black Italic", "noto sans"},
+		{"Source Sans Semi Bold", "source sans"},
+		{"Source Sans Semi", "source sans semi"},
+		{"Courier-Plain", "courier"},
+		{"DejaVuSans-BoldOblique", "dejavusans"},
+		{"Arial-BoldItalicMT", "arial"},
+		{"ArialMT", "arialmt"},
+		{"Noto Sans CJK SC", "noto sans cjk sc"},
+		{"6x13", "6x13"},
+		{"Bold Italic", ""},
+	}
+
+	for _, test := range tests {
+		if family := extractFamily(test.query); family != test.family {
+			t.Errorf("extractFamily(%q): got %q, want %q", test.query, family, test.family)
+		}
+	}
+}
+
+func TestSameFamily(t *testing.T) {
+	tests := []struct {
+		query  string
+		family string
+		same   bool
+	}{
+		{"arial", "Arial", true},
+		{"arial", "ArialMT", true},
+		{"arial", "Arial PS", true},
+		{"arialmt", "Arial", true},
+		{"dejavu sans", "DejaVuSans", true},
+		{"mt", "MT", true},
+		{"arial", "Arial Black", false},
+		{"times", "Times New Roman", false},
+	}
+
+	for _, test := range tests {
+		if same := sameFamily(test.query, test.family); same != test.same {
+			t.Errorf("sameFamily(%q, %q): got %t, want %t", test.query, test.family, same, test.same)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package sysfont
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FontEvent describes a change of the installed fonts, detected while
+// watching the search paths of a finder.
+type FontEvent struct {
+	// Path contains the path of the changed font file or directory.
+	Path string
+
+	// Added contains the fonts added by the change.
+	Added []*Font
+
+	// Removed contains the fonts removed by the change.
+	Removed []*Font
+}
+
+// Subscribe registers a function which is called for each change of the
+// installed fonts detected by the Watch method. The functions are called
+// sequentially, after the fonts of the finder are updated.
+func (f *Finder) Subscribe(fn func(*FontEvent)) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.subscribers = append(f.subscribers, fn)
+}
+
+// Watch starts watching the search paths of the finder for changes. Font
+// files which are added, modified or removed after the finder is created are
+// identified and the fonts reported by the finder are updated accordingly.
+// The subscribers registered using the Subscribe method are notified of each
+// change. The finder can be used concurrently while updates happen. Watching
+// stops when the specified context is canceled.
+//
+// Only search paths which exist when the method is called are watched.
+// The fonts.dir and fonts.alias files of the search paths are only read
+// when the finder is created.
+// Watching is currently supported only on Linux, using inotify, and only for
+// the OS file system. Otherwise, ErrWatchUnsupported is returned.
+func (f *Finder) Watch(ctx context.Context) error {
+	if f.fsys != nil {
+		return ErrWatchUnsupported
+	}
+
+	f.mu.Lock()
+	if f.watching {
+		f.mu.Unlock()
+		return errors.New("sysfont: finder is already watching")
+	}
+	f.watching = true
+	f.mu.Unlock()
+
+	if err := f.watch(ctx); err != nil {
+		f.stopWatching()
+		return err
+	}
+
+	return nil
+}
+
+func (f *Finder) stopWatching() {
+	f.mu.Lock()
+	f.watching = false
+	f.mu.Unlock()
+}
+
+// updateFile identifies the fonts of the specified file again, replacing its
+// existing fonts.
+func (f *Finder) updateFile(filename string) {
+	var fonts []*Font
+
+	info, err := os.Stat(filename)
+	switch {
+	case err == nil && !info.IsDir():
+		if fonts, err = f.readFonts(filename, info, nil); err != nil {
+			f.addError(err)
+		}
+	case err != nil && !os.IsNotExist(err):
+		f.addError(err)
+	}
+
+	f.replaceFonts(filename, false, fonts)
+}
+
+// replaceFonts replaces the fonts of the specified file, or of the files
+// inside the specified directory, with the specified fonts. The subscribers
+// of the finder are notified if the fonts change.
+func (f *Finder) replaceFonts(path string, dir bool, added []*Font) {
+	prefix := path + string(filepath.Separator)
+
+	f.mu.Lock()
+	var removed []*Font
+	fonts := make([]*Font, 0, len(f.fonts)+len(added))
+	for _, font := range f.fonts {
+		if font.Filename == path || dir && strings.HasPrefix(font.Filename, prefix) {
+			removed = append(removed, font)
+			continue
+		}
+
+		fonts = append(fonts, font)
+	}
+	f.fonts = append(fonts, added...)
+	subscribers := f.subscribers
+	f.mu.Unlock()
+
+	if len(added) == 0 && len(removed) == 0 {
+		return
+	}
+
+	// Notify subscribers.
+	event := &FontEvent{Path: path}
+	for _, font := range added {
+		event.Added = append(event.Added, font.clone())
+	}
+	for _, font := range removed {
+		event.Removed = append(event.Removed, font.clone())
+	}
+
+	for _, fn := range subscribers {
+		fn(event)
+	}
+}
+
+func (f *Finder) addError(err error) {
+	f.mu.Lock()
+	f.errors = append(f.errors, err)
+	f.mu.Unlock()
+}
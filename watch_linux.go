@@ -0,0 +1,178 @@
+//go:build linux
+// +build linux
+
+package sysfont
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"unsafe"
+)
+
+// inotifyMask contains the inotify events watched for each directory.
+const inotifyMask = syscall.IN_CREATE | syscall.IN_CLOSE_WRITE | syscall.IN_DELETE |
+	syscall.IN_MOVED_FROM | syscall.IN_MOVED_TO | syscall.IN_DELETE_SELF |
+	syscall.IN_MOVE_SELF | syscall.IN_ONLYDIR
+
+// inotifyWatcher watches the search paths of a finder using inotify.
+type inotifyWatcher struct {
+	finder *Finder
+	file   *os.File
+	fd     int
+	dirs   map[int]string
+}
+
+func (f *Finder) watch(ctx context.Context) error {
+	fd, err := syscall.InotifyInit1(syscall.IN_CLOEXEC | syscall.IN_NONBLOCK)
+	if err != nil {
+		return os.NewSyscallError("inotify_init1", err)
+	}
+
+	w := &inotifyWatcher{
+		finder: f,
+		file:   os.NewFile(uintptr(fd), "inotify"),
+		fd:     fd,
+		dirs:   map[int]string{},
+	}
+
+	// Watch the search paths and their subdirectories.
+	for _, dir := range f.searchPaths {
+		w.addDir(dir, false)
+	}
+
+	// Stop watching when the context is canceled. Closing the file
+	// interrupts the pending reads.
+	go func() {
+		<-ctx.Done()
+		w.file.Close()
+	}()
+	go w.run()
+
+	return nil
+}
+
+// run reads inotify events until the inotify file is closed.
+func (w *inotifyWatcher) run() {
+	defer w.finder.stopWatching()
+
+	buf := make([]byte, 64*(syscall.SizeofInotifyEvent+syscall.PathMax))
+	for {
+		n, err := w.file.Read(buf)
+		if err != nil {
+			if !errors.Is(err, os.ErrClosed) {
+				w.finder.addError(err)
+				w.file.Close()
+			}
+			return
+		}
+
+		for offset := 0; offset+syscall.SizeofInotifyEvent <= n; {
+			event := (*syscall.InotifyEvent)(unsafe.Pointer(&buf[offset]))
+			offset += syscall.SizeofInotifyEvent
+
+			var name string
+			if size := int(event.Len); size > 0 && offset+size <= n {
+				name = strings.TrimRight(string(buf[offset:offset+size]), "\x00")
+				offset += size
+			}
+
+			w.handle(int(event.Wd), event.Mask, name)
+		}
+	}
+}
+
+// handle updates the fonts of the finder based on the specified event.
+func (w *inotifyWatcher) handle(wd int, mask uint32, name string) {
+	// Rescan the search paths if events were lost.
+	if mask&syscall.IN_Q_OVERFLOW != 0 {
+		for _, dir := range w.finder.searchPaths {
+			w.finder.replaceFonts(dir, true, w.addDir(dir, true))
+		}
+		return
+	}
+
+	dir, ok := w.dirs[wd]
+	if !ok {
+		return
+	}
+	if mask&syscall.IN_IGNORED != 0 {
+		delete(w.dirs, wd)
+		return
+	}
+	path := filepath.Join(dir, name)
+
+	switch isDir := mask&syscall.IN_ISDIR != 0; {
+	case mask&(syscall.IN_DELETE_SELF|syscall.IN_MOVE_SELF) != 0:
+		w.removeDir(dir)
+		w.finder.replaceFonts(dir, true, nil)
+	case isDir && mask&(syscall.IN_CREATE|syscall.IN_MOVED_TO) != 0:
+		w.finder.replaceFonts(path, true, w.addDir(path, true))
+	case isDir && mask&(syscall.IN_DELETE|syscall.IN_MOVED_FROM) != 0:
+		w.removeDir(path)
+		w.finder.replaceFonts(path, true, nil)
+	case mask&(syscall.IN_CLOSE_WRITE|syscall.IN_MOVED_TO) != 0:
+		w.finder.updateFile(path)
+	case mask&syscall.IN_CREATE != 0:
+		// Regular files are identified once they are written. Symbolic
+		// links do not generate write events.
+		if info, err := os.Lstat(path); err == nil && info.Mode()&os.ModeSymlink != 0 {
+			w.finder.updateFile(path)
+		}
+	case mask&(syscall.IN_DELETE|syscall.IN_MOVED_FROM) != 0:
+		w.finder.replaceFonts(path, false, nil)
+	}
+}
+
+// addDir watches the specified directory and its subdirectories. If scan is
+// true, the fonts found in the directory are returned.
+func (w *inotifyWatcher) addDir(root string, scan bool) []*Font {
+	var fonts []*Font
+	filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if !os.IsNotExist(err) {
+				w.finder.addError(err)
+			}
+			if info != nil && info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if info.IsDir() {
+			wd, err := syscall.InotifyAddWatch(w.fd, path, inotifyMask)
+			if err != nil {
+				w.finder.addError(&os.PathError{Op: "watch", Path: path, Err: err})
+				return filepath.SkipDir
+			}
+
+			w.dirs[wd] = path
+			return nil
+		}
+		if scan {
+			found, err := w.finder.readFonts(path, info, nil)
+			if err != nil {
+				w.finder.addError(err)
+			}
+			fonts = append(fonts, found...)
+		}
+
+		return nil
+	})
+
+	return fonts
+}
+
+// removeDir stops watching the specified directory and its subdirectories.
+func (w *inotifyWatcher) removeDir(root string) {
+	prefix := root + string(filepath.Separator)
+	for wd, dir := range w.dirs {
+		if dir == root || strings.HasPrefix(dir, prefix) {
+			syscall.InotifyRmWatch(w.fd, uint32(wd))
+			delete(w.dirs, wd)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+//go:build linux
+// +build linux
+
+package sysfont
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestWatch(t *testing.T) {
+	dir := t.TempDir()
+	staging := t.TempDir()
+	var finder *Finder
+
+	writeFont := func(path, family string) {
+		t.Helper()
+		data := "STARTFONT 2.1\nFONT -misc-" + family + "-medium-r-normal--13-120-75-75-p-70-iso10646-1\nCHARS 0\nENDFONT\n"
+		if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	families := func(fonts []*Font) []string {
+		var names []string
+		for _, font := range fonts {
+			names = append(names, font.Family)
+		}
+		sort.Strings(names)
+		return names
+	}
+	checkList := func(step string, want ...string) {
+		t.Helper()
+		if got := families(finder.List()); !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: got fonts %q, want %q", step, got, want)
+		}
+	}
+
+	events := make(chan *FontEvent, 16)
+	nextEvent := func(step string) *FontEvent {
+		t.Helper()
+		select {
+		case event := <-events:
+			return event
+		case <-time.After(5 * time.Second):
+			t.Fatalf("%s: timed out waiting for font event", step)
+			return nil
+		}
+	}
+	checkEvent := func(step, path string, added, removed []string) {
+		t.Helper()
+		event := nextEvent(step)
+		if event.Path != path || !reflect.DeepEqual(families(event.Added), added) || !reflect.DeepEqual(families(event.Removed), removed) {
+			t.Errorf("%s: got event for %q adding %q and removing %q, want %q adding %q and removing %q",
+				step, event.Path, families(event.Added), families(event.Removed), path, added, removed)
+		}
+	}
+
+	writeFont(filepath.Join(dir, "a.bdf"), "Alpha")
+	finder = NewFinder(&FinderOpts{SearchPaths: []string{dir}, Extensions: []string{".bdf"}})
+	finder.Subscribe(func(event *FontEvent) { events <- event })
+	checkList("initial fonts", "Alpha")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := finder.Watch(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := finder.Watch(ctx); err == nil {
+		t.Error("second watch: got no error")
+	}
+
+	// Use the finder concurrently with the updates.
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		for {
+			select {
+			case <-done:
+				return
+			default:
+				finder.List()
+				finder.Match("Alpha")
+			}
+		}
+	}()
+	defer func() {
+		close(done)
+		<-stopped
+	}()
+
+	// Create, write and delete font files.
+	writeFont(filepath.Join(dir, "b.bdf"), "Beta")
+	checkEvent("create", filepath.Join(dir, "b.bdf"), []string{"Beta"}, nil)
+	checkList("create", "Alpha", "Beta")
+
+	writeFont(filepath.Join(dir, "a.bdf"), "Gamma")
+	checkEvent("write", filepath.Join(dir, "a.bdf"), []string{"Gamma"}, []string{"Alpha"})
+	checkList("write", "Beta", "Gamma")
+
+	if err := os.Remove(filepath.Join(dir, "b.bdf")); err != nil {
+		t.Fatal(err)
+	}
+	checkEvent("delete", filepath.Join(dir, "b.bdf"), nil, []string{"Beta"})
+	checkList("delete", "Gamma")
+
+	// Move a subdirectory containing fonts into the search path. The new
+	// subdirectory is watched as well.
+	sub := filepath.Join(dir, "sub")
+	if err := os.Mkdir(filepath.Join(staging, "sub"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeFont(filepath.Join(staging, "sub", "c.bdf"), "Delta")
+	if err := os.Rename(filepath.Join(staging, "sub"), sub); err != nil {
+		t.Fatal(err)
+	}
+	checkEvent("subdirectory create", sub, []string{"Delta"}, nil)
+
+	writeFont(filepath.Join(sub, "d.bdf"), "Epsilon")
+	checkEvent("subdirectory write", filepath.Join(sub, "d.bdf"), []string{"Epsilon"}, nil)
+	checkList("subdirectory create", "Delta", "Epsilon", "Gamma")
+
+	// Removing the subdirectory removes its font files first.
+	if err := os.RemoveAll(sub); err != nil {
+		t.Fatal(err)
+	}
+	var removed []*Font
+	for len(removed) < 2 {
+		event := nextEvent("subdirectory remove")
+		if len(event.Added) != 0 {
+			t.Errorf("subdirectory remove: got added fonts %q", families(event.Added))
+		}
+		removed = append(removed, event.Removed...)
+	}
+	if got := families(removed); !reflect.DeepEqual(got, []string{"Delta", "Epsilon"}) {
+		t.Errorf("subdirectory remove: got removed fonts %q", got)
+	}
+	checkList("subdirectory remove", "Gamma")
+
+	// Changes are not detected after the context is canceled.
+	cancel()
+	for deadline := time.Now().Add(5 * time.Second); ; {
+		finder.mu.RLock()
+		watching := finder.watching
+		finder.mu.RUnlock()
+		if !watching {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("cancel: finder is still watching")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	writeFont(filepath.Join(dir, "e.bdf"), "Zeta")
+
+	// Watch again.
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	if err := finder.Watch(ctx); err != nil {
+		t.Fatalf("watch after cancel: %v", err)
+	}
+
+	writeFont(filepath.Join(dir, "f.bdf"), "Eta")
+	checkEvent("watch after cancel", filepath.Join(dir, "f.bdf"), []string{"Eta"}, nil)
+	checkList("watch after cancel", "Eta", "Gamma")
+
+	if errs := finder.Errors(); len(errs) != 0 {
+		t.Errorf("got errors %v", errs)
+	}
+}
+
+func TestWatchUnsupported(t *testing.T) {
+	finder := newTestFinder(t, "-misc-Alpha-medium-r-normal--13-120-75-75-p-70-iso10646-1")
+	if err := finder.Watch(context.Background()); err != ErrWatchUnsupported {
+		t.Errorf("got error %v, want %v", err, ErrWatchUnsupported)
+	}
+}
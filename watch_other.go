@@ -0,0 +1,10 @@
+//go:build !linux
+// +build !linux
+
+package sysfont
+
+import "context"
+
+func (f *Finder) watch(ctx context.Context) error {
+	return ErrWatchUnsupported
+}
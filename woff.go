@@ -0,0 +1,355 @@
+package sysfont
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"io"
+
+	"github.com/andybalholm/brotli"
+)
+
+// Web font container signatures.
+const (
+	woffSignature  = 0x774F4646 // 'wOFF'
+	woff2Signature = 0x774F4632 // 'wOF2'
+)
+
+// FontContainer represents the container format of a font file.
+type FontContainer int
+
+// Font container formats.
+const (
+	// ContainerNone specifies that the font data is not wrapped in a
+	// container format.
+	ContainerNone FontContainer = iota
+
+	// ContainerWOFF specifies that the font data is stored in the Web Open
+	// Font Format (.woff), using zlib compression.
+	ContainerWOFF
+
+	// ContainerWOFF2 specifies that the font data is stored in the Web Open
+	// Font Format 2.0 (.woff2), using Brotli compression.
+	ContainerWOFF2
+
+	// ContainerGzip specifies that the font file is compressed using gzip
+	// (e.g. .pcf.gz).
+	ContainerGzip
+)
+
+// String returns the name of the container format.
+func (c FontContainer) String() string {
+	switch c {
+	case ContainerWOFF:
+		return "woff"
+	case ContainerWOFF2:
+		return "woff2"
+	case ContainerGzip:
+		return "gzip"
+	default:
+		return "none"
+	}
+}
+
+// woffMaxSize is the maximum size of the font data which is decompressed
+// from web fonts in order to identify them.
+const woffMaxSize = 1 << 26
+
+// sfntInfoTables contains the tables used for identifying fonts.
+var sfntInfoTables = []string{"name", "OS/2", "post", "head", "cmap"}
+
+// woff2KnownTags contains the table tags which can be referenced by index in
+// WOFF2 table directories.
+var woff2KnownTags = [63]string{
+	"cmap", "head", "hhea", "hmtx", "maxp", "name", "OS/2", "post",
+	"cvt ", "fpgm", "glyf", "loca", "prep", "CFF ", "VORG", "EBDT",
+	"EBLC", "gasp", "hdmx", "kern", "LTSH", "PCLT", "VDMX", "vhea",
+	"vmtx", "BASE", "GDEF", "GPOS", "GSUB", "EBSC", "JSTF", "MATH",
+	"CBDT", "CBLC", "COLR", "CPAL", "SVG ", "sbix", "acnt", "avar",
+	"bdat", "bloc", "bsln", "cvar", "fdsc", "feat", "fmtx", "fvar",
+	"gvar", "hsty", "just", "lcar", "mort", "morx", "opbd", "prop",
+	"trak", "Zapf", "Silf", "Glat", "Gloc", "Feat", "Sill",
+}
+
+// newWOFFReader returns a reader for the tables of the specified WOFF font,
+// having the specified size. Only the tables used for identifying the font
+// are decompressed.
+func newWOFFReader(r io.ReaderAt, size int64) (*sfntReader, error) {
+	// Read header.
+	var header [44]byte
+	if _, err := r.ReadAt(header[:], 0); err != nil {
+		return nil, errInvalidFont
+	}
+	if binary.BigEndian.Uint32(header[:]) != woffSignature {
+		return nil, errUnsupportedFont
+	}
+	if binary.BigEndian.Uint32(header[4:]) == sfntCollection {
+		return nil, errUnsupportedFont
+	}
+
+	// Read table directory.
+	numTables := int(binary.BigEndian.Uint16(header[12:]))
+	sfntSize := binary.BigEndian.Uint32(header[16:])
+	if 44+int64(numTables)*20 > size {
+		return nil, errInvalidFont
+	}
+
+	records := make([]byte, numTables*20)
+	if _, err := r.ReadAt(records, 44); err != nil {
+		return nil, errInvalidFont
+	}
+
+	// Decompress the required tables into a single buffer.
+	var buf []byte
+	tables := map[string]sfntTable{}
+	for i := 0; i < numTables; i++ {
+		record := records[i*20:]
+
+		tag := string(record[:4])
+		if !isSFNTInfoTable(tag) {
+			continue
+		}
+		offset := int64(binary.BigEndian.Uint32(record[4:]))
+		compLength := binary.BigEndian.Uint32(record[8:])
+		origLength := binary.BigEndian.Uint32(record[12:])
+		if compLength > origLength || origLength > sfntSize ||
+			offset+int64(compLength) > size || len(buf)+int(origLength) > woffMaxSize {
+			return nil, errInvalidFont
+		}
+
+		data := make([]byte, compLength)
+		if _, err := r.ReadAt(data, offset); err != nil {
+			return nil, errInvalidFont
+		}
+		if compLength < origLength {
+			zr, err := zlib.NewReader(bytes.NewReader(data))
+			if err != nil {
+				return nil, errInvalidFont
+			}
+
+			data = make([]byte, origLength)
+			if _, err := io.ReadFull(io.LimitReader(zr, int64(origLength)), data); err != nil {
+				return nil, errInvalidFont
+			}
+		}
+
+		tables[tag] = sfntTable{offset: uint32(len(buf)), length: origLength}
+		buf = append(buf, data...)
+	}
+
+	return &sfntReader{r: bytes.NewReader(buf), size: int64(len(buf)), tables: tables}, nil
+}
+
+// newWOFF2Readers returns a reader for each font contained in the specified
+// WOFF2 file, having the specified size. The compressed font data is
+// decompressed up to the end of the last table used for identifying the
+// fonts. Transformed tables (e.g. glyf, loca) are skipped, as they are not
+// needed for identifying fonts.
+func newWOFF2Readers(r io.ReaderAt, size int64) ([]*sfntReader, error) {
+	// Read header.
+	var header [48]byte
+	if _, err := r.ReadAt(header[:], 0); err != nil {
+		return nil, errInvalidFont
+	}
+	if binary.BigEndian.Uint32(header[:]) != woff2Signature {
+		return nil, errUnsupportedFont
+	}
+	flavor := binary.BigEndian.Uint32(header[4:])
+	length := int64(binary.BigEndian.Uint32(header[8:]))
+	numTables := int(binary.BigEndian.Uint16(header[12:]))
+	sfntSize := binary.BigEndian.Uint32(header[16:])
+	compressedSize := int64(binary.BigEndian.Uint32(header[20:]))
+	if length < 48 || length > size {
+		return nil, errInvalidFont
+	}
+
+	dr := &woff2DirReader{r: io.NewSectionReader(r, 48, length-48)}
+
+	// Read table directory.
+	type woff2Table struct {
+		tag    string
+		offset uint32
+		length uint32
+	}
+
+	var offset uint32
+	tables := make([]woff2Table, numTables)
+	for i := range tables {
+		flags := dr.byte()
+
+		tag := ""
+		if index := flags & 0x3F; index == 0x3F {
+			tag = dr.tag()
+		} else if int(index) < len(woff2KnownTags) {
+			tag = woff2KnownTags[index]
+		}
+
+		// Identify the length of the table in the decompressed stream.
+		// The glyf and loca tables are transformed when the transform
+		// version is 0, while the other tables are transformed when it is
+		// not 0.
+		length := dr.uintBase128()
+		version := flags >> 6
+		if (tag == "glyf" || tag == "loca") == (version == 0) {
+			length = dr.uintBase128()
+		}
+		if dr.err != nil || tag == "" {
+			return nil, errInvalidFont
+		}
+
+		tables[i] = woff2Table{tag: tag, offset: offset, length: length}
+		if offset += length; offset < length {
+			return nil, errInvalidFont
+		}
+	}
+
+	// Read collection directory. Fonts reference tables by index.
+	var fonts [][]int
+	if flavor == sfntCollection {
+		dr.skip(4)
+		numFonts := int(dr.uint255())
+
+		for i := 0; i < numFonts && dr.err == nil; i++ {
+			count := int(dr.uint255())
+			dr.skip(4)
+
+			indices := make([]int, 0, count)
+			for j := 0; j < count && dr.err == nil; j++ {
+				index := int(dr.uint255())
+				if index >= numTables {
+					return nil, errInvalidFont
+				}
+				indices = append(indices, index)
+			}
+
+			fonts = append(fonts, indices)
+		}
+	} else {
+		indices := make([]int, numTables)
+		for i := range indices {
+			indices[i] = i
+		}
+		fonts = append(fonts, indices)
+	}
+	if dr.err != nil || len(fonts) == 0 {
+		return nil, errInvalidFont
+	}
+
+	// Decompress font data up to the end of the last required table.
+	var end uint32
+	for _, table := range tables {
+		if isSFNTInfoTable(table.tag) && table.offset+table.length > end {
+			end = table.offset + table.length
+		}
+	}
+	if end > sfntSize || end > woffMaxSize || 48+dr.offset+compressedSize > length {
+		return nil, errInvalidFont
+	}
+
+	br := brotli.NewReader(io.NewSectionReader(r, 48+dr.offset, compressedSize))
+	data := make([]byte, end)
+	if _, err := io.ReadFull(io.LimitReader(br, int64(end)), data); err != nil {
+		return nil, errInvalidFont
+	}
+
+	// Create a reader for each font.
+	stream := bytes.NewReader(data)
+
+	readers := make([]*sfntReader, 0, len(fonts))
+	for _, indices := range fonts {
+		sr := &sfntReader{r: stream, size: stream.Size(), tables: map[string]sfntTable{}}
+		for _, index := range indices {
+			if table := tables[index]; isSFNTInfoTable(table.tag) {
+				sr.tables[table.tag] = sfntTable{offset: table.offset, length: table.length}
+			}
+		}
+
+		readers = append(readers, sr)
+	}
+
+	return readers, nil
+}
+
+func isSFNTInfoTable(tag string) bool {
+	for _, t := range sfntInfoTables {
+		if t == tag {
+			return true
+		}
+	}
+
+	return false
+}
+
+// woff2DirReader reads the variable length fields of WOFF2 directories.
+// The first error encountered is recorded and subsequent reads return 0.
+type woff2DirReader struct {
+	r      io.ReaderAt
+	offset int64
+	err    error
+}
+
+func (d *woff2DirReader) read(n int) []byte {
+	buf := make([]byte, n)
+	if d.err != nil {
+		return buf
+	}
+
+	if _, err := d.r.ReadAt(buf, d.offset); err != nil {
+		d.err = errInvalidFont
+	}
+	d.offset += int64(n)
+
+	return buf
+}
+
+func (d *woff2DirReader) skip(n int) {
+	d.read(n)
+}
+
+func (d *woff2DirReader) byte() byte {
+	return d.read(1)[0]
+}
+
+func (d *woff2DirReader) tag() string {
+	return string(d.read(4))
+}
+
+// uintBase128 reads a variable length unsigned integer, encoded using up to
+// 5 bytes of 7 bits each.
+func (d *woff2DirReader) uintBase128() uint32 {
+	var value uint32
+	for i := 0; i < 5; i++ {
+		b := d.byte()
+		if d.err != nil {
+			return 0
+		}
+
+		// Leading zeros and overflows are invalid.
+		if i == 0 && b == 0x80 || value&0xFE000000 != 0 {
+			d.err = errInvalidFont
+			return 0
+		}
+
+		value = value<<7 | uint32(b&0x7F)
+		if b&0x80 == 0 {
+			return value
+		}
+	}
+
+	d.err = errInvalidFont
+	return 0
+}
+
+// uint255 reads a variable length unsigned 16-bit integer.
+func (d *woff2DirReader) uint255() uint16 {
+	switch code := d.byte(); code {
+	case 253:
+		return binary.BigEndian.Uint16(d.read(2))
+	case 254:
+		return uint16(d.byte()) + 253*2
+	case 255:
+		return uint16(d.byte()) + 253
+	default:
+		return uint16(code)
+	}
+}
@@ -0,0 +1,473 @@
+package sysfont
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"errors"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+)
+
+// buildWOFF returns a WOFF font containing the specified tables. If compress
+// is true, the tables are compressed using zlib.
+func buildWOFF(flavor uint32, tables map[string][]byte, compress bool) []byte {
+	tags := make([]string, 0, len(tables))
+	for tag := range tables {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+
+	data := make([]byte, 44+len(tags)*20)
+	binary.BigEndian.PutUint32(data, woffSignature)
+	binary.BigEndian.PutUint32(data[4:], flavor)
+	binary.BigEndian.PutUint16(data[12:], uint16(len(tags)))
+
+	sfntSize := 12 + len(tags)*16
+	for i, tag := range tags {
+		table := tables[tag]
+		sfntSize += len(table)
+
+		if compress {
+			var buf bytes.Buffer
+			zw := zlib.NewWriter(&buf)
+			zw.Write(table)
+			zw.Close()
+			if buf.Len() < len(table) {
+				table = buf.Bytes()
+			}
+		}
+
+		record := data[44+i*20:]
+		copy(record, tag)
+		binary.BigEndian.PutUint32(record[4:], uint32(len(data)))
+		binary.BigEndian.PutUint32(record[8:], uint32(len(table)))
+		binary.BigEndian.PutUint32(record[12:], uint32(len(tables[tag])))
+		data = append(data, table...)
+	}
+	binary.BigEndian.PutUint32(data[8:], uint32(len(data)))
+	binary.BigEndian.PutUint32(data[16:], uint32(sfntSize))
+
+	return data
+}
+
+// testWOFF2Table represents a table of a WOFF2 font built for testing. If
+// transformed is true, the table data is stored as transformed data.
+type testWOFF2Table struct {
+	tag         string
+	data        []byte
+	transformed bool
+}
+
+// buildWOFF2 returns a WOFF2 font containing the specified tables. If the
+// flavor is sfntCollection, the fonts contain the indices of their tables.
+func buildWOFF2(flavor uint32, tables []testWOFF2Table, fonts [][]int) []byte {
+	data := make([]byte, 48)
+	binary.BigEndian.PutUint32(data, woff2Signature)
+	binary.BigEndian.PutUint32(data[4:], flavor)
+	binary.BigEndian.PutUint16(data[12:], uint16(len(tables)))
+
+	// Write table directory.
+	var stream []byte
+	for _, table := range tables {
+		index := byte(0x3F)
+		for i, tag := range woff2KnownTags {
+			if tag == table.tag {
+				index = byte(i)
+				break
+			}
+		}
+
+		// Use the null transform for the glyf and loca tables, unless the
+		// table is transformed.
+		isGlyf := table.tag == "glyf" || table.tag == "loca"
+		var version byte
+		if isGlyf != table.transformed {
+			version = 3
+		}
+		data = append(data, version<<6|index)
+		if index == 0x3F {
+			data = append(data, table.tag...)
+		}
+
+		if table.transformed {
+			data = append(data, encodeUintBase128(uint32(len(table.data))+100)...)
+		}
+		data = append(data, encodeUintBase128(uint32(len(table.data)))...)
+		stream = append(stream, table.data...)
+	}
+
+	// Write collection directory.
+	if flavor == sfntCollection {
+		data = append(data, 0, 2, 0, 0)
+		data = append(data, encodeUint255(uint16(len(fonts)))...)
+		for _, indices := range fonts {
+			data = append(data, encodeUint255(uint16(len(indices)))...)
+			data = append(data, 0, 1, 0, 0)
+			for _, index := range indices {
+				data = append(data, encodeUint255(uint16(index))...)
+			}
+		}
+	}
+
+	// Write compressed data.
+	var buf bytes.Buffer
+	bw := brotli.NewWriter(&buf)
+	bw.Write(stream)
+	bw.Close()
+	data = append(data, buf.Bytes()...)
+
+	binary.BigEndian.PutUint32(data[8:], uint32(len(data)))
+	binary.BigEndian.PutUint32(data[16:], uint32(12+len(tables)*16+len(stream)))
+	binary.BigEndian.PutUint32(data[20:], uint32(buf.Len()))
+
+	return data
+}
+
+func encodeUintBase128(value uint32) []byte {
+	data := []byte{byte(value & 0x7F)}
+	for value >>= 7; value > 0; value >>= 7 {
+		data = append([]byte{byte(value&0x7F) | 0x80}, data...)
+	}
+
+	return data
+}
+
+func encodeUint255(value uint16) []byte {
+	switch {
+	case value < 253:
+		return []byte{byte(value)}
+	case value < 506:
+		return []byte{255, byte(value - 253)}
+	case value < 759:
+		return []byte{254, byte(value - 506)}
+	default:
+		return []byte{253, byte(value >> 8), byte(value)}
+	}
+}
+
+// patchUint32 returns a copy of the specified data, having the 32-bit value
+// at the specified offset replaced.
+func patchUint32(data []byte, offset int, value uint32) []byte {
+	data = append([]byte(nil), data...)
+	binary.BigEndian.PutUint32(data[offset:], value)
+
+	return data
+}
+
+func TestReadWOFF(t *testing.T) {
+	tables := map[string][]byte{
+		"name": testNames(map[uint16]string{nameFamily: "Go", nameFull: "Go Bold"}),
+		"OS/2": testOS2(700, 5, 0),
+		"glyf": {0xDE, 0xAD},
+	}
+	name := map[string][]byte{"name": testNames(map[uint16]string{
+		0:          strings.Repeat("Copyright The Go Authors. ", 4),
+		nameFamily: "Go",
+		nameFull:   "Go Bold",
+	})}
+
+	// The name table is the only table of the font, and it is compressed.
+	compressed := buildWOFF(sfntVersionTrueType, name, true)
+	origLength := binary.BigEndian.Uint32(compressed[56:])
+
+	tests := []struct {
+		name   string
+		data   []byte
+		weight int
+		err    error
+	}{
+		{
+			name:   "uncompressed",
+			data:   buildWOFF(sfntVersionTrueType, tables, false),
+			weight: WeightBold,
+		},
+		{
+			name:   "compressed",
+			data:   buildWOFF(sfntVersionOpenType, tables, true),
+			weight: WeightBold,
+		},
+		{
+			name: "collection",
+			data: buildWOFF(sfntCollection, tables, false),
+			err:  errUnsupportedFont,
+		},
+		{
+			name: "truncated header",
+			data: compressed[:30],
+			err:  errInvalidFont,
+		},
+		{
+			name: "truncated directory",
+			data: compressed[:50],
+			err:  errInvalidFont,
+		},
+		{
+			name: "oversized table count",
+			data: func() []byte {
+				data := append([]byte(nil), compressed...)
+				binary.BigEndian.PutUint16(data[12:], 0xFFFF)
+				return data
+			}(),
+			err: errInvalidFont,
+		},
+		{
+			name: "truncated table",
+			data: compressed[:len(compressed)-1],
+			err:  errInvalidFont,
+		},
+		{
+			name: "oversized table offset",
+			data: patchUint32(compressed, 48, 0xFFFFFFF0),
+			err:  errInvalidFont,
+		},
+		{
+			name: "oversized compressed length",
+			data: patchUint32(compressed, 52, 0xFFFFFFF0),
+			err:  errInvalidFont,
+		},
+		{
+			name: "oversized original length",
+			data: patchUint32(patchUint32(compressed, 56, 0xFFFFFFF0), 16, 0xFFFFFFFF),
+			err:  errInvalidFont,
+		},
+		{
+			name: "original length exceeds font size",
+			data: patchUint32(compressed, 16, origLength-1),
+			err:  errInvalidFont,
+		},
+		{
+			name: "short decompressed table",
+			data: patchUint32(patchUint32(compressed, 56, 0x10000), 16, 0x20000),
+			err:  errInvalidFont,
+		},
+		{
+			name: "corrupt compressed table",
+			data: func() []byte {
+				data := append([]byte(nil), compressed...)
+				for i := 64; i < len(data); i++ {
+					data[i] = 0xFF
+				}
+				return data
+			}(),
+			err: errInvalidFont,
+		},
+	}
+
+	for _, test := range tests {
+		fonts, err := readTestFontData(test.data)
+		if !errors.Is(err, test.err) {
+			t.Errorf("%s: got error %v, want %v", test.name, err, test.err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+
+		if len(fonts) != 1 {
+			t.Errorf("%s: got %d fonts, want 1", test.name, len(fonts))
+			continue
+		}
+		font := fonts[0]
+		if font.Family != "Go" || font.Name != "Go Bold" || font.Weight != test.weight {
+			t.Errorf("%s: got font %q %q with weight %d", test.name, font.Family, font.Name, font.Weight)
+		}
+		if font.Container != ContainerWOFF {
+			t.Errorf("%s: got container %s, want %s", test.name, font.Container, ContainerWOFF)
+		}
+	}
+}
+
+func TestReadWOFF2(t *testing.T) {
+	tables := []testWOFF2Table{
+		{tag: "glyf", data: []byte{1, 2, 3, 4}, transformed: true},
+		{tag: "name", data: testNames(map[uint16]string{nameFamily: "Go", nameFull: "Go Bold"})},
+		{tag: "OS/2", data: testOS2(700, 5, 0)},
+		{tag: "zzzz", data: []byte{5, 6}},
+		{tag: "name", data: testNames(map[uint16]string{nameFamily: "Go", nameFull: "Go Mono Bold"})},
+		{tag: "loca", data: []byte{7, 8}},
+	}
+	single := buildWOFF2(sfntVersionTrueType, tables[:4], nil)
+	collection := buildWOFF2(sfntCollection, tables, [][]int{{0, 1, 2}, {2, 3, 4, 5}})
+
+	// The compressed data follows the table directory.
+	compressedSize := binary.BigEndian.Uint32(single[20:])
+	dirEnd := len(single) - int(compressedSize)
+
+	tests := []struct {
+		name  string
+		data  []byte
+		names []string
+		err   error
+	}{
+		{
+			name:  "single font",
+			data:  single,
+			names: []string{"Go Bold"},
+		},
+		{
+			name:  "collection",
+			data:  collection,
+			names: []string{"Go Bold", "Go Mono Bold"},
+		},
+		{
+			name: "truncated header",
+			data: single[:40],
+			err:  errInvalidFont,
+		},
+		{
+			name: "truncated directory",
+			data: single[:50],
+			err:  errInvalidFont,
+		},
+		{
+			name: "truncated data",
+			data: single[:len(single)-1],
+			err:  errInvalidFont,
+		},
+		{
+			name: "oversized length",
+			data: patchUint32(single, 8, 0xFFFFFFF0),
+			err:  errInvalidFont,
+		},
+		{
+			name: "undersized length",
+			data: patchUint32(single, 8, 20),
+			err:  errInvalidFont,
+		},
+		{
+			name: "oversized compressed size",
+			data: patchUint32(single, 20, 0xFFFFFFF0),
+			err:  errInvalidFont,
+		},
+		{
+			name: "tables exceed font size",
+			data: patchUint32(single, 16, 12),
+			err:  errInvalidFont,
+		},
+		{
+			name: "oversized table count",
+			data: func() []byte {
+				data := append([]byte(nil), single...)
+				binary.BigEndian.PutUint16(data[12:], 0xFFFF)
+				return data
+			}(),
+			err: errInvalidFont,
+		},
+		{
+			name: "oversized table length",
+			data: func() []byte {
+				data := append([]byte(nil), single[:48]...)
+				data = append(data, 5, 0x8F, 0xFF, 0xFF, 0xFF, 0x7F)
+				return append(data, single[dirEnd:]...)
+			}(),
+			err: errInvalidFont,
+		},
+		{
+			name: "invalid table index",
+			data: buildWOFF2(sfntCollection, tables[:2], [][]int{{0, 2}}),
+			err:  errInvalidFont,
+		},
+		{
+			name: "empty collection",
+			data: buildWOFF2(sfntCollection, tables[:2], nil),
+			err:  errInvalidFont,
+		},
+		{
+			name: "corrupt compressed data",
+			data: func() []byte {
+				data := append([]byte(nil), single...)
+				for i := dirEnd; i < len(data); i++ {
+					data[i] = 0xFF
+				}
+				return data
+			}(),
+			err: errInvalidFont,
+		},
+	}
+
+	for _, test := range tests {
+		fonts, err := readTestFontData(test.data)
+		if !errors.Is(err, test.err) {
+			t.Errorf("%s: got error %v, want %v", test.name, err, test.err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+
+		if len(fonts) != len(test.names) {
+			t.Errorf("%s: got %d fonts, want %d", test.name, len(fonts), len(test.names))
+			continue
+		}
+		for i, font := range fonts {
+			if font.Name != test.names[i] || font.Weight != WeightBold || font.Index != i {
+				t.Errorf("%s: got font %d %q with weight %d, want %q", test.name, font.Index, font.Name, font.Weight, test.names[i])
+			}
+			if font.Container != ContainerWOFF2 {
+				t.Errorf("%s: got container %s, want %s", test.name, font.Container, ContainerWOFF2)
+			}
+		}
+	}
+}
+
+func TestWOFF2DirReader(t *testing.T) {
+	tests := []struct {
+		name  string
+		data  []byte
+		read  func(d *woff2DirReader) uint32
+		value uint32
+		err   error
+	}{
+		{"base128 single byte", []byte{0x3F}, readUintBase128, 63, nil},
+		{"base128 two bytes", []byte{0x81, 0x00}, readUintBase128, 128, nil},
+		{"base128 maximum", []byte{0x8F, 0xFF, 0xFF, 0xFF, 0x7F}, readUintBase128, 0xFFFFFFFF, nil},
+		{"base128 leading zero", []byte{0x80, 0x01}, readUintBase128, 0, errInvalidFont},
+		{"base128 overflow", []byte{0x90, 0x80, 0x80, 0x80, 0x00}, readUintBase128, 0, errInvalidFont},
+		{"base128 too long", []byte{0x81, 0x81, 0x81, 0x81, 0x81, 0x01}, readUintBase128, 0, errInvalidFont},
+		{"base128 truncated", []byte{0x81}, readUintBase128, 0, errInvalidFont},
+		{"uint255 single byte", []byte{252}, readUint255, 252, nil},
+		{"uint255 one more byte", []byte{255, 2}, readUint255, 255, nil},
+		{"uint255 two more bytes", []byte{254, 2}, readUint255, 508, nil},
+		{"uint255 word", []byte{253, 0x12, 0x34}, readUint255, 0x1234, nil},
+		{"uint255 truncated", []byte{253, 0x12}, readUint255, 0, errInvalidFont},
+		{"uint255 empty", nil, readUint255, 0, errInvalidFont},
+	}
+
+	for _, test := range tests {
+		d := &woff2DirReader{r: bytes.NewReader(test.data)}
+		value := test.read(d)
+		if !errors.Is(d.err, test.err) {
+			t.Errorf("%s: got error %v, want %v", test.name, d.err, test.err)
+			continue
+		}
+		if d.err == nil && value != test.value {
+			t.Errorf("%s: got %d, want %d", test.name, value, test.value)
+		}
+	}
+
+	// Check the encoders used for building test fonts.
+	for _, value := range []uint32{0, 127, 128, 0x3FFF, 0x4000, 0xFFFFFFFF} {
+		d := &woff2DirReader{r: bytes.NewReader(encodeUintBase128(value))}
+		if got := d.uintBase128(); got != value || d.err != nil {
+			t.Errorf("uintBase128 round trip of %d: got %d, error %v", value, got, d.err)
+		}
+	}
+	for _, value := range []uint16{0, 252, 253, 505, 506, 758, 759, 0xFFFF} {
+		d := &woff2DirReader{r: bytes.NewReader(encodeUint255(value))}
+		if got := d.uint255(); got != value || d.err != nil {
+			t.Errorf("uint255 round trip of %d: got %d, error %v", value, got, d.err)
+		}
+	}
+}
+
+func readUintBase128(d *woff2DirReader) uint32 {
+	return d.uintBase128()
+}
+
+func readUint255(d *woff2DirReader) uint32 {
+	return uint32(d.uint255())
+}
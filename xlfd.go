@@ -0,0 +1,492 @@
+package sysfont
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// X logical font description fields
+// (e.g. -misc-fixed-medium-r-normal--13-120-75-75-c-70-iso8859-1).
+const (
+	xlfdFoundry = iota
+	xlfdFamily
+	xlfdWeight
+	xlfdSlant
+	xlfdSetwidth
+	xlfdAddStyle
+	xlfdPixelSize
+	xlfdPointSize
+	xlfdResolutionX
+	xlfdResolutionY
+	xlfdSpacing
+	xlfdAverageWidth
+	xlfdCharsetRegistry
+	xlfdCharsetEncoding
+	xlfdFieldCount
+)
+
+// xlfdProperties contains the X11 font properties corresponding to the
+// fields of X logical font descriptions.
+var xlfdProperties = [xlfdFieldCount]string{
+	"FOUNDRY", "FAMILY_NAME", "WEIGHT_NAME", "SLANT", "SETWIDTH_NAME",
+	"ADD_STYLE_NAME", "PIXEL_SIZE", "POINT_SIZE", "RESOLUTION_X",
+	"RESOLUTION_Y", "SPACING", "AVERAGE_WIDTH", "CHARSET_REGISTRY",
+	"CHARSET_ENCODING",
+}
+
+// Files which define the X11 font names and aliases of font directories.
+const (
+	fontsDirFile   = "fonts.dir"
+	fontsAliasFile = "fonts.alias"
+)
+
+// splitXLFD splits the specified X logical font description into fields.
+// The second return value is false if the name is not a valid description.
+func splitXLFD(name string) ([xlfdFieldCount]string, bool) {
+	var fields [xlfdFieldCount]string
+	if !strings.HasPrefix(name, "-") {
+		return fields, false
+	}
+
+	parts := strings.Split(name[1:], "-")
+	if len(parts) != xlfdFieldCount {
+		return fields, false
+	}
+	copy(fields[:], parts)
+
+	return fields, true
+}
+
+// parseXLFDQuery parses the specified X logical font description (e.g.
+// -adobe-helvetica-bold-r-normal--12-*-*-*-p-*-iso8859-1) into a query.
+// The family, weight, slant, setwidth, pixel size and character set fields
+// are used. Fields containing wildcards are not taken into account, except
+// for the family and character set fields, which are used as patterns. A
+// field consisting of a single * can span multiple fields, as with X
+// servers (e.g. -*-fixed-bold-*). The second return value is false if the
+// query is not an X logical font description.
+func parseXLFDQuery(query string) (Query, bool) {
+	fields, ok := expandXLFD(strings.TrimSpace(query))
+	if !ok {
+		return Query{}, false
+	}
+
+	unset := func(field string) bool {
+		return field == "" || isXLFDPattern(field)
+	}
+
+	var q Query
+	if family := fields[xlfdFamily]; family != "" {
+		q.Family = []string{family}
+	} else {
+		q.Family = []string{"*"}
+	}
+	if field := fields[xlfdWeight]; !unset(field) {
+		q.Weight = parseXLFDWeight(field)
+	}
+	if field := fields[xlfdSlant]; !unset(field) {
+		q.Style = parseXLFDSlant(field)
+	}
+	if field := fields[xlfdSetwidth]; !unset(field) {
+		q.Stretch = parseXLFDStretch(field)
+	}
+
+	// Identify pixel size. If it is not specified, it is calculated based on
+	// the point size, expressed in tenths of a point, and the resolution.
+	if field := fields[xlfdPixelSize]; !unset(field) {
+		q.PixelSize, _ = strconv.Atoi(field)
+	} else if !unset(fields[xlfdPointSize]) && !unset(fields[xlfdResolutionY]) {
+		pointSize, _ := strconv.Atoi(fields[xlfdPointSize])
+		resolution, _ := strconv.Atoi(fields[xlfdResolutionY])
+		q.PixelSize = (pointSize*resolution + 360) / 720
+	}
+
+	// Identify character set.
+	registry, encoding := fields[xlfdCharsetRegistry], fields[xlfdCharsetEncoding]
+	if registry != "*" || encoding != "*" {
+		q.Charset = registry + "-" + encoding
+	}
+
+	return q, true
+}
+
+// expandXLFD splits the specified X logical font description into fields.
+// Unlike splitXLFD, it accepts descriptions with fewer fields, if one of
+// them consists of a single * (e.g. -*-helvetica-*-iso8859-1). The last
+// such field spans the missing fields, while the fields around it keep their
+// positions relative to the start and the end of the description.
+func expandXLFD(name string) ([xlfdFieldCount]string, bool) {
+	var fields [xlfdFieldCount]string
+	if len(name) < 2 || name[0] != '-' {
+		return fields, false
+	}
+
+	parts := strings.Split(name[1:], "-")
+	if len(parts) == xlfdFieldCount {
+		copy(fields[:], parts)
+		return fields, true
+	}
+	if len(parts) > xlfdFieldCount {
+		return fields, false
+	}
+
+	span := -1
+	for i, part := range parts {
+		if part == "*" {
+			span = i
+		}
+	}
+	if span < 0 {
+		return fields, false
+	}
+
+	for i := range fields {
+		fields[i] = "*"
+	}
+	copy(fields[:], parts[:span])
+	copy(fields[xlfdFieldCount-(len(parts)-span-1):], parts[span+1:])
+
+	return fields, true
+}
+
+// xlfdCharset returns the character set of the specified X logical font
+// description, as registry and encoding (e.g. iso8859-1). If the name is
+// not a valid description, an empty string is returned.
+func xlfdCharset(name string) string {
+	fields, ok := splitXLFD(name)
+	if !ok || fields[xlfdCharsetRegistry] == "" {
+		return ""
+	}
+
+	return fields[xlfdCharsetRegistry] + "-" + fields[xlfdCharsetEncoding]
+}
+
+// isXLFDPattern returns true if the specified string contains the * or ?
+// wildcards.
+func isXLFDPattern(s string) bool {
+	return strings.ContainsAny(s, "*?")
+}
+
+// formatXLFD returns the X logical font description composed of the
+// specified fields.
+func formatXLFD(fields [xlfdFieldCount]string) string {
+	return "-" + strings.Join(fields[:], "-")
+}
+
+// parseXLFDWeight converts the specified X11 weight name to a font weight.
+// In X11 font names, medium designates the normal weight.
+func parseXLFDWeight(name string) int {
+	weight := WeightNormal
+	for _, word := range splitStyleWords(name) {
+		if word == "medium" {
+			continue
+		}
+		if value, ok := fontWeights[word]; ok {
+			weight = value
+		}
+	}
+
+	return weight
+}
+
+// parseXLFDStretch converts the specified X11 setwidth name to a font
+// stretch.
+func parseXLFDStretch(name string) int {
+	stretch := StretchNormal
+	for _, word := range splitStyleWords(name) {
+		if value, ok := fontStretches[word]; ok {
+			stretch = value
+		}
+	}
+
+	return stretch
+}
+
+// parseXLFDSlant converts the specified X11 slant code (e.g. r, i, o) to a
+// font style. Reverse slants are treated as their regular counterparts.
+func parseXLFDSlant(code string) Style {
+	switch strings.ToLower(code) {
+	case "i", "ri":
+		return StyleItalic
+	case "o", "ro":
+		return StyleOblique
+	default:
+		return StyleNormal
+	}
+}
+
+// matchXLFDPattern returns true if the specified X11 font name matches the
+// specified pattern. Patterns are case-insensitive and can contain the *
+// (any sequence of characters) and ? (any single character) wildcards.
+func matchXLFDPattern(pattern, name string) bool {
+	pattern, name = strings.ToLower(pattern), strings.ToLower(name)
+
+	// Backtrack to the last star when a mismatch occurs.
+	var p, n int
+	star, next := -1, 0
+	for n < len(name) {
+		switch {
+		case p < len(pattern) && (pattern[p] == '?' || pattern[p] == name[n]):
+			p++
+			n++
+		case p < len(pattern) && pattern[p] == '*':
+			star, next = p, n
+			p++
+		case star >= 0:
+			next++
+			p, n = star+1, next
+		default:
+			return false
+		}
+	}
+	for p < len(pattern) && pattern[p] == '*' {
+		p++
+	}
+
+	return p == len(pattern)
+}
+
+// xfontAlias represents an entry of a fonts.alias file.
+type xfontAlias struct {
+	alias  string
+	target string
+}
+
+// readXFontFiles assigns the X11 font names and aliases defined by the
+// specified fonts.dir and fonts.alias files to the fonts of the finder.
+// Names defined in fonts.dir files take precedence over the names stored in
+// the font files, as with X servers. Aliases are assigned to the first font
+// whose name or alias matches the aliased name.
+func (f *Finder) readXFontFiles(filenames []string) {
+	// Assign the names defined in fonts.dir files and collect the aliases
+	// defined in fonts.alias files.
+	var aliases []*xfontAlias
+	for _, filename := range filenames {
+		dir, base := filepath.Split(filename)
+		if f.fsys != nil {
+			dir, base = path.Split(filename)
+		}
+
+		switch base {
+		case fontsDirFile:
+			names, err := f.readFontsDir(filename)
+			if err != nil {
+				f.errors = append(f.errors, err)
+				continue
+			}
+
+			for _, font := range f.fonts {
+				if !strings.HasPrefix(font.Filename, dir) {
+					continue
+				}
+				if name, ok := names[font.Filename[len(dir):]]; ok {
+					font.XLFD = name
+				}
+			}
+		case fontsAliasFile:
+			dirAliases, fileNames, err := f.readFontsAlias(filename)
+			if err != nil {
+				f.errors = append(f.errors, err)
+				continue
+			}
+			aliases = append(aliases, dirAliases...)
+
+			// Use the names of the font files as aliases, if requested.
+			if !fileNames {
+				continue
+			}
+			for _, font := range f.fonts {
+				if !strings.HasPrefix(font.Filename, dir) {
+					continue
+				}
+
+				name := font.Filename[len(dir):]
+				if strings.EqualFold(filepath.Ext(name), ".gz") {
+					name = strings.TrimSuffix(name, filepath.Ext(name))
+				}
+				font.Aliases = append(font.Aliases, strings.TrimSuffix(name, filepath.Ext(name)))
+			}
+		}
+	}
+
+	// Assign aliases. Aliases can refer to other aliases, regardless of the
+	// order in which they are defined, so the unresolved aliases are
+	// assigned repeatedly, until none of them can be resolved.
+	for len(aliases) > 0 {
+		var unresolved []*xfontAlias
+		for _, alias := range aliases {
+			var resolved bool
+			for _, font := range f.fonts {
+				if font.matchesXFontName(alias.target) {
+					font.Aliases = append(font.Aliases, alias.alias)
+					resolved = true
+					break
+				}
+			}
+			if !resolved {
+				unresolved = append(unresolved, alias)
+			}
+		}
+		if len(unresolved) == len(aliases) {
+			break
+		}
+
+		aliases = unresolved
+	}
+}
+
+// readFontsDir returns the X11 font names defined by the specified
+// fonts.dir file, indexed by the names of the font files. The first line of
+// the file contains the number of entries, while each of the following lines
+// contains a filename and a font name.
+func (f *Finder) readFontsDir(filename string) (map[string]string, error) {
+	names := map[string]string{}
+	err := f.readXFontFile(filename, func(line string, first bool) {
+		if first {
+			return
+		}
+
+		fields := strings.SplitN(line, " ", 2)
+		if len(fields) != 2 {
+			return
+		}
+		if _, ok := names[fields[0]]; !ok {
+			names[fields[0]] = strings.TrimSpace(fields[1])
+		}
+	})
+
+	return names, err
+}
+
+// readFontsAlias returns the aliases defined by the specified fonts.alias
+// file. Each line of the file contains an alias and the aliased font name,
+// either of which can be quoted. Lines starting with ! are comments. The
+// second return value specifies whether the names of the font files in the
+// directory should also be used as aliases.
+func (f *Finder) readFontsAlias(filename string) ([]*xfontAlias, bool, error) {
+	var aliases []*xfontAlias
+	var fileNames bool
+	err := f.readXFontFile(filename, func(line string, first bool) {
+		if strings.HasPrefix(line, "!") {
+			return
+		}
+		if line == "FILE_NAMES_ALIASES" {
+			fileNames = true
+			return
+		}
+
+		alias, rest := readXFontAliasField(line)
+		target, _ := readXFontAliasField(rest)
+		if alias != "" && target != "" {
+			aliases = append(aliases, &xfontAlias{alias: alias, target: target})
+		}
+	})
+
+	return aliases, fileNames, err
+}
+
+// readXFontFile calls the specified function for each non-empty line of the
+// specified file. Leading and trailing whitespace is removed from the lines.
+func (f *Finder) readXFontFile(filename string, fn func(line string, first bool)) error {
+	var r io.ReadCloser
+	var err error
+	if f.fsys == nil {
+		r, err = os.Open(filename)
+	} else {
+		r, err = f.fsys.Open(filename)
+	}
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	first := true
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			fn(line, first)
+			first = false
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return &os.PathError{Op: "read", Path: filename, Err: err}
+	}
+
+	return nil
+}
+
+// readXFontAliasField reads the first field of the specified fonts.alias
+// line. Fields are separated by whitespace, unless they are quoted.
+// Backslashes escape the character which follows them. The field and the
+// remainder of the line are returned.
+func readXFontAliasField(line string) (string, string) {
+	line = strings.TrimSpace(line)
+
+	var field []byte
+	var quoted bool
+	for i := 0; i < len(line); i++ {
+		switch c := line[i]; {
+		case c == '\\' && i+1 < len(line):
+			i++
+			field = append(field, line[i])
+		case c == '"':
+			quoted = !quoted
+		case !quoted && (c == ' ' || c == '\t'):
+			return string(field), line[i:]
+		default:
+			field = append(field, c)
+		}
+	}
+
+	return string(field), ""
+}
+
+// matchesXFontName returns true if the X11 font name or one of the aliases
+// of the font match the specified name. The name can contain wildcards.
+func (f *Font) matchesXFontName(name string) bool {
+	if f.XLFD != "" && matchXLFDPattern(name, f.XLFD) {
+		return true
+	}
+	for _, alias := range f.Aliases {
+		if matchXLFDPattern(name, alias) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchXFontName returns the first font of the finder whose X11 font name
+// or aliases match the specified name, in the same way as X servers
+// resolve font names. Matched fonts have the maximum combined score. If no
+// font matches, nil is returned.
+func (f *Finder) matchXFontName(name string) *MatchResult {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil
+	}
+
+	for _, font := range f.fonts {
+		if font.matchesXFontName(name) {
+			return &MatchResult{Font: font, Source: SourceExact, Score: 2}
+		}
+	}
+
+	return nil
+}
+
+// isXFontFile returns true if the specified file defines X11 font names or
+// aliases.
+func isXFontFile(filename string) bool {
+	switch filepath.Base(filename) {
+	case fontsDirFile, fontsAliasFile:
+		return true
+	}
+
+	return false
+}
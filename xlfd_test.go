@@ -0,0 +1,324 @@
+package sysfont
+
+import (
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+func TestMatchXLFDPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		name    string
+		match   bool
+	}{
+		{"fixed", "fixed", true},
+		{"FIXED", "Fixed", true},
+		{"fixed", "fixed2", false},
+		{"fix?d", "fixed", true},
+		{"fix?d", "fixd", false},
+		{"*", "", true},
+		{"", "", true},
+		{"", "fixed", false},
+		{"-misc-fixed-*", "-misc-fixed-medium-r-normal--13-120-75-75-c-70-iso8859-1", true},
+		{"-*-fixed-*-iso8859-1", "-misc-fixed-medium-r-normal--13-120-75-75-c-70-iso8859-1", true},
+		{"-*-fixed-*-iso8859-1", "-misc-fixed-medium-r-normal--13-120-75-75-c-70-iso10646-1", false},
+		{"-*-*-bold-*", "-misc-fixed-medium-r-normal--13-120-75-75-c-70-iso8859-1", false},
+		{"*a*b*c", "xaxbxbxc", true},
+		{"*a*b*c", "xaxbxcx", false},
+		{"**", "fixed", true},
+	}
+
+	for _, test := range tests {
+		if match := matchXLFDPattern(test.pattern, test.name); match != test.match {
+			t.Errorf("matchXLFDPattern(%q, %q): got %t, want %t", test.pattern, test.name, match, test.match)
+		}
+	}
+}
+
+func TestSplitXLFD(t *testing.T) {
+	tests := []struct {
+		name    string
+		ok      bool
+		charset string
+	}{
+		{"-misc-fixed-medium-r-normal--13-120-75-75-c-70-iso8859-1", true, "iso8859-1"},
+		{"-misc-fixed-medium-r-normal--13-120-75-75-c-70--", true, ""},
+		{"misc-fixed-medium-r-normal--13-120-75-75-c-70-iso8859-1", false, ""},
+		{"-misc-fixed-medium-r-normal--13-120-75-75-c-70-iso8859", false, ""},
+		{"-misc-fixed-medium-r-normal--13-120-75-75-c-70-iso8859-1-x", false, ""},
+		{"fixed", false, ""},
+	}
+
+	for _, test := range tests {
+		if _, ok := splitXLFD(test.name); ok != test.ok {
+			t.Errorf("splitXLFD(%q): got %t, want %t", test.name, ok, test.ok)
+		}
+		if charset := xlfdCharset(test.name); charset != test.charset {
+			t.Errorf("xlfdCharset(%q): got %q, want %q", test.name, charset, test.charset)
+		}
+	}
+}
+
+func TestXLFDStyle(t *testing.T) {
+	tests := []struct {
+		weight   string
+		setwidth string
+		slant    string
+		font     *Font
+	}{
+		{"medium", "normal", "r", &Font{Weight: WeightNormal, Stretch: StretchNormal, Style: StyleNormal}},
+		{"bold", "semicondensed", "i", &Font{Weight: WeightBold, Stretch: StretchSemiCondensed, Style: StyleItalic}},
+		{"demi bold", "narrow", "O", &Font{Weight: WeightSemiBold, Stretch: StretchCondensed, Style: StyleOblique}},
+		{"light", "wide", "ri", &Font{Weight: WeightLight, Stretch: StretchExpanded, Style: StyleItalic}},
+		{"", "", "ro", &Font{Weight: WeightNormal, Stretch: StretchNormal, Style: StyleOblique}},
+		{"unknown", "unknown", "ot", &Font{Weight: WeightNormal, Stretch: StretchNormal, Style: StyleNormal}},
+	}
+
+	for _, test := range tests {
+		font := &Font{
+			Weight:  parseXLFDWeight(test.weight),
+			Stretch: parseXLFDStretch(test.setwidth),
+			Style:   parseXLFDSlant(test.slant),
+		}
+		if !reflect.DeepEqual(font, test.font) {
+			t.Errorf("%q %q %q: got weight %d, stretch %d and style %v", test.weight, test.setwidth, test.slant, font.Weight, font.Stretch, font.Style)
+		}
+	}
+}
+
+func TestReadXFontAliasField(t *testing.T) {
+	tests := []struct {
+		line  string
+		field string
+		rest  string
+	}{
+		{"fixed -misc-fixed-*", "fixed", " -misc-fixed-*"},
+		{"  fixed\t-misc-fixed-*", "fixed", "\t-misc-fixed-*"},
+		{`"bold fixed" "-misc-fixed-bold-*"`, "bold fixed", ` "-misc-fixed-bold-*"`},
+		{`escaped\ name\"s fixed`, `escaped name"s`, " fixed"},
+		{`"unterminated field`, "unterminated field", ""},
+		{`trailing\`, `trailing\`, ""},
+		{"", "", ""},
+	}
+
+	for _, test := range tests {
+		field, rest := readXFontAliasField(test.line)
+		if field != test.field || rest != test.rest {
+			t.Errorf("%q: got %q and %q, want %q and %q", test.line, field, rest, test.field, test.rest)
+		}
+	}
+}
+
+func TestReadXFontFiles(t *testing.T) {
+	bdf := func(name string) *fstest.MapFile {
+		return &fstest.MapFile{Data: []byte("STARTFONT 2.1\nFONT " + name + "\nCHARS 0\nENDFONT\n")}
+	}
+
+	fsys := fstest.MapFS{
+		"misc/6x13.bdf":     bdf("-misc-fixed-medium-r-semicondensed--13-120-75-75-c-60-iso8859-1"),
+		"misc/7x13B.pcf.gz": {Data: gzipData(bdf("-misc-fixed-bold-r-normal--13-120-75-75-c-70-iso8859-1").Data)},
+		"misc/fonts.dir": {Data: []byte(`3
+6x13.bdf -misc-fixed-medium-r-semicondensed--13-120-75-75-c-60-iso8859-1
+7x13B.pcf.gz   -Misc-Fixed-Bold-R-Normal--13-120-75-75-C-70-ISO8859-1
+invalid
+7x13B.pcf.gz -ignored-fixed-bold-r-normal--13-120-75-75-c-70-iso8859-1
+`)},
+		"misc/fonts.alias": {Data: []byte(`! X11 font aliases.
+FILE_NAMES_ALIASES
+fixed        -misc-fixed-medium-r-semicondensed--13-*
+"bold fixed" "-misc-fixed-bold-*"
+chain        second
+second       "bold fixed"
+escaped\ name fixed
+missing      -nonexistent-*
+invalid
+`)},
+		"other/9x15.bdf": bdf("-misc-fixed-medium-r-normal--15-140-75-75-c-90-iso8859-1"),
+	}
+
+	finder := NewFinder(&FinderOpts{FS: fsys, Extensions: []string{".bdf", ".pcf.gz"}})
+	if errs := finder.Errors(); len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+
+	tests := []struct {
+		file    string
+		xlfd    string
+		aliases []string
+	}{
+		{
+			file:    "misc/6x13.bdf",
+			xlfd:    "-misc-fixed-medium-r-semicondensed--13-120-75-75-c-60-iso8859-1",
+			aliases: []string{"6x13", "fixed", "escaped name"},
+		},
+		{
+			file:    "misc/7x13B.pcf.gz",
+			xlfd:    "-Misc-Fixed-Bold-R-Normal--13-120-75-75-C-70-ISO8859-1",
+			aliases: []string{"7x13B", "bold fixed", "second", "chain"},
+		},
+		{
+			file: "other/9x15.bdf",
+			xlfd: "-misc-fixed-medium-r-normal--15-140-75-75-c-90-iso8859-1",
+		},
+	}
+
+	fonts := map[string]*Font{}
+	for _, font := range finder.List() {
+		fonts[font.Filename] = font
+	}
+	for _, test := range tests {
+		font := fonts[test.file]
+		if font == nil {
+			t.Errorf("%s: font not found", test.file)
+			continue
+		}
+		if font.XLFD != test.xlfd {
+			t.Errorf("%s: got name %q, want %q", test.file, font.XLFD, test.xlfd)
+		}
+		if !reflect.DeepEqual(font.Aliases, test.aliases) {
+			t.Errorf("%s: got aliases %q, want %q", test.file, font.Aliases, test.aliases)
+		}
+	}
+
+	// Check font name matching.
+	matchTests := []struct {
+		name string
+		file string
+	}{
+		{"fixed", "misc/6x13.bdf"},
+		{"CHAIN", "misc/7x13B.pcf.gz"},
+		{"-misc-fixed-bold-r-*", "misc/7x13B.pcf.gz"},
+		{"-*-*-medium-r-normal--15-*", "other/9x15.bdf"},
+		{"7x13?", "misc/7x13B.pcf.gz"},
+		{"missing", ""},
+		{" ", ""},
+	}
+	for _, test := range matchTests {
+		var file string
+		if result := finder.matchXFontName(test.name); result != nil {
+			file = result.Font.Filename
+		}
+		if file != test.file {
+			t.Errorf("matchXFontName(%q): got %q, want %q", test.name, file, test.file)
+		}
+	}
+}
+
+func TestExpandXLFD(t *testing.T) {
+	tests := []struct {
+		name   string
+		fields string
+		ok     bool
+	}{
+		{
+			name:   "-misc-fixed-medium-r-normal--13-120-75-75-c-70-iso8859-1",
+			fields: "-misc-fixed-medium-r-normal--13-120-75-75-c-70-iso8859-1",
+			ok:     true,
+		},
+		{
+			name:   "-*-fixed-bold-*",
+			fields: "-*-fixed-bold-*-*-*-*-*-*-*-*-*-*-*",
+			ok:     true,
+		},
+		{
+			name:   "-*-helvetica-*-iso8859-1",
+			fields: "-*-helvetica-*-*-*-*-*-*-*-*-*-*-iso8859-1",
+			ok:     true,
+		},
+		{
+			name:   "-misc-*-bold-*-75-*-iso10646-1",
+			fields: "-misc-*-bold-*-75-*-*-*-*-*-*-*-iso10646-1",
+			ok:     true,
+		},
+		{
+			name:   "-*",
+			fields: "-*-*-*-*-*-*-*-*-*-*-*-*-*-*",
+			ok:     true,
+		},
+		{name: "-misc-fixed-bold"},
+		{name: "-misc-fixed-medium-r-normal--13-120-75-75-c-70-iso8859-1-*"},
+		{name: "-"},
+		{name: "fixed"},
+		{name: ""},
+	}
+
+	for _, test := range tests {
+		fields, ok := expandXLFD(test.name)
+		if ok != test.ok {
+			t.Errorf("%q: got %t, want %t", test.name, ok, test.ok)
+			continue
+		}
+		if ok && formatXLFD(fields) != test.fields {
+			t.Errorf("%q: got fields %q, want %q", test.name, formatXLFD(fields), test.fields)
+		}
+	}
+}
+
+func TestParseXLFDQuery(t *testing.T) {
+	tests := []struct {
+		query string
+		want  Query
+		ok    bool
+	}{
+		{
+			query: "-misc-fixed-bold-r-normal--13-120-75-75-c-70-iso8859-1",
+			want: Query{
+				Family:    []string{"fixed"},
+				Weight:    WeightBold,
+				Style:     StyleNormal,
+				Stretch:   StretchNormal,
+				PixelSize: 13,
+				Charset:   "iso8859-1",
+			},
+			ok: true,
+		},
+		{
+			query: "-*-fixed-medium-i-semicondensed--*-120-*-*-*-*-*-*",
+			want: Query{
+				Family:  []string{"fixed"},
+				Weight:  WeightNormal,
+				Style:   StyleItalic,
+				Stretch: StretchSemiCondensed,
+			},
+			ok: true,
+		},
+		{
+			query: "-adobe-helvetica-*-o-*--*-140-75-75-*-*-iso10646-*",
+			want: Query{
+				Family:    []string{"helvetica"},
+				Style:     StyleOblique,
+				PixelSize: 15,
+				Charset:   "iso10646-*",
+			},
+			ok: true,
+		},
+		{
+			query: "  -*-helv*-bold-*  ",
+			want:  Query{Family: []string{"helv*"}, Weight: WeightBold},
+			ok:    true,
+		},
+		{
+			query: "-misc--b?ld-r-normal--1?-*-iso8859-1",
+			want:  Query{Family: []string{"*"}, Style: StyleNormal, Stretch: StretchNormal, Charset: "iso8859-1"},
+			ok:    true,
+		},
+		{query: "-misc-fixed-bold"},
+		{query: "DejaVu Sans Bold"},
+		{query: "DejaVu Sans:weight=bold"},
+	}
+
+	for _, test := range tests {
+		query, ok := parseXLFDQuery(test.query)
+		if ok != test.ok {
+			t.Errorf("%q: got %t, want %t", test.query, ok, test.ok)
+			continue
+		}
+		if !reflect.DeepEqual(query, test.want) {
+			t.Errorf("%q: got query %+v, want %+v", test.query, query, test.want)
+		}
+		if ok && !reflect.DeepEqual(parseQuery(test.query), test.want) {
+			t.Errorf("%q: got parsed query %+v, want %+v", test.query, parseQuery(test.query), test.want)
+		}
+	}
+}